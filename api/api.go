@@ -61,18 +61,23 @@ func (c server) handlers() {
 }
 
 func (c server) v1() {
-	authHandler := v1.NewAuthHandler(c.service.AuthService(), c.service.UserService(), c.service.ActivationTokenService(), c.service.PasswordResetTokenService(), c.infra)
-	userHandler := v1.NewUserHandler(c.service.UserService(), c.service.ActivationTokenService(), c.infra, c.middleware)
+	authHandler := v1.NewAuthHandler(c.service.AuthService(), c.service.UserService(), c.service.ActivationTokenService(), c.service.PasswordResetTokenService(), c.service.Notifier(), c.service.WebhookDispatcher(), c.infra)
+	userHandler := v1.NewUserHandler(c.service.UserService(), c.service.ActivationTokenService(), c.service.Notifier(), c.service.WebhookDispatcher(), c.infra, c.middleware)
 	dashboardHandler := v1.NewDashboardHandler(c.service.DashboardService(), c.infra, c.middleware)
 	profileHandler := v1.NewProfileHandler(
 		c.service.UserService(),
 		c.service.StudentService(),
 		c.service.TeacherService(),
 		c.service.ActivationTokenService(),
+		c.service.GuardianService(),
+		c.service.NotificationService(),
 		c.infra, c.middleware,
 	)
+	guardianHandler := v1.NewGuardianHandler(c.service.GuardianService(), c.infra, c.middleware)
 	subjectHandler := v1.NewSubjectHandler(c.service.SubjectService(), c.infra, c.middleware)
 	facultyHandler := v1.NewFacultyHandler(c.service.FacultyService(), c.infra, c.middleware)
+	academicCalendarHandler := v1.NewAcademicCalendarHandler(c.service.AcademicCalendarService(), c.service.FacultyService(), c.infra, c.middleware)
+	roomHandler := v1.NewRoomHandler(c.service.RoomService(), c.service.GeofenceZoneService(), c.infra, c.middleware)
 	majorHandler := v1.NewMajorHandler(c.service.MajorService(), c.infra, c.middleware)
 	studyProgramHandler := v1.NewStudyProgramHandler(c.service.StudyProgramService(), c.infra, c.middleware)
 	scheduleHandler := v1.NewScheduleHandler(
@@ -80,11 +85,38 @@ func (c server) v1() {
 		c.service.SubjectService(),
 		c.service.UserScheduleService(),
 		c.service.DailyScheduleService(),
+		c.service.AttendanceService(),
+		c.service.GeofenceZoneService(),
+		c.service.RoomService(),
+		c.service.ScheduleExceptionService(),
+		c.service.SessionService(),
+		c.infra,
+		c.middleware,
+	)
+	scheduleExceptionHandler := v1.NewScheduleExceptionHandler(
+		c.service.ScheduleExceptionService(),
+		c.service.ScheduleService(),
+		c.service.DailyScheduleService(),
+		c.service.RoomService(),
+		c.service.SessionService(),
+		c.service.UserScheduleService(),
+		c.service.Notifier(),
+		c.infra,
+		c.middleware,
+	)
+	dailyScheduleHandler := v1.NewDailyScheduleHandler(c.service.DailyScheduleService(), c.service.ScheduleService(), c.service.SessionService(), c.infra, c.middleware)
+	lectureJournalHandler := v1.NewLectureJournalHandler(
+		c.service.LectureJournalService(),
+		c.service.ScheduleService(),
+		c.service.DailyScheduleService(),
+		c.service.SessionService(),
 		c.infra,
 		c.middleware,
 	)
-	dailyScheduleHandler := v1.NewDailyScheduleHandler(c.service.DailyScheduleService(), c.infra, c.middleware)
-	userScheduleHandler := v1.NewUserScheduleHandler(c.service.UserScheduleService(), c.infra, c.middleware)
+	leaveRequestHandler := v1.NewLeaveRequestHandler(c.service.LeaveRequestService(), c.service.Notifier(), c.infra, c.middleware)
+	attachmentHandler := v1.NewAttachmentHandler(c.service.AttachmentService(), c.infra, c.middleware)
+	sessionHandler := v1.NewSessionHandler(c.service.SessionService(), c.service.ScheduleService(), c.service.TeacherService(), c.infra, c.middleware)
+	userScheduleHandler := v1.NewUserScheduleHandler(c.service.UserScheduleService(), c.service.ScheduleService(), c.infra, c.middleware)
 	myScheduleHandler := v1.NewMyScheduleHandler(c.service.UserScheduleService(), c.service.AttendanceService(), c.infra, c.middleware)
 	passwordResetTokenHandler := v1.NewPasswordResetTokenHandler(c.service.PasswordResetTokenService(), c.infra, c.middleware)
 	activationTokenHandler := v1.NewActivationTokenHandler(c.service.ActivationTokenService(), c.infra, c.middleware)
@@ -94,6 +126,46 @@ func (c server) v1() {
 		c.service.ScheduleService(),
 		c.service.UserScheduleService(),
 		c.service.DailyScheduleService(),
+		c.service.AcademicCalendarService(),
+		c.service.ScheduleExceptionService(),
+		c.service.SessionService(),
+		c.service.AttendancePolicyService(),
+		c.service.Notifier(),
+		c.service.WebhookDispatcher(),
+		c.infra,
+		c.middleware,
+	)
+	attendanceCorrectionHandler := v1.NewAttendanceCorrectionHandler(
+		c.service.AttendanceCorrectionService(),
+		c.service.AttendanceService(),
+		c.service.ScheduleService(),
+		c.service.DailyScheduleService(),
+		c.service.ScheduleExceptionService(),
+		c.service.AttendancePolicyService(),
+		c.service.Notifier(),
+		c.service.WebhookDispatcher(),
+		c.infra,
+		c.middleware,
+	)
+	attendancePolicyHandler := v1.NewAttendancePolicyHandler(
+		c.service.AttendancePolicyService(),
+		c.service.AttendanceService(),
+		c.service.ScheduleService(),
+		c.infra,
+		c.middleware,
+	)
+	webhookHandler := v1.NewWebhookHandler(c.service.WebhookService(), c.service.WebhookDispatcher(), c.infra, c.middleware)
+	notificationHandler := v1.NewNotificationHandler(c.service.NotificationService(), c.infra, c.middleware)
+	attendanceAlertHandler := v1.NewAttendanceAlertHandler(
+		c.service.AttendanceAlertService(),
+		c.infra,
+		c.middleware,
+	)
+	attendanceGradeHandler := v1.NewAttendanceGradeHandler(
+		c.service.AttendanceGradeService(),
+		c.service.AttendanceService(),
+		c.service.ScheduleService(),
+		c.service.UserScheduleService(),
 		c.infra,
 		c.middleware,
 	)
@@ -101,6 +173,8 @@ func (c server) v1() {
 		c.service.UserService(),
 		c.service.StudentService(),
 		c.service.ActivationTokenService(),
+		c.service.GuardianService(),
+		c.service.Notifier(),
 		c.infra,
 		c.middleware,
 	)
@@ -108,6 +182,7 @@ func (c server) v1() {
 		c.service.UserService(),
 		c.service.TeacherService(),
 		c.service.ActivationTokenService(),
+		c.service.Notifier(),
 		c.infra,
 		c.middleware,
 	)
@@ -190,6 +265,10 @@ func (c server) v1() {
 			profile.GET("/teacher", profileHandler.Teacher)
 			profile.PUT("/update", profileHandler.Update)
 			profile.PUT("/update-password", profileHandler.UpdatePassword)
+			profile.GET("/guardian", profileHandler.Guardian)
+			profile.PUT("/guardian", profileHandler.UpdateGuardian)
+			profile.GET("/notification-preference", profileHandler.NotificationPreference)
+			profile.PUT("/notification-preference", profileHandler.UpdateNotificationPreference)
 		}
 
 		activationToken := v1.Group("/activation-token")
@@ -236,6 +315,28 @@ func (c server) v1() {
 			faculty.GET("/drop-down", facultyHandler.DropDown)
 		}
 
+		academicCalendar := v1.Group("/academic-calendar")
+		academicCalendar.Use(c.middleware.ADMIN())
+		{
+			academicCalendar.POST("/create", academicCalendarHandler.Create)
+			academicCalendar.GET("/retrieve", academicCalendarHandler.Retrieve)
+			academicCalendar.PUT("/update", academicCalendarHandler.Update)
+			academicCalendar.DELETE("/delete", academicCalendarHandler.Delete)
+			academicCalendar.GET("/list", academicCalendarHandler.List)
+			academicCalendar.POST("/import", academicCalendarHandler.Import)
+		}
+
+		room := v1.Group("/room")
+		room.Use(c.middleware.ADMIN())
+		{
+			room.POST("/create", roomHandler.Create)
+			room.GET("/retrieve", roomHandler.Retrieve)
+			room.PUT("/update", roomHandler.Update)
+			room.DELETE("/delete", roomHandler.Delete)
+			room.GET("/list", roomHandler.List)
+			room.GET("/drop-down", roomHandler.DropDown)
+		}
+
 		major := v1.Group("/major")
 		major.Use(c.middleware.ADMIN())
 		{
@@ -267,11 +368,22 @@ func (c server) v1() {
 			schedule.GET("/retrieve", scheduleHandler.Retrieve)
 			schedule.PUT("/update", scheduleHandler.Update)
 			schedule.PUT("/update-qr-code", scheduleHandler.UpdateQRcode)
+			schedule.GET("/current-qr-code", scheduleHandler.CurrentQRcode)
+			schedule.GET("/qr-code/png", scheduleHandler.QRcodePNG)
+			schedule.GET("/qr-code/svg", scheduleHandler.QRcodeSVG)
+			schedule.GET("/stream-ticket", scheduleHandler.StreamTicket)
+			schedule.POST("/check-conflict", scheduleHandler.CheckConflict)
 			schedule.DELETE("/delete", scheduleHandler.Delete)
 			schedule.GET("/list", scheduleHandler.List)
 			schedule.GET("/drop-down", scheduleHandler.DropDown)
 		}
 
+		scheduleStream := v1.Group("/schedule")
+		scheduleStream.Use(c.middleware.STREAMTICKET())
+		{
+			scheduleStream.GET("/stream", scheduleHandler.Stream)
+		}
+
 		dailySchedule := v1.Group("/daily-schedule")
 		dailySchedule.Use(c.middleware.ADMIN())
 		{
@@ -283,6 +395,37 @@ func (c server) v1() {
 			dailySchedule.GET("/drop-down", dailyScheduleHandler.DropDown)
 		}
 
+		scheduleException := v1.Group("/schedule-exception")
+		scheduleException.Use(c.middleware.ADMIN())
+		{
+			scheduleException.POST("/create", scheduleExceptionHandler.Create)
+			scheduleException.GET("/retrieve", scheduleExceptionHandler.Retrieve)
+			scheduleException.PUT("/update", scheduleExceptionHandler.Update)
+			scheduleException.DELETE("/delete", scheduleExceptionHandler.Delete)
+			scheduleException.GET("/list", scheduleExceptionHandler.List)
+		}
+
+		session := v1.Group("/session")
+		session.Use(c.middleware.ADMIN())
+		{
+			session.GET("/retrieve", sessionHandler.Retrieve)
+			session.PUT("/update", sessionHandler.Update)
+			session.GET("/list", sessionHandler.List)
+			session.POST("/generate", sessionHandler.Generate)
+		}
+
+		lectureJournal := v1.Group("/lecture-journal")
+		lectureJournal.Use(c.middleware.ADMIN())
+		{
+			lectureJournal.POST("/create", lectureJournalHandler.Create)
+			lectureJournal.GET("/retrieve", lectureJournalHandler.Retrieve)
+			lectureJournal.PUT("/update", lectureJournalHandler.Update)
+			lectureJournal.DELETE("/delete", lectureJournalHandler.Delete)
+			lectureJournal.GET("/list", lectureJournalHandler.List)
+			lectureJournal.POST("/submit", lectureJournalHandler.Submit)
+			lectureJournal.POST("/unlock", lectureJournalHandler.Unlock)
+		}
+
 		userSchedule := v1.Group("/user-schedule")
 		userSchedule.Use(c.middleware.ADMIN())
 		{
@@ -314,12 +457,100 @@ func (c server) v1() {
 			attendance.DELETE("/delete", attendanceHandler.Delete)
 			attendance.GET("/list", attendanceHandler.List)
 			attendance.GET("/drop-down", attendanceHandler.DropDown)
+			attendance.GET("/export", attendanceHandler.Export)
 			attendance.GET("/summary", attendanceHandler.Summary)
+			attendance.GET("/eligibility", attendanceHandler.Eligibility)
 			attendance.POST("/clock-in", attendanceHandler.ClockIn)
 			attendance.POST("/clock-out", attendanceHandler.ClockOut)
 			attendance.GET("/auto-generate", attendanceHandler.AutoGenerate)
 		}
 
+		attendanceCorrection := v1.Group("/attendance-correction")
+		attendanceCorrection.Use(c.middleware.AUTH())
+		{
+			attendanceCorrection.POST("/create", attendanceCorrectionHandler.Create)
+			attendanceCorrection.GET("/retrieve", attendanceCorrectionHandler.Retrieve)
+			attendanceCorrection.POST("/cancel", attendanceCorrectionHandler.Cancel)
+			attendanceCorrection.PUT("/review", attendanceCorrectionHandler.Review)
+			attendanceCorrection.GET("/list", attendanceCorrectionHandler.List)
+		}
+
+		attendancePolicy := v1.Group("/attendance-policy")
+		attendancePolicy.Use(c.middleware.AUTH())
+		{
+			attendancePolicy.POST("/create", attendancePolicyHandler.Create)
+			attendancePolicy.GET("/retrieve", attendancePolicyHandler.Retrieve)
+			attendancePolicy.PUT("/update", attendancePolicyHandler.Update)
+			attendancePolicy.DELETE("/delete", attendancePolicyHandler.Delete)
+			attendancePolicy.GET("/list", attendancePolicyHandler.List)
+			attendancePolicy.PUT("/assign", attendancePolicyHandler.Assign)
+			attendancePolicy.POST("/recompute", attendancePolicyHandler.Recompute)
+		}
+
+		attendanceGrade := v1.Group("/attendance-grade")
+		attendanceGrade.Use(c.middleware.AUTH())
+		{
+			attendanceGrade.GET("/formula", attendanceGradeHandler.RetrieveFormula)
+			attendanceGrade.PUT("/formula", attendanceGradeHandler.UpdateFormula)
+			attendanceGrade.GET("/score", attendanceGradeHandler.Score)
+			attendanceGrade.GET("/export", attendanceGradeHandler.Export)
+		}
+
+		attendanceAlert := v1.Group("/attendance-alert")
+		attendanceAlert.Use(c.middleware.AUTH())
+		{
+			attendanceAlert.GET("/list", attendanceAlertHandler.List)
+		}
+
+		notification := v1.Group("/notification")
+		notification.Use(c.middleware.AUTH())
+		{
+			notification.GET("/list", notificationHandler.List)
+			notification.GET("/unread-count", notificationHandler.UnreadCount)
+			notification.PUT("/read", notificationHandler.Read)
+			notification.PUT("/read-all", notificationHandler.ReadAll)
+			notification.DELETE("/delete", notificationHandler.Delete)
+		}
+
+		webhook := v1.Group("/webhook")
+		webhook.Use(c.middleware.AUTH())
+		{
+			webhook.POST("/create", webhookHandler.Create)
+			webhook.GET("/retrieve", webhookHandler.Retrieve)
+			webhook.PUT("/update", webhookHandler.Update)
+			webhook.DELETE("/delete", webhookHandler.Delete)
+			webhook.GET("/list", webhookHandler.List)
+			webhook.GET("/delivery/list", webhookHandler.DeliveryList)
+			webhook.POST("/redeliver", webhookHandler.Redeliver)
+		}
+
+		guardian := v1.Group("/guardian")
+		{
+			guardian.GET("/opt-out", guardianHandler.OptOut)
+		}
+
+		leaveRequest := v1.Group("/leave-request")
+		leaveRequest.Use(c.middleware.AUTH())
+		{
+			leaveRequest.POST("/create", leaveRequestHandler.Create)
+			leaveRequest.GET("/retrieve", leaveRequestHandler.Retrieve)
+			leaveRequest.POST("/cancel", leaveRequestHandler.Cancel)
+			leaveRequest.PUT("/decide", leaveRequestHandler.Decide)
+			leaveRequest.GET("/list", leaveRequestHandler.List)
+			leaveRequest.GET("/log", leaveRequestHandler.Log)
+		}
+
+		attachment := v1.Group("/attachment")
+		{
+			// link unduhan sudah bertanda tangan dan kedaluwarsa sehingga tidak memerlukan token
+			attachment.GET("/file", attachmentHandler.File)
+			attachment.Use(c.middleware.AUTH())
+			attachment.POST("/upload", attachmentHandler.Upload)
+			attachment.GET("/retrieve", attachmentHandler.Retrieve)
+			attachment.DELETE("/delete", attachmentHandler.Delete)
+			attachment.GET("/list", attachmentHandler.List)
+		}
+
 		attendanceLog := v1.Group("/attendance-log")
 		attendanceLog.Use(c.middleware.AUTH())
 		{
@@ -0,0 +1,359 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/response"
+	"attendance-api/common/util/converter"
+	"attendance-api/common/util/ical"
+	"attendance-api/common/util/pagination"
+	"attendance-api/infra"
+	"attendance-api/model"
+	"attendance-api/service"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type AcademicCalendarHandler interface {
+	Create(c *gin.Context)
+	Retrieve(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	List(c *gin.Context)
+	Import(c *gin.Context)
+}
+
+type academicCalendarHandler struct {
+	academicCalendarService service.AcademicCalendarService
+	facultyService          service.FacultyService
+	infra                   infra.Infra
+	middleware              middleware.Middleware
+}
+
+func NewAcademicCalendarHandler(
+	academicCalendarService service.AcademicCalendarService,
+	facultyService service.FacultyService,
+	infra infra.Infra,
+	middleware middleware.Middleware,
+) AcademicCalendarHandler {
+	return &academicCalendarHandler{
+		academicCalendarService: academicCalendarService,
+		facultyService:          facultyService,
+		infra:                   infra,
+		middleware:              middleware,
+	}
+}
+
+// Create ... Create Academic Calendar
+// @Summary Create New Academic Calendar
+// @Description Create Academic Calendar (libur, minggu ujian, masa jeda)
+// @Tags Academic Calendar
+// @Accept       json
+// @Produce      json
+// @Param data body model.AcademicCalendarForm true "data"
+// @Success 200 {object} model.AcademicCalendarResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /academic-calendar/create [post]
+// @Security BearerTokenAuth
+func (h academicCalendarHandler) Create(c *gin.Context) {
+	var data model.AcademicCalendar
+	c.BindJSON(&data)
+
+	if !h.middleware.IsSuperAdmin(c) {
+		err := errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("%v", err))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	data.GormCustom.CreatedBy = currentUserID
+	data.OwnerID = currentUserID
+
+	if err := h.validate(data); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.academicCalendarService.CreateAcademicCalendar(data)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusCreated, "sukses membuat data", result)
+}
+
+// Retrieve ... Retrieve Academic Calendar
+// @Summary Retrieve Single Academic Calendar
+// @Description Retrieve Single Academic Calendar
+// @Tags Academic Calendar
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AcademicCalendarResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /academic-calendar/retrieve [get]
+// @Security BearerTokenAuth
+// @param id query string true "id academic calendar"
+func (h academicCalendarHandler) Retrieve(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	result, err := h.academicCalendarService.RetrieveAcademicCalendar(id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusCreated, "sukses mengambil data", result)
+}
+
+// Update ... Update Academic Calendar
+// @Summary Update Single Academic Calendar
+// @Description Update Single Academic Calendar
+// @Tags Academic Calendar
+// @Accept       json
+// @Produce      json
+// @Param data body model.AcademicCalendarForm true "data"
+// @Success 200 {object} model.AcademicCalendarResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /academic-calendar/update [put]
+// @Security BearerTokenAuth
+// @param id query string true "id academic calendar"
+func (h academicCalendarHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		err := errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("%v", err))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	current, err := h.academicCalendarService.RetrieveAcademicCalendar(id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var data model.AcademicCalendar
+	c.BindJSON(&data)
+
+	data.UpdatedBy = currentUserID
+	data.UpdatedAt = time.Now()
+
+	if err := h.validate(data); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.academicCalendarService.UpdateAcademicCalendar(id, data)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	// Updates melewati nilai kosong, kalender yang dipindah ke seluruh kampus perlu disimpan terpisah
+	if data.FacultyID == 0 && current.FacultyID > 0 {
+		if err := h.academicCalendarService.UpdateAcademicCalendarFaculty(id, 0); err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	}
+	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
+}
+
+// Delete ... Delete Academic Calendar
+// @Summary Delete Single Academic Calendar
+// @Description Delete Single Academic Calendar
+// @Tags Academic Calendar
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AcademicCalendarResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /academic-calendar/delete [delete]
+// @Security BearerTokenAuth
+// @param id query string true "id academic calendar"
+func (h academicCalendarHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		err := errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("%v", err))
+		return
+	}
+
+	if err := h.academicCalendarService.DeleteAcademicCalendar(id); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	response.New(c).Write(http.StatusOK, "sukses menghapus data")
+}
+
+// List ... List all Academic Calendar
+// @Summary List all Academic Calendar
+// @Description List all Academic Calendar, start_date dan end_date menyaring kalender yang beririsan dengan rentang tersebut
+// @Tags Academic Calendar
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AcademicCalendarResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /academic-calendar/list [get]
+// @Security BearerTokenAuth
+func (h academicCalendarHandler) List(c *gin.Context) {
+	pagination := pagination.GeneratePaginationFromRequest(c)
+	var data model.AcademicCalendar
+	c.BindQuery(&data)
+
+	dataList, err := h.academicCalendarService.ListAcademicCalendar(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	metaList, err := h.academicCalendarService.ListAcademicCalendarMeta(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	response.New(c).List(http.StatusOK, "sukses mengambil list data", dataList, metaList)
+}
+
+// Import ... Import Academic Calendar
+// @Summary Import Academic Calendar From iCalendar
+// @Description Import berkas .ics (mis. daftar libur nasional), event dengan UID yang sama akan diperbaharui
+// @Tags Academic Calendar
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param file formData file true "berkas iCalendar (.ics)"
+// @Success 200 {object} model.AcademicCalendarImportResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /academic-calendar/import [post]
+// @Security BearerTokenAuth
+// @param type query string false "holiday, exam atau break (default holiday)"
+// @param faculty_id query string false "id fakultas, kosongkan untuk seluruh kampus"
+func (h academicCalendarHandler) Import(c *gin.Context) {
+	if !h.middleware.IsSuperAdmin(c) {
+		err := errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("%v", err))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	calendarType := c.DefaultQuery("type", model.CalendarHoliday)
+	facultyID, _ := strconv.Atoi(c.DefaultQuery("faculty_id", "0"))
+
+	var reader io.Reader = c.Request.Body
+	if file, err := c.FormFile("file"); err == nil {
+		opened, err := file.Open()
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+		defer opened.Close()
+		reader = opened
+	}
+
+	events, err := ical.Parse(reader)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("berkas: %v", err))
+		return
+	}
+	if len(events) == 0 {
+		response.New(c).Error(http.StatusBadRequest, errors.New("berkas: tidak ada event yang bisa diimport"))
+		return
+	}
+
+	result := model.AcademicCalendarImport{Total: len(events)}
+	for _, event := range events {
+		data := model.AcademicCalendar{
+			Name:      event.Summary,
+			Type:      calendarType,
+			StartDate: event.StartDate,
+			EndDate:   event.EndDate,
+			FacultyID: uint(facultyID),
+			UID:       event.UID,
+			Summary:   event.Description,
+			OwnerID:   currentUserID,
+		}
+		if data.UID == "" {
+			data.UID = event.StartDate + "-" + event.Summary
+		}
+
+		if err := h.validate(data); err != nil {
+			response.New(c).Error(http.StatusBadRequest, fmt.Errorf("event %s: %v", event.Summary, err))
+			return
+		}
+
+		current, err := h.academicCalendarService.RetrieveAcademicCalendarByUID(data.UID, facultyID)
+		if err == nil {
+			data.UpdatedBy = currentUserID
+			data.UpdatedAt = time.Now()
+			if _, err := h.academicCalendarService.UpdateAcademicCalendar(int(current.ID), data); err != nil {
+				result.Skipped++
+				continue
+			}
+			result.Updated++
+			continue
+		}
+
+		data.CreatedBy = currentUserID
+		if _, err := h.academicCalendarService.CreateAcademicCalendar(data); err != nil {
+			result.Skipped++
+			continue
+		}
+		result.Created++
+	}
+
+	response.New(c).Data(http.StatusOK, "sukses mengimport kalender akademik", result)
+}
+
+func (h academicCalendarHandler) validate(data model.AcademicCalendar) error {
+	if err := validation.Validate(data.Name, validation.Required, validation.Length(1, 255)); err != nil {
+		return fmt.Errorf("nama: %v", err)
+	}
+	if !data.IsValidType() {
+		return errors.New("tipe: tipe kalender harus holiday, exam atau break")
+	}
+	if err := validation.Validate(converter.GetOnlyDateString(data.StartDate), validation.Required, validation.Date("2006-01-02")); err != nil {
+		return fmt.Errorf("tanggal mulai: %v", err)
+	}
+	if err := validation.Validate(converter.GetOnlyDateString(data.EndDate), validation.Required, validation.Date("2006-01-02")); err != nil {
+		return fmt.Errorf("tanggal selesai: %v", err)
+	}
+	if !data.IsValidRange() {
+		return errors.New("tanggal selesai: tanggal selesai tidak boleh sebelum tanggal mulai")
+	}
+	if data.FacultyID > 0 && !h.facultyService.CheckIsExist(int(data.FacultyID)) {
+		return errors.New("fakultas: data fakultas tidak ditemukan")
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/response"
+	"attendance-api/common/util/pagination"
+	"attendance-api/infra"
+	"attendance-api/infra/storage"
+	"attendance-api/model"
+	"attendance-api/service"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttachmentHandler interface {
+	Upload(c *gin.Context)
+	Retrieve(c *gin.Context)
+	Delete(c *gin.Context)
+	List(c *gin.Context)
+	File(c *gin.Context)
+}
+
+type attachmentHandler struct {
+	attachmentService service.AttachmentService
+	infra             infra.Infra
+	middleware        middleware.Middleware
+}
+
+func NewAttachmentHandler(attachmentService service.AttachmentService, infra infra.Infra, middleware middleware.Middleware) AttachmentHandler {
+	return &attachmentHandler{
+		attachmentService: attachmentService,
+		infra:             infra,
+		middleware:        middleware,
+	}
+}
+
+// Upload ... Upload Attachment
+// @Summary Upload Attachment
+// @Description Upload file (multipart field "file") dan tautkan ke data record_type (attendance, user, leave_request, attendance_correction) dengan id record_id
+// @Tags Attachment
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param file formData file true "file"
+// @Param record_type formData string true "attendance, user, leave_request atau attendance_correction"
+// @Param record_id formData string true "id data"
+// @Success 200 {object} model.AttachmentResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attachment/upload [post]
+// @Security BearerTokenAuth
+func (h attachmentHandler) Upload(c *gin.Context) {
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	recordID, err := strconv.Atoi(c.PostForm("record_id"))
+	if recordID < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("record_id harus diisi dengan nomor yang valid"))
+		return
+	}
+	data := model.Attachment{
+		GormCustom: model.GormCustom{
+			CreatedBy: currentUserID,
+			UpdatedBy: currentUserID,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		RecordType: c.PostForm("record_type"),
+		RecordID:   uint(recordID),
+		OwnerID:    currentUserID,
+	}
+	if !data.IsValidRecordType() {
+		response.New(c).Error(http.StatusBadRequest, errors.New("record_type: harus attendance, user, leave_request atau attendance_correction"))
+		return
+	}
+	if !h.middleware.IsSuperAdmin(c) && !h.attachmentService.CheckRecordAccess(data.RecordType, recordID, currentUserID) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("file: %v", err))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("file: %v", err))
+		return
+	}
+	defer file.Close()
+
+	config := h.infra.Config().Sub("storage")
+	limit := storage.Limit{MaxSize: 5 << 20}
+	if config != nil {
+		if maxSize := config.GetInt64("max_size"); maxSize > 0 {
+			limit.MaxSize = maxSize
+		}
+		limit.AllowedTypes = config.GetStringSlice("allowed_types")
+	}
+
+	object, err := storage.Save(h.infra.Storage(), data.RecordType, fileHeader.Filename, file, limit)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("file: %v", err))
+		return
+	}
+	data.Key = object.Key
+	data.Name = object.Name
+	data.ContentType = object.ContentType
+	data.Size = object.Size
+	data.Hash = object.Hash
+
+	result, err := h.attachmentService.CreateAttachment(data)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	result.URL = h.signedURL(result.Key)
+	response.New(c).Data(http.StatusCreated, "sukses mengunggah file", result)
+}
+
+// Retrieve ... Retrieve Attachment
+// @Summary Retrieve Single Attachment
+// @Description Retrieve Single Attachment beserta link unduhan yang kedaluwarsa
+// @Tags Attachment
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AttachmentResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attachment/retrieve [get]
+// @Security BearerTokenAuth
+// @param id query string true "id attachment"
+func (h attachmentHandler) Retrieve(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.attachmentService.RetrieveAttachment(id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	if !h.haveAccess(c, result, currentUserID) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+		return
+	}
+
+	result.URL = h.signedURL(result.Key)
+	response.New(c).Data(http.StatusCreated, "sukses mengambil data", result)
+}
+
+// Delete ... Delete Attachment
+// @Summary Delete Single Attachment
+// @Description Delete Attachment, file di storage dihapus jika tidak dipakai lampiran lain
+// @Tags Attachment
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.Response
+// @Failure 400,500 {object} model.Response
+// @Router /attachment/delete [delete]
+// @Security BearerTokenAuth
+// @param id query string true "id attachment"
+func (h attachmentHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	attachment, err := h.attachmentService.RetrieveAttachment(id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	if !h.middleware.IsSuperAdmin(c) && attachment.OwnerID != currentUserID {
+		response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+		return
+	}
+
+	if err := h.attachmentService.DeleteAttachment(id); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	if h.attachmentService.CountAttachmentByKey(attachment.Key) == 0 {
+		if err := h.infra.Storage().Delete(attachment.Key); err != nil {
+			log.Printf("[Error] [Storage-Delete] E: %v\n", err)
+		}
+	}
+
+	response.New(c).Write(http.StatusOK, "sukses menghapus data")
+}
+
+// List ... List all Attachment
+// @Summary List all Attachment
+// @Description List Attachment milik satu data, filter record_type dan record_id wajib selain superadmin
+// @Tags Attachment
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AttachmentResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /attachment/list [get]
+// @Security BearerTokenAuth
+func (h attachmentHandler) List(c *gin.Context) {
+	pagination := pagination.GeneratePaginationFromRequest(c)
+	var data model.Attachment
+	c.BindQuery(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) && !h.attachmentService.CheckRecordAccess(data.RecordType, int(data.RecordID), currentUserID) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+		return
+	}
+
+	dataList, err := h.attachmentService.ListAttachment(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+	for i := range dataList {
+		dataList[i].URL = h.signedURL(dataList[i].Key)
+	}
+
+	metaList, err := h.attachmentService.ListAttachmentMeta(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	response.New(c).List(http.StatusOK, "sukses mengambil list data", dataList, metaList)
+}
+
+// File ... Download Attachment File
+// @Summary Download Attachment File
+// @Description Unduh file dari link bertanda tangan storage lokal, tidak memerlukan token
+// @Tags Attachment
+// @Produce      octet-stream
+// @Failure 400,500 {object} model.Response
+// @Router /attachment/file [get]
+// @param key query string true "key file"
+// @param expires query string true "waktu kedaluwarsa (unix)"
+// @param signature query string true "signature"
+func (h attachmentHandler) File(c *gin.Context) {
+	local, ok := h.infra.Storage().(*storage.Local)
+	if !ok {
+		response.New(c).Error(http.StatusBadRequest, errors.New("storage tidak melayani unduhan langsung"))
+		return
+	}
+
+	key := c.Query("key")
+	if err := local.Verify(key, c.Query("expires"), c.Query("signature")); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	file, err := local.Get(key)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("file tidak ditemukan"))
+		return
+	}
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, path.Base(key)))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, max-age=0")
+	c.Status(http.StatusOK)
+	io.Copy(c.Writer, file)
+}
+
+// haveAccess pengunggah, superadmin dan pihak yang berhak atas data terkait boleh melihat lampiran
+func (h attachmentHandler) haveAccess(c *gin.Context, attachment model.Attachment, currentUserID int) bool {
+	if h.middleware.IsSuperAdmin(c) || attachment.OwnerID == currentUserID {
+		return true
+	}
+	return h.attachmentService.CheckRecordAccess(attachment.RecordType, int(attachment.RecordID), currentUserID)
+}
+
+func (h attachmentHandler) signedURL(key string) string {
+	expired := 15
+	if config := h.infra.Config().Sub("storage"); config != nil && config.GetInt("url_expired") > 0 {
+		expired = config.GetInt("url_expired")
+	}
+	url, err := h.infra.Storage().SignedURL(key, time.Duration(expired)*time.Minute)
+	if err != nil {
+		log.Printf("[Error] [Storage-SignedURL] E: %v\n", err)
+		return ""
+	}
+	return url
+}
@@ -2,19 +2,26 @@ package v1
 
 import (
 	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/notification"
 	"attendance-api/common/http/response"
+	"attendance-api/common/http/webhook"
 	"attendance-api/common/util/calculation"
 	"attendance-api/common/util/converter"
+	"attendance-api/common/util/myqr"
 	"attendance-api/common/util/pagination"
 	"attendance-api/common/util/presence"
+	"attendance-api/common/util/xlsx"
 	"attendance-api/infra"
 	"attendance-api/model"
 	"attendance-api/service"
+	"encoding/csv"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,20 +37,28 @@ type AttendanceHandler interface {
 	Delete(c *gin.Context)
 	List(c *gin.Context)
 	DropDown(c *gin.Context)
+	Export(c *gin.Context)
 	ClockIn(c *gin.Context)
 	ClockOut(c *gin.Context)
 	Summary(s *gin.Context)
+	Eligibility(c *gin.Context)
 	AutoGenerate(s *gin.Context)
 }
 
 type attendanceHandler struct {
-	attendanceService    service.AttendanceService
-	attendanceLogService service.AttendanceLogService
-	scheduleService      service.ScheduleService
-	userScheduleService  service.UserScheduleService
-	dailyScheduleService service.DailyScheduleService
-	infra                infra.Infra
-	middleware           middleware.Middleware
+	attendanceService        service.AttendanceService
+	attendanceLogService     service.AttendanceLogService
+	scheduleService          service.ScheduleService
+	userScheduleService      service.UserScheduleService
+	dailyScheduleService     service.DailyScheduleService
+	academicCalendarService  service.AcademicCalendarService
+	scheduleExceptionService service.ScheduleExceptionService
+	sessionService           service.SessionService
+	attendancePolicyService  service.AttendancePolicyService
+	notifier                 notification.Notifier
+	webhookDispatcher        webhook.Dispatcher
+	infra                    infra.Infra
+	middleware               middleware.Middleware
 }
 
 func NewAttendanceHandler(
@@ -52,16 +67,28 @@ func NewAttendanceHandler(
 	scheduleService service.ScheduleService,
 	userScheduleService service.UserScheduleService,
 	dailyScheduleService service.DailyScheduleService,
+	academicCalendarService service.AcademicCalendarService,
+	scheduleExceptionService service.ScheduleExceptionService,
+	sessionService service.SessionService,
+	attendancePolicyService service.AttendancePolicyService,
+	notifier notification.Notifier,
+	webhookDispatcher webhook.Dispatcher,
 	infra infra.Infra,
 	middleware middleware.Middleware) AttendanceHandler {
 	return &attendanceHandler{
-		attendanceService:    attendanceService,
-		attendanceLogService: attendanceLogService,
-		scheduleService:      scheduleService,
-		userScheduleService:  userScheduleService,
-		dailyScheduleService: dailyScheduleService,
-		infra:                infra,
-		middleware:           middleware,
+		attendanceService:        attendanceService,
+		attendanceLogService:     attendanceLogService,
+		scheduleService:          scheduleService,
+		userScheduleService:      userScheduleService,
+		dailyScheduleService:     dailyScheduleService,
+		academicCalendarService:  academicCalendarService,
+		scheduleExceptionService: scheduleExceptionService,
+		sessionService:           sessionService,
+		attendancePolicyService:  attendancePolicyService,
+		notifier:                 notifier,
+		webhookDispatcher:        webhookDispatcher,
+		infra:                    infra,
+		middleware:               middleware,
 	}
 }
 
@@ -114,6 +141,11 @@ func (h attendanceHandler) Create(c *gin.Context) {
 		return
 	}
 
+	if err := validation.Validate(data.Date, validation.Required, validation.Date("2006-01-02")); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("date: %v", err))
+		return
+	}
+
 	schedule, err := h.scheduleService.RetrieveSchedule(int(data.ScheduleID))
 	if err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
@@ -133,43 +165,49 @@ func (h attendanceHandler) Create(c *gin.Context) {
 		return
 	}
 
-	// Check In Radius
-	if inRadius := schedule.InRange(data.LatitudeIn, data.LongitudeIn); !inRadius {
-		err = errors.New("data jam masuk berada di luar radius")
+	// Check daily Schedule, satu tanggal bisa memiliki beberapa blok jadwal harian
+	dailySchedules, err := retrieveDailySchedules(h.dailyScheduleService, h.scheduleExceptionService, &schedule, data.Date)
+	if err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
-	// Check In Radius
-	if inRadius := schedule.InRange(data.LatitudeOut, data.LongitudeOut); !inRadius {
-		err = errors.New("data jam keluar berada di luar radius")
+	if len(dailySchedules) == 0 {
+		err = errors.New("absensi tidak bisa dilakukan pada tanggal tersebut")
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
-	// Check daily Schedule
-	isExistDailySchedule, dailyScheduleID, err := h.dailyScheduleService.CheckHaveDailySchedule(int(schedule.ID), converter.GetDayNameFromDateString(data.Date))
-	if err != nil {
-		response.New(c).Error(http.StatusBadRequest, err)
-		return
+	dailySchedule := model.MatchDailySchedule(dailySchedules, data.ClockIn, data.TimeZoneIn, "clock_in")
+	if data.DailyScheduleID > 0 {
+		var isFound bool
+		if dailySchedule, isFound = model.FindDailySchedule(dailySchedules, data.DailyScheduleID); !isFound {
+			response.New(c).Error(http.StatusBadRequest, errors.New("daily_schedule_id: blok jadwal harian tidak ada pada tanggal tersebut"))
+			return
+		}
 	}
+	data.DailyScheduleID = dailySchedule.ID
 
-	if !isExistDailySchedule {
-		err = errors.New("absensi tidak bisa dilakukan pada tanggal tersebut")
+	// pertemuan pindahan / kelas pengganti bisa memakai ruangan lain
+	schedule.ApplyException(dailySchedule)
+
+	// Check In Geofence
+	if err := schedule.GeofenceError("data jam masuk berada di luar radius", data.LatitudeIn, data.LongitudeIn); err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
-	dailySchedule, err := h.dailyScheduleService.RetrieveDailySchedule(dailyScheduleID)
-	if err != nil {
+	// Check In Geofence
+	if err := schedule.GeofenceError("data jam keluar berada di luar radius", data.LatitudeOut, data.LongitudeOut); err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
-	data.LateIn = calculation.CalculateLateDuration(dailySchedule.StartTime, data.ClockIn, data.TimeZoneIn, schedule.LateDuration)
-	data.EarlyOut = calculation.CalculateEarlyDuration(dailySchedule.EndTime, data.ClockOut, data.TimeZoneOut)
+	data.LateIn = calculation.CalculateLateDuration(converter.GetOnlyDateString(data.Date), dailySchedule.StartTime, data.ClockIn, data.TimeZoneIn, schedule.LateDuration)
+	data.EarlyOut = calculation.CalculateEarlyDuration(converter.GetOnlyDateString(data.Date), dailySchedule.EndTime, dailySchedule.IsOvernight(), data.ClockOut, data.TimeZoneOut)
 	data.StatusPresence = data.GenerateStatusPresence()
 	data.Status = data.GenerateStatus()
+	data = applyAttendancePolicy(h.attendancePolicyService, data, dailySchedule.IsClockOutClosed(data.Date, time.Now()))
 
 	result, err := h.attendanceService.CreateAttendance(data)
 	if err != nil {
@@ -271,6 +309,11 @@ func (h attendanceHandler) Update(c *gin.Context) {
 		return
 	}
 
+	if err := validation.Validate(data.Date, validation.Required, validation.Date("2006-01-02")); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("date: %v", err))
+		return
+	}
+
 	schedule, err := h.scheduleService.RetrieveSchedule(int(data.ScheduleID))
 	if err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
@@ -290,43 +333,49 @@ func (h attendanceHandler) Update(c *gin.Context) {
 		return
 	}
 
-	// Check In Radius
-	if inRadius := schedule.InRange(data.LatitudeIn, data.LongitudeIn); !inRadius {
-		err = errors.New("data jam masuk berada di luar radius")
+	// Check daily Schedule, satu tanggal bisa memiliki beberapa blok jadwal harian
+	dailySchedules, err := retrieveDailySchedules(h.dailyScheduleService, h.scheduleExceptionService, &schedule, data.Date)
+	if err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
-	// Check In Radius
-	if inRadius := schedule.InRange(data.LatitudeOut, data.LongitudeOut); !inRadius {
-		err = errors.New("data jam keluar berada di luar radius")
+	if len(dailySchedules) == 0 {
+		err = errors.New("absensi tidak bisa dilakukan pada tanggal tersebut")
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
-	// Check daily Schedule
-	isExistDailySchedule, dailyScheduleID, err := h.dailyScheduleService.CheckHaveDailySchedule(int(schedule.ID), converter.GetDayNameFromDateString(data.Date))
-	if err != nil {
-		response.New(c).Error(http.StatusBadRequest, err)
-		return
+	dailySchedule := model.MatchDailySchedule(dailySchedules, data.ClockIn, data.TimeZoneIn, "clock_in")
+	if data.DailyScheduleID > 0 {
+		var isFound bool
+		if dailySchedule, isFound = model.FindDailySchedule(dailySchedules, data.DailyScheduleID); !isFound {
+			response.New(c).Error(http.StatusBadRequest, errors.New("daily_schedule_id: blok jadwal harian tidak ada pada tanggal tersebut"))
+			return
+		}
 	}
+	data.DailyScheduleID = dailySchedule.ID
 
-	if !isExistDailySchedule {
-		err = errors.New("absensi tidak bisa dilakukan pada tanggal tersebut")
+	// pertemuan pindahan / kelas pengganti bisa memakai ruangan lain
+	schedule.ApplyException(dailySchedule)
+
+	// Check In Geofence
+	if err := schedule.GeofenceError("data jam masuk berada di luar radius", data.LatitudeIn, data.LongitudeIn); err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
-	dailySchedule, err := h.dailyScheduleService.RetrieveDailySchedule(dailyScheduleID)
-	if err != nil {
+	// Check In Geofence
+	if err := schedule.GeofenceError("data jam keluar berada di luar radius", data.LatitudeOut, data.LongitudeOut); err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
-	data.LateIn = calculation.CalculateLateDuration(dailySchedule.StartTime, data.ClockIn, data.TimeZoneIn, schedule.LateDuration)
-	data.EarlyOut = calculation.CalculateEarlyDuration(dailySchedule.EndTime, data.ClockOut, data.TimeZoneOut)
+	data.LateIn = calculation.CalculateLateDuration(converter.GetOnlyDateString(data.Date), dailySchedule.StartTime, data.ClockIn, data.TimeZoneIn, schedule.LateDuration)
+	data.EarlyOut = calculation.CalculateEarlyDuration(converter.GetOnlyDateString(data.Date), dailySchedule.EndTime, dailySchedule.IsOvernight(), data.ClockOut, data.TimeZoneOut)
 	data.StatusPresence = data.GenerateStatusPresence()
 	data.Status = data.GenerateStatus()
+	data = applyAttendancePolicy(h.attendancePolicyService, data, dailySchedule.IsClockOutClosed(data.Date, time.Now()))
 
 	var result model.Attendance
 	if h.middleware.IsSuperAdmin(c) {
@@ -339,6 +388,7 @@ func (h attendanceHandler) Update(c *gin.Context) {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
+	h.webhookDispatcher.Dispatch(model.WebhookEventStatusChanged, model.NewWebhookAttendanceData(result))
 	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
 }
 
@@ -380,6 +430,7 @@ func (h attendanceHandler) UpdateStatusPresence(c *gin.Context) {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
+	h.webhookDispatcher.Dispatch(model.WebhookEventStatusChanged, model.NewWebhookAttendanceData(result))
 	response.New(c).Data(http.StatusOK, "sukses memperbaharui status presensi", result)
 }
 
@@ -460,6 +511,152 @@ func (h attendanceHandler) List(c *gin.Context) {
 	response.New(c).List(http.StatusOK, "sukses mengambil list data", dataList, metaList)
 }
 
+// Export ... Export Attendance
+// @Summary Export Attendance
+// @Description Ekspor presensi dalam format CSV (default) atau XLSX dengan filter yang sama seperti list ditambah rentang tanggal start_date dan end_date.
+// @Description Jam masuk dan pulang ditulis dalam zona waktu saat presensi dicatat. Dosen hanya mengekspor jadwal miliknya, mahasiswa hanya presensinya sendiri
+// @Tags Attendance
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Success 200 {file} binary
+// @Failure 400,500 {object} model.Response
+// @Router /attendance/export [get]
+// @Security BearerTokenAuth
+// @param format query string false "csv / xlsx"
+// @param start_date query string false "tanggal mulai (2006-01-02)"
+// @param end_date query string false "tanggal selesai (2006-01-02)"
+// @param user_id query string false "id user"
+// @param schedule_id query string false "id schedule"
+// @param date query string false "tanggal (2006-01-02)"
+// @param status query string false "status"
+func (h attendanceHandler) Export(c *gin.Context) {
+	var data model.Attendance
+	c.BindQuery(&data)
+
+	format := c.DefaultQuery("format", "csv")
+	if err := validation.Validate(format, validation.In("csv", "xlsx")); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("format: %v", err))
+		return
+	}
+	if err := validation.Validate(data.StartDate, validation.Date("2006-01-02")); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("tanggal mulai: %v", err))
+		return
+	}
+	if err := validation.Validate(data.EndDate, validation.Date("2006-01-02")); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("tanggal selesai: %v", err))
+		return
+	}
+	if data.StartDate != "" && data.EndDate != "" && data.EndDate < data.StartDate {
+		response.New(c).Error(http.StatusBadRequest, errors.New("tanggal selesai: tidak boleh sebelum tanggal mulai"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	if !h.middleware.IsSuperAdmin(c) {
+		if h.middleware.IsAdmin(c) {
+			data.Schedule.OwnerID = uint(currentUserID)
+		} else {
+			data.UserID = currentUserID
+		}
+	}
+
+	header := []string{"nim", "name", "subject", "schedule", "date", "clock_in", "clock_out", "status", "status_presence"}
+	filename := "presensi-" + time.Now().Format("20060102150405") + "." + format
+
+	// response baru dimulai pada baris pertama agar error query masih bisa dibalas sebagai JSON
+	var writeRow func(row []string) error
+	var flush func() error
+	var closeWriter func() error
+	total := 0
+	start := func() error {
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+		if format == "xlsx" {
+			c.Header("Content-Type", xlsx.ContentType)
+			c.Status(http.StatusOK)
+			writer, err := xlsx.NewWriter(c.Writer, "Presensi")
+			if err != nil {
+				return err
+			}
+			writeRow, flush, closeWriter = writer.WriteRow, writer.Flush, writer.Close
+		} else {
+			c.Header("Content-Type", "text/csv; charset=utf-8")
+			c.Status(http.StatusOK)
+			writer := csv.NewWriter(c.Writer)
+			writeRow = writer.Write
+			flush = func() error {
+				writer.Flush()
+				return writer.Error()
+			}
+			closeWriter = flush
+		}
+		return writeRow(header)
+	}
+
+	err = h.attendanceService.ExportAttendance(data, func(attendance model.AttendanceExport) error {
+		if writeRow == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := writeRow([]string{
+			attendance.NIM,
+			strings.TrimSpace(attendance.FirstName + " " + attendance.LastName),
+			attendance.SubjectName,
+			strings.TrimSpace(attendance.ScheduleCode + " " + attendance.ScheduleName),
+			converter.GetOnlyDateString(attendance.Date),
+			exportClock(attendance.ClockIn, attendance.TimeZoneIn),
+			exportClock(attendance.ClockOut, attendance.TimeZoneOut),
+			attendance.Status,
+			attendance.StatusPresence,
+		}); err != nil {
+			return err
+		}
+
+		total++
+		if total%500 == 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err != nil && writeRow == nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		// header sudah terkirim, berkas terpotong
+		log.Printf("[Error] [Attendance-Export] E: %v\n", err)
+		return
+	}
+
+	if writeRow == nil {
+		if err := start(); err != nil {
+			log.Printf("[Error] [Attendance-Export] E: %v\n", err)
+			return
+		}
+	}
+	if err := closeWriter(); err != nil {
+		log.Printf("[Error] [Attendance-Export] E: %v\n", err)
+	}
+}
+
+// exportClock jam dari waktu millis pada zona waktu saat dicatat, contoh 07:55 GMT+7
+func exportClock(timeMillis int64, timeZone int) string {
+	if timeMillis <= 0 {
+		return ""
+	}
+	if timeZone == 0 {
+		return converter.MillisToTimeString(timeMillis, timeZone)
+	}
+	return fmt.Sprintf("%s GMT%+d", converter.MillisToTimeString(timeMillis, timeZone), timeZone)
+}
+
 // Dropdown ... Dropdown All Attendance
 // @Summary Dropdown All Attendance
 // @Description Dropdown All Attendance
@@ -537,7 +734,7 @@ func (h attendanceHandler) ClockIn(c *gin.Context) {
 	}
 
 	// check data schedule dari scan
-	schedule, err := h.scheduleService.RetrieveScheduleByQRcode(dataClockIn.QRCode)
+	schedule, err := h.retrieveScheduleByScan(dataClockIn.QRCode)
 	if err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
@@ -556,28 +753,32 @@ func (h attendanceHandler) ClockIn(c *gin.Context) {
 		return
 	}
 
-	// Check In Radius
-	if inRadius := schedule.InRange(dataClockIn.Latitude, dataClockIn.Longitude); !inRadius {
-		err = errors.New("maaf anda berada di luar radius")
+	// Check daily Schedule, blok jadwal harian dipilih sesuai jam absen
+	dailySchedules, err := retrieveDailySchedules(h.dailyScheduleService, h.scheduleExceptionService, &schedule, toDay)
+	if err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
-	// Check daily Schedule
-	isExistDailySchedule, dailyScheduleID, err := h.dailyScheduleService.CheckHaveDailySchedule(int(schedule.ID), converter.GetDayNameFromDateString(toDay))
-	if err != nil {
+	if len(dailySchedules) == 0 {
+		err = errors.New("absensi tidak bisa dilakukan pada hari ini")
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
-	if !isExistDailySchedule {
-		err = errors.New("absensi tidak bisa dilakukan pada hari ini")
+	dailySchedule := model.MatchDailySchedule(dailySchedules, currentCheckIn, dataClockIn.TimeZone, "clock_in")
+
+	// Check batas waktu absen masuk
+	if err := dailySchedule.ClockInError(toDay, currentCheckIn, dataClockIn.TimeZone); err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
-	dailySchedule, err := h.dailyScheduleService.RetrieveDailySchedule(dailyScheduleID)
-	if err != nil {
+	// pertemuan pindahan / kelas pengganti bisa memakai ruangan lain
+	schedule.ApplyException(dailySchedule)
+
+	// Check In Geofence
+	if err := schedule.GeofenceError("maaf anda berada di luar radius", dataClockIn.Latitude, dataClockIn.Longitude); err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
@@ -590,10 +791,10 @@ func (h attendanceHandler) ClockIn(c *gin.Context) {
 	}
 
 	// Check Attendance is Exist or not
-	isExistAttendance := h.attendanceService.CheckIsExistByDate(currentUserID, int(schedule.ID), toDay)
+	isExistAttendance := h.attendanceService.CheckIsExistByDate(currentUserID, int(schedule.ID), int(dailySchedule.ID), toDay)
 	if isExistAttendance {
 		// Get
-		attendance, err := h.attendanceService.RetrieveAttendanceByDate(currentUserID, int(schedule.ID), toDay)
+		attendance, err := h.attendanceService.RetrieveAttendanceByDate(currentUserID, int(schedule.ID), int(dailySchedule.ID), toDay)
 		if err != nil {
 			response.New(c).Error(http.StatusBadRequest, err)
 			return
@@ -615,7 +816,7 @@ func (h attendanceHandler) ClockIn(c *gin.Context) {
 		}
 
 		attendanceNew.ClockIn = currentCheckIn
-		attendanceNew.LateIn = calculation.CalculateLateDuration(dailySchedule.StartTime, currentCheckIn, dataClockIn.TimeZone, schedule.LateDuration)
+		attendanceNew.LateIn = calculation.CalculateLateDuration(toDay, dailySchedule.StartTime, currentCheckIn, dataClockIn.TimeZone, schedule.LateDuration)
 
 		if attendance.LatitudeIn == 0 {
 			attendanceNew.LatitudeIn = dataClockIn.Latitude
@@ -632,8 +833,10 @@ func (h attendanceHandler) ClockIn(c *gin.Context) {
 
 		attendanceNew.StatusPresence = attendanceNew.GenerateStatusPresence()
 		attendanceNew.Status = attendanceNew.GenerateStatus()
+		attendanceNew = applyAttendancePolicy(h.attendancePolicyService, attendanceNew, false)
 
-		if attendance.ClockIn == 0 {
+		isUpdated := attendance.ClockIn == 0
+		if isUpdated {
 			// Update attendance
 			attendance, err = h.attendanceService.UpdateAttendance(int(attendance.ID), attendanceNew)
 			if err != nil {
@@ -660,6 +863,12 @@ func (h attendanceHandler) ClockIn(c *gin.Context) {
 			Location:     dataClockIn.Location,
 		})
 
+		h.markSessionHeld(attendance)
+		// scan ulang tidak mengubah presensi, tanda terima dan webhook hanya dikirim saat presensi diperbarui
+		if isUpdated {
+			h.publishReceipt(model.NotificationEventClockIn, attendance, schedule)
+			h.webhookDispatcher.Dispatch(model.WebhookEventClockIn, model.NewWebhookAttendanceData(attendance))
+		}
 		response.New(c).Data(http.StatusCreated, "berhasil absen masuk", attendance)
 
 	} else {
@@ -670,18 +879,20 @@ func (h attendanceHandler) ClockIn(c *gin.Context) {
 				UpdatedBy: currentUserID,
 				CreatedAt: time.Now(),
 			},
-			UserID:      currentUserID,
-			ScheduleID:  schedule.ID,
-			Date:        toDay,
-			ClockIn:     currentCheckIn,
-			LateIn:      calculation.CalculateLateDuration(dailySchedule.StartTime, currentCheckIn, dataClockIn.TimeZone, schedule.LateDuration),
-			LatitudeIn:  dataClockIn.Latitude,
-			LongitudeIn: dataClockIn.Longitude,
-			TimeZoneIn:  dataClockIn.TimeZone,
-			LocationIn:  dataClockIn.Location,
+			UserID:          currentUserID,
+			ScheduleID:      schedule.ID,
+			DailyScheduleID: dailySchedule.ID,
+			Date:            toDay,
+			ClockIn:         currentCheckIn,
+			LateIn:          calculation.CalculateLateDuration(toDay, dailySchedule.StartTime, currentCheckIn, dataClockIn.TimeZone, schedule.LateDuration),
+			LatitudeIn:      dataClockIn.Latitude,
+			LongitudeIn:     dataClockIn.Longitude,
+			TimeZoneIn:      dataClockIn.TimeZone,
+			LocationIn:      dataClockIn.Location,
 		}
 		newAttendance.StatusPresence = newAttendance.GenerateStatusPresence()
 		newAttendance.Status = newAttendance.GenerateStatus()
+		newAttendance = applyAttendancePolicy(h.attendancePolicyService, newAttendance, false)
 		// Create attendance
 		attendance, err := h.attendanceService.CreateAttendance(newAttendance)
 		if err != nil {
@@ -707,6 +918,9 @@ func (h attendanceHandler) ClockIn(c *gin.Context) {
 			Location:     attendance.LocationIn,
 		})
 
+		h.markSessionHeld(attendance)
+		h.publishReceipt(model.NotificationEventClockIn, attendance, schedule)
+		h.webhookDispatcher.Dispatch(model.WebhookEventClockIn, model.NewWebhookAttendanceData(attendance))
 		response.New(c).Data(http.StatusCreated, "berhasil absen masuk", attendance)
 	}
 
@@ -757,12 +971,18 @@ func (h attendanceHandler) ClockOut(c *gin.Context) {
 	}
 
 	// check data schedule dari scan
-	schedule, err := h.scheduleService.RetrieveScheduleByQRcode(dataClockOut.QRCode)
+	schedule, err := h.retrieveScheduleByScan(dataClockOut.QRCode)
 	if err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
+	// absen keluar lewat tengah malam untuk blok lintas hari masuk ke absensi kemarin
+	overnightSchedule, isOvernight := h.retrieveOvernightDailySchedule(schedule, currentUserID, currentCheckIn, dataClockOut.TimeZone)
+	if isOvernight {
+		toDay = time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	}
+
 	//check tanggal dalam range aturan jadwal?
 	isDateInRange, err := presence.IsDateInRange(toDay, schedule.StartDate, schedule.EndDate)
 	if err != nil {
@@ -776,28 +996,35 @@ func (h attendanceHandler) ClockOut(c *gin.Context) {
 		return
 	}
 
-	// Check In Radius
-	if inRadius := schedule.InRange(dataClockOut.Latitude, dataClockOut.Longitude); !inRadius {
-		err = errors.New("maaf anda berada di luar radius")
+	// Check daily Schedule, blok jadwal harian dipilih sesuai jam absen
+	dailySchedules, err := retrieveDailySchedules(h.dailyScheduleService, h.scheduleExceptionService, &schedule, toDay)
+	if err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
-	// Check daily Schedule
-	isExistDailySchedule, dailyScheduleID, err := h.dailyScheduleService.CheckHaveDailySchedule(int(schedule.ID), converter.GetDayNameFromDateString(toDay))
-	if err != nil {
+	if len(dailySchedules) == 0 {
+		err = errors.New("absensi tidak bisa dilakukan pada hari ini")
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
-	if !isExistDailySchedule {
-		err = errors.New("absensi tidak bisa dilakukan pada hari ini")
+	dailySchedule := model.MatchDailySchedule(dailySchedules, currentCheckIn, dataClockOut.TimeZone, "clock_out")
+	if isOvernight {
+		dailySchedule = overnightSchedule
+	}
+
+	// Check batas waktu absen keluar
+	if err := dailySchedule.ClockOutError(toDay, currentCheckIn, dataClockOut.TimeZone); err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
 
-	dailySchedule, err := h.dailyScheduleService.RetrieveDailySchedule(dailyScheduleID)
-	if err != nil {
+	// pertemuan pindahan / kelas pengganti bisa memakai ruangan lain
+	schedule.ApplyException(dailySchedule)
+
+	// Check In Geofence
+	if err := schedule.GeofenceError("maaf anda berada di luar radius", dataClockOut.Latitude, dataClockOut.Longitude); err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
@@ -810,10 +1037,10 @@ func (h attendanceHandler) ClockOut(c *gin.Context) {
 	}
 
 	// Check Attendance is Exist or not
-	isExistAttendance := h.attendanceService.CheckIsExistByDate(currentUserID, int(schedule.ID), toDay)
+	isExistAttendance := h.attendanceService.CheckIsExistByDate(currentUserID, int(schedule.ID), int(dailySchedule.ID), toDay)
 	if isExistAttendance {
 		// Get
-		attendance, err := h.attendanceService.RetrieveAttendanceByDate(currentUserID, int(schedule.ID), toDay)
+		attendance, err := h.attendanceService.RetrieveAttendanceByDate(currentUserID, int(schedule.ID), int(dailySchedule.ID), toDay)
 		if err != nil {
 			response.New(c).Error(http.StatusBadRequest, err)
 			return
@@ -833,14 +1060,19 @@ func (h attendanceHandler) ClockOut(c *gin.Context) {
 			}
 		}
 		attendanceNew.ClockOut = currentCheckIn
-		attendanceNew.EarlyOut = calculation.CalculateEarlyDuration(dailySchedule.EndTime, currentCheckIn, dataClockOut.TimeZone)
+		attendanceNew.EarlyOut = calculation.CalculateEarlyDuration(toDay, dailySchedule.EndTime, dailySchedule.IsOvernight(), currentCheckIn, dataClockOut.TimeZone)
 		attendanceNew.StatusPresence = attendanceNew.GenerateStatusPresence()
 		attendanceNew.Status = attendanceNew.GenerateStatus()
+		attendanceNew = applyAttendancePolicy(h.attendancePolicyService, attendanceNew, true)
 		attendanceNew.LatitudeOut = dataClockOut.Latitude
 		attendanceNew.LongitudeOut = dataClockOut.Longitude
 		attendanceNew.TimeZoneOut = dataClockOut.TimeZone
 		attendanceNew.LocationOut = dataClockOut.Location
 
+		// scan ulang hanya menggeser jam keluar, tanda terima dan webhook dikirim saat absen keluar pertama
+		// atau saat hasil presensi berubah
+		isUpdated := attendance.ClockOut == 0 || attendance.EarlyOut != attendanceNew.EarlyOut || attendance.Status != attendanceNew.Status
+
 		// Update attendance
 		attendance, err = h.attendanceService.UpdateAttendance(int(attendance.ID), attendanceNew)
 		if err != nil {
@@ -866,6 +1098,10 @@ func (h attendanceHandler) ClockOut(c *gin.Context) {
 			Location:     dataClockOut.Location,
 		})
 
+		if isUpdated {
+			h.publishReceipt(model.NotificationEventClockOut, attendance, schedule)
+			h.webhookDispatcher.Dispatch(model.WebhookEventClockOut, model.NewWebhookAttendanceData(attendance))
+		}
 		response.New(c).Data(http.StatusCreated, "berhasil absen keluar", attendance)
 
 	} else {
@@ -876,18 +1112,20 @@ func (h attendanceHandler) ClockOut(c *gin.Context) {
 				UpdatedBy: currentUserID,
 				CreatedAt: time.Now(),
 			},
-			UserID:       currentUserID,
-			ScheduleID:   schedule.ID,
-			Date:         toDay,
-			ClockOut:     currentCheckIn,
-			EarlyOut:     calculation.CalculateEarlyDuration(dailySchedule.EndTime, currentCheckIn, dataClockOut.TimeZone),
-			LatitudeOut:  dataClockOut.Latitude,
-			LongitudeOut: dataClockOut.Longitude,
-			TimeZoneOut:  dataClockOut.TimeZone,
-			LocationOut:  dataClockOut.Location,
+			UserID:          currentUserID,
+			ScheduleID:      schedule.ID,
+			DailyScheduleID: dailySchedule.ID,
+			Date:            toDay,
+			ClockOut:        currentCheckIn,
+			EarlyOut:        calculation.CalculateEarlyDuration(toDay, dailySchedule.EndTime, dailySchedule.IsOvernight(), currentCheckIn, dataClockOut.TimeZone),
+			LatitudeOut:     dataClockOut.Latitude,
+			LongitudeOut:    dataClockOut.Longitude,
+			TimeZoneOut:     dataClockOut.TimeZone,
+			LocationOut:     dataClockOut.Location,
 		}
 		newAttendance.StatusPresence = newAttendance.GenerateStatusPresence()
 		newAttendance.Status = newAttendance.GenerateStatus()
+		newAttendance = applyAttendancePolicy(h.attendancePolicyService, newAttendance, true)
 		// Create attendance
 		attendance, err := h.attendanceService.CreateAttendance(newAttendance)
 		if err != nil {
@@ -913,6 +1151,8 @@ func (h attendanceHandler) ClockOut(c *gin.Context) {
 			Location:     attendance.LocationOut,
 		})
 
+		h.publishReceipt(model.NotificationEventClockOut, attendance, schedule)
+		h.webhookDispatcher.Dispatch(model.WebhookEventClockOut, model.NewWebhookAttendanceData(attendance))
 		response.New(c).Data(http.StatusCreated, "berhasil absen keluar", attendance)
 	}
 
@@ -953,6 +1193,73 @@ func (h attendanceHandler) Summary(c *gin.Context) {
 	response.New(c).Data(http.StatusOK, "sukses mendapatkan rangkuman data", data)
 }
 
+// Eligibility ... Eligibility Attendance
+// @Summary Exam Eligibility Report
+// @Description Rekap kehadiran setiap mahasiswa pada jadwal: pertemuan terlaksana, hadir, izin, persentase dan kelayakan ujian.
+// @Description Ambang kehadiran dari jadwal, mata kuliah lalu config eligibility.min_attendance. Mahasiswa hanya melihat datanya sendiri
+// @Tags Attendance
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AttendanceEligibilityResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attendance/eligibility [get]
+// @Security BearerTokenAuth
+// @param schedule_id query string true "id schedule"
+func (h attendanceHandler) Eligibility(c *gin.Context) {
+	scheduleID, err := strconv.Atoi(c.Query("schedule_id"))
+	if scheduleID < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("schedule_id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var schedule model.Schedule
+	if h.middleware.IsSuperAdmin(c) {
+		schedule, err = h.scheduleService.RetrieveSchedule(scheduleID)
+	} else if h.middleware.IsAdmin(c) {
+		schedule, err = h.scheduleService.RetrieveScheduleByOwner(scheduleID, currentUserID)
+	} else if h.userScheduleService.CheckUserInSchedule(scheduleID, currentUserID) {
+		schedule, err = h.scheduleService.RetrieveSchedule(scheduleID)
+	} else {
+		err = errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+	}
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	rule := model.NewEligibilityRule(h.infra.Config().Sub("eligibility"))
+	rule.MinAttendance = schedule.GetMinAttendance(rule.MinAttendance)
+
+	students, err := h.attendanceService.ListAttendanceEligibility(scheduleID, rule)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result := model.AttendanceEligibilityReport{
+		ScheduleID:   schedule.ID,
+		ScheduleName: schedule.Name,
+		HeldMeeting:  h.attendanceService.CountHeldMeeting(scheduleID),
+		Rule:         rule,
+		Students:     []model.AttendanceEligibility{},
+	}
+	for _, student := range students {
+		if h.middleware.IsUser(c) && student.UserID != currentUserID {
+			continue
+		}
+		student.HeldMeeting = result.HeldMeeting
+		result.Students = append(result.Students, rule.Evaluate(student))
+	}
+
+	response.New(c).Data(http.StatusOK, "sukses mendapatkan rekap kelayakan ujian", result)
+}
+
 // AutoGenerate ... Auto Generate Attendance
 // @Summary Auto Generate Attendance
 // @Description Auto Generate Attendance
@@ -988,28 +1295,47 @@ func (h attendanceHandler) AutoGenerate(c *gin.Context) {
 			log.Printf("Error Get List Date E: %v\n", err)
 			break
 		}
+		nonTeachingDays, err := h.academicCalendarService.ListNonTeachingDayBySchedule(int(userSchedule.ScheduleID), startDate, endDate)
+		if err != nil {
+			log.Printf("Error Get List Non Teaching Day E: %v\n", err)
+		}
 		for j, date := range listDates {
-			wg.Add(1)
-			go func(j int, date string, userSchedule model.UserSchedule) {
-				if !h.attendanceService.CheckIsExistByDate(userSchedule.UserID, int(userSchedule.ScheduleID), date) {
-					// Create Attendance with default (alpa)
-					// Buat Data Presensi kosong / tidak hadir secara default terlebih dahulu
-					dataAttendance := model.Attendance{
-						UserID:         userSchedule.UserID,
-						ScheduleID:     userSchedule.ScheduleID,
-						Date:           date,
-						ClockIn:        0,
-						ClockOut:       0,
-						Status:         "-",
-						StatusPresence: "not_presence",
-					}
-					_, err := h.attendanceService.CreateAttendance(dataAttendance)
-					if err != nil {
-						log.Printf("[Error] [Attendance-CreateAttendance] E: %v\n", err)
+			// Lewati hari libur, minggu ujian dan masa jeda pada kalender akademik
+			if model.IsNonTeachingDate(nonTeachingDays, date) {
+				continue
+			}
+			// presensi dibuat per blok jadwal harian yang berlangsung (pengecualian batal / pindah sudah diperhitungkan),
+			// tanggal tanpa pertemuan dilewati
+			schedule := userSchedule.Schedule
+			dailySchedules, err := retrieveDailySchedules(h.dailyScheduleService, h.scheduleExceptionService, &schedule, date)
+			if err != nil {
+				log.Printf("Error Get List Daily Schedule E: %v\n", err)
+				continue
+			}
+			for _, dailySchedule := range dailySchedules {
+				wg.Add(1)
+				go func(j int, date string, dailyScheduleID uint, userSchedule model.UserSchedule) {
+					if !h.attendanceService.CheckIsExistByDate(userSchedule.UserID, int(userSchedule.ScheduleID), int(dailyScheduleID), date) {
+						// Create Attendance with default (alpa)
+						// Buat Data Presensi kosong / tidak hadir secara default terlebih dahulu
+						dataAttendance := model.Attendance{
+							UserID:          userSchedule.UserID,
+							ScheduleID:      userSchedule.ScheduleID,
+							DailyScheduleID: dailyScheduleID,
+							Date:            date,
+							ClockIn:         0,
+							ClockOut:        0,
+							Status:          "-",
+							StatusPresence:  "not_presence",
+						}
+						_, err := h.attendanceService.CreateAttendance(dataAttendance)
+						if err != nil {
+							log.Printf("[Error] [Attendance-CreateAttendance] E: %v\n", err)
+						}
 					}
-				}
-				wg.Done()
-			}(j, date, userSchedule)
+					wg.Done()
+				}(j, date, dailySchedule.ID, userSchedule)
+			}
 
 		}
 	}
@@ -1017,3 +1343,125 @@ func (h attendanceHandler) AutoGenerate(c *gin.Context) {
 
 	response.New(c).Write(http.StatusOK, "sukses melakukan generate data presensi")
 }
+
+// retrieveScheduleByScan mencari jadwal dari payload hasil scan, baik kode qr statis maupun berputar
+func (h attendanceHandler) retrieveScheduleByScan(payload string) (model.Schedule, error) {
+	now := time.Now()
+	schedule, err := h.scheduleService.RetrieveScheduleByQRcode(payload)
+	if err != nil {
+		code, token := myqr.SplitPayload(payload)
+		if token == "" {
+			return model.Schedule{}, err
+		}
+		schedule, err = h.scheduleService.RetrieveScheduleByQRcode(code)
+		if err != nil {
+			return model.Schedule{}, err
+		}
+	}
+
+	if !schedule.IsValidQRCode(payload, now) {
+		return model.Schedule{}, errors.New("kode qr sudah kedaluwarsa, silahkan scan ulang kode qr yang sedang tampil")
+	}
+	return schedule, nil
+}
+
+// markSessionHeld menandai pertemuan (blok) dari presensi sudah terlaksana saat ada presensi masuk
+func (h attendanceHandler) markSessionHeld(attendance model.Attendance) {
+	var session model.Session
+	var err error
+	if attendance.SessionID > 0 {
+		session, err = h.sessionService.RetrieveSession(int(attendance.SessionID))
+	} else {
+		session, err = h.sessionService.RetrieveSessionByDailySchedule(int(attendance.ScheduleID), int(attendance.DailyScheduleID), converter.GetOnlyDateString(attendance.Date))
+	}
+	if err != nil || session.Status == model.SessionHeld {
+		return
+	}
+	if err := h.sessionService.UpdateSessionStatus(int(session.ID), model.SessionHeld); err != nil {
+		log.Printf("[Error] [Session-UpdateSessionStatus] E: %v\n", err)
+	}
+}
+
+// publishReceipt bukti absen masuk / keluar ke kotak masuk mahasiswa
+func (h attendanceHandler) publishReceipt(event string, attendance model.Attendance, schedule model.Schedule) {
+	h.notifier.Publish(notification.Event{
+		Type:      event,
+		Recipient: notification.Recipient{UserID: attendance.UserID},
+		Data: map[string]string{
+			"schedule_name": schedule.Name,
+			"schedule_code": schedule.Code,
+			"date":          converter.GetOnlyDateString(attendance.Date),
+			"status":        attendance.Status,
+		},
+		Channels: []string{model.NotificationChannelInApp},
+	})
+}
+
+// applyAttendancePolicy menilai presensi dengan kebijakan presensi jadwal, tanpa kebijakan dipakai penilaian bawaan.
+// Saat absen masuk batas absen keluar belum lewat sehingga missing_clock_out dinilai kemudian oleh task attendance_policy
+func applyAttendancePolicy(attendancePolicyService service.AttendancePolicyService, data model.Attendance, isClockOutClosed bool) model.Attendance {
+	policy, err := attendancePolicyService.RetrieveAttendancePolicyBySchedule(int(data.ScheduleID))
+	if err != nil {
+		log.Printf("[Error] [AttendancePolicy-RetrieveAttendancePolicyBySchedule] E: %v\n", err)
+	}
+	return policy.Apply(data, isClockOutClosed)
+}
+
+// retrieveOvernightDailySchedule blok lintas hari kemarin yang absensinya belum ditutup, hanya berlaku
+// selama belum ada blok hari ini yang dimulai
+func (h attendanceHandler) retrieveOvernightDailySchedule(schedule model.Schedule, userID int, checkIn int64, timeZone int) (model.DailySchedule, bool) {
+	timeCheck := converter.MillisToTimeString(checkIn, timeZone)
+	now := time.Now()
+
+	todaySchedules, err := retrieveDailySchedules(h.dailyScheduleService, h.scheduleExceptionService, &schedule, now.Format("2006-01-02"))
+	if err != nil {
+		return model.DailySchedule{}, false
+	}
+	for _, dailySchedule := range todaySchedules {
+		if dailySchedule.StartTime <= timeCheck {
+			return model.DailySchedule{}, false
+		}
+	}
+
+	yesterday := now.AddDate(0, 0, -1).Format("2006-01-02")
+	yesterdaySchedules, err := retrieveDailySchedules(h.dailyScheduleService, h.scheduleExceptionService, &schedule, yesterday)
+	if err != nil {
+		return model.DailySchedule{}, false
+	}
+	for _, dailySchedule := range yesterdaySchedules {
+		if !dailySchedule.IsOvernight() || timeCheck >= dailySchedule.StartTime {
+			continue
+		}
+		attendance, err := h.attendanceService.RetrieveAttendanceByDate(userID, int(schedule.ID), int(dailySchedule.ID), yesterday)
+		if err == nil && attendance.ClockIn > 0 && attendance.ClockOut == 0 {
+			return dailySchedule, true
+		}
+	}
+	return model.DailySchedule{}, false
+}
+
+// retrieveDailySchedules semua blok jadwal harian pada tanggal tersebut (urut jam mulai), kosong jika tidak ada pertemuan.
+// Blok mingguan yang tidak dibatalkan / dipindah digabung dengan pertemuan pindahan / kelas pengganti sebagai blok tanpa id.
+// Batas waktu absen yang kosong memakai bawaan jadwal
+func retrieveDailySchedules(dailyScheduleService service.DailyScheduleService, scheduleExceptionService service.ScheduleExceptionService, schedule *model.Schedule, date string) ([]model.DailySchedule, error) {
+	dailySchedules, err := dailyScheduleService.ListDailyScheduleByDate(int(schedule.ID), date)
+	if err != nil {
+		return nil, err
+	}
+
+	exceptions, err := scheduleExceptionService.ListMeetingException(int(schedule.ID), date)
+	if err != nil {
+		return nil, err
+	}
+	for _, exception := range exceptions {
+		dailySchedules = append(dailySchedules, exception.GetDailySchedule())
+	}
+	sort.SliceStable(dailySchedules, func(i, j int) bool {
+		return dailySchedules[i].StartTime < dailySchedules[j].StartTime
+	})
+
+	for i := range dailySchedules {
+		dailySchedules[i].ClockWindow = dailySchedules[i].ClockWindow.WithDefault(schedule.ClockWindow)
+	}
+	return dailySchedules, nil
+}
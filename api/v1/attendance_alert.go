@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/response"
+	"attendance-api/common/util/pagination"
+	"attendance-api/infra"
+	"attendance-api/model"
+	"attendance-api/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttendanceAlertHandler interface {
+	List(c *gin.Context)
+}
+
+type attendanceAlertHandler struct {
+	attendanceAlertService service.AttendanceAlertService
+	infra                  infra.Infra
+	middleware             middleware.Middleware
+}
+
+func NewAttendanceAlertHandler(
+	attendanceAlertService service.AttendanceAlertService,
+	infra infra.Infra,
+	middleware middleware.Middleware) AttendanceAlertHandler {
+	return &attendanceAlertHandler{
+		attendanceAlertService: attendanceAlertService,
+		infra:                  infra,
+		middleware:             middleware,
+	}
+}
+
+// List ... List all Attendance Alert
+// @Summary List all Attendance Alert
+// @Description Riwayat peringatan kehadiran yang dikirim job attendance_alert, filter user_id, schedule_id dan level (warning / critical).
+// @Description Dosen hanya melihat peringatan pada jadwal miliknya
+// @Tags Attendance Alert
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AttendanceAlertResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-alert/list [get]
+// @Security BearerTokenAuth
+func (h attendanceAlertHandler) List(c *gin.Context) {
+	pagination := pagination.GeneratePaginationFromRequest(c)
+	var data model.AttendanceAlert
+	c.BindQuery(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		if !h.middleware.IsAdmin(c) {
+			response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+			return
+		}
+		data.OwnerID = currentUserID
+	}
+
+	dataList, err := h.attendanceAlertService.ListAttendanceAlert(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	metaList, err := h.attendanceAlertService.ListAttendanceAlertMeta(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	response.New(c).List(http.StatusOK, "sukses mengambil list data", dataList, metaList)
+}
@@ -0,0 +1,403 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/notification"
+	"attendance-api/common/http/response"
+	"attendance-api/common/http/webhook"
+	"attendance-api/common/util/calculation"
+	"attendance-api/common/util/converter"
+	"attendance-api/common/util/pagination"
+	"attendance-api/infra"
+	"attendance-api/model"
+	"attendance-api/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type AttendanceCorrectionHandler interface {
+	Create(c *gin.Context)
+	Retrieve(c *gin.Context)
+	Cancel(c *gin.Context)
+	Review(c *gin.Context)
+	List(c *gin.Context)
+}
+
+type attendanceCorrectionHandler struct {
+	attendanceCorrectionService service.AttendanceCorrectionService
+	attendanceService           service.AttendanceService
+	scheduleService             service.ScheduleService
+	dailyScheduleService        service.DailyScheduleService
+	scheduleExceptionService    service.ScheduleExceptionService
+	attendancePolicyService     service.AttendancePolicyService
+	notifier                    notification.Notifier
+	webhookDispatcher           webhook.Dispatcher
+	infra                       infra.Infra
+	middleware                  middleware.Middleware
+}
+
+func NewAttendanceCorrectionHandler(
+	attendanceCorrectionService service.AttendanceCorrectionService,
+	attendanceService service.AttendanceService,
+	scheduleService service.ScheduleService,
+	dailyScheduleService service.DailyScheduleService,
+	scheduleExceptionService service.ScheduleExceptionService,
+	attendancePolicyService service.AttendancePolicyService,
+	notifier notification.Notifier,
+	webhookDispatcher webhook.Dispatcher,
+	infra infra.Infra,
+	middleware middleware.Middleware) AttendanceCorrectionHandler {
+	return &attendanceCorrectionHandler{
+		attendanceCorrectionService: attendanceCorrectionService,
+		attendanceService:           attendanceService,
+		scheduleService:             scheduleService,
+		dailyScheduleService:        dailyScheduleService,
+		scheduleExceptionService:    scheduleExceptionService,
+		attendancePolicyService:     attendancePolicyService,
+		notifier:                    notifier,
+		webhookDispatcher:           webhookDispatcher,
+		infra:                       infra,
+		middleware:                  middleware,
+	}
+}
+
+// Create ... Create Attendance Correction
+// @Summary Create New Attendance Correction
+// @Description Mahasiswa mengajukan koreksi jam masuk/keluar untuk presensi miliknya beserta alasan dan bukti
+// @Tags Attendance Correction
+// @Accept       json
+// @Produce      json
+// @Param data body model.AttendanceCorrectionForm true "data"
+// @Success 200 {object} model.AttendanceCorrectionResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-correction/create [post]
+// @Security BearerTokenAuth
+func (h attendanceCorrectionHandler) Create(c *gin.Context) {
+	var data model.AttendanceCorrection
+	c.BindJSON(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !h.middleware.IsUser(c) {
+		err = errors.New("maaf hanya role user yang bisa mengajukan koreksi presensi")
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	attendance, err := h.attendanceService.RetrieveAttendanceByUserID(int(data.AttendanceID), currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("presensi: presensi tidak ditemukan"))
+		return
+	}
+
+	if h.attendanceCorrectionService.CheckIsExistPending(int(attendance.ID)) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("presensi: masih ada pengajuan koreksi yang menunggu pemeriksaan"))
+		return
+	}
+
+	attendanceCorrection := model.AttendanceCorrection{
+		GormCustom: model.GormCustom{
+			CreatedBy: currentUserID,
+			UpdatedBy: currentUserID,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		AttendanceID: attendance.ID,
+		UserID:       currentUserID,
+		ScheduleID:   attendance.ScheduleID,
+		OwnerID:      int(attendance.Schedule.OwnerID),
+		Date:         converter.GetOnlyDateString(attendance.Date),
+		ClockIn:      data.ClockIn,
+		ClockOut:     data.ClockOut,
+		TimeZone:     data.TimeZone,
+		Reason:       data.Reason,
+		Evidence:     data.Evidence,
+		Status:       model.CorrectionPending,
+	}
+	attendanceCorrection.SetOriginal(attendance)
+
+	if attendanceCorrection.TimeZone == 0 {
+		attendanceCorrection.TimeZone = attendance.TimeZoneIn
+	}
+	if attendanceCorrection.TimeZone == 0 {
+		attendanceCorrection.TimeZone = converter.GetTimeZone(attendance.Schedule.Latitude, attendance.Schedule.Longitude)
+	}
+
+	if attendanceCorrection.ClockIn <= 0 {
+		response.New(c).Error(http.StatusBadRequest, errors.New("jam masuk: tidak boleh kosong"))
+		return
+	}
+	if attendanceCorrection.ClockIn > time.Now().UnixMilli() {
+		response.New(c).Error(http.StatusBadRequest, errors.New("jam masuk: tidak boleh melebihi waktu sekarang"))
+		return
+	}
+	if converter.MillisToDateString(attendanceCorrection.ClockIn, attendanceCorrection.TimeZone) != attendanceCorrection.Date {
+		response.New(c).Error(http.StatusBadRequest, errors.New("jam masuk: harus pada tanggal presensi"))
+		return
+	}
+	if attendanceCorrection.ClockOut < 0 || (attendanceCorrection.ClockOut > 0 && attendanceCorrection.ClockOut <= attendanceCorrection.ClockIn) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("jam keluar: harus setelah jam masuk"))
+		return
+	}
+	if err := validation.Validate(attendanceCorrection.Reason, validation.Required); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("alasan: %v", err))
+		return
+	}
+	if err := validation.Validate(attendanceCorrection.Evidence, validation.Length(0, 255)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("bukti: %v", err))
+		return
+	}
+
+	result, err := h.attendanceCorrectionService.CreateAttendanceCorrection(attendanceCorrection)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusCreated, "sukses membuat data", result)
+}
+
+// Retrieve ... Retrieve Attendance Correction
+// @Summary Retrieve Single Attendance Correction
+// @Description Retrieve Single Attendance Correction milik sendiri atau untuk jadwal milik dosen
+// @Tags Attendance Correction
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AttendanceCorrectionResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-correction/retrieve [get]
+// @Security BearerTokenAuth
+// @param id query string true "id attendance correction"
+func (h attendanceCorrectionHandler) Retrieve(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var result model.AttendanceCorrection
+	if h.middleware.IsSuperAdmin(c) {
+		result, err = h.attendanceCorrectionService.RetrieveAttendanceCorrection(id)
+	} else if h.middleware.IsAdmin(c) {
+		result, err = h.attendanceCorrectionService.RetrieveAttendanceCorrectionByOwner(id, currentUserID)
+	} else {
+		result, err = h.attendanceCorrectionService.RetrieveAttendanceCorrectionByUser(id, currentUserID)
+	}
+
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusCreated, "sukses mengambil data", result)
+}
+
+// Cancel ... Cancel Attendance Correction
+// @Summary Cancel Attendance Correction
+// @Description Mahasiswa membatalkan pengajuan koreksi yang belum diperiksa
+// @Tags Attendance Correction
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AttendanceCorrectionResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-correction/cancel [post]
+// @Security BearerTokenAuth
+// @param id query string true "id attendance correction"
+func (h attendanceCorrectionHandler) Cancel(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.attendanceCorrectionService.CancelAttendanceCorrection(id, currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses membatalkan pengajuan", result)
+}
+
+// Review ... Review Attendance Correction
+// @Summary Approve or Reject Attendance Correction
+// @Description Dosen pemilik jadwal atau superadmin memeriksa pengajuan, persetujuan menghitung ulang keterlambatan dan pulang cepat lalu memperbarui presensi
+// @Tags Attendance Correction
+// @Accept       json
+// @Produce      json
+// @Param data body model.AttendanceCorrectionReview true "data"
+// @Success 200 {object} model.AttendanceCorrectionResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-correction/review [put]
+// @Security BearerTokenAuth
+// @param id query string true "id attendance correction"
+func (h attendanceCorrectionHandler) Review(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var data model.AttendanceCorrectionReview
+	c.BindJSON(&data)
+
+	if !data.IsValidStatus() {
+		response.New(c).Error(http.StatusBadRequest, errors.New("status: status harus approved atau rejected"))
+		return
+	}
+
+	var attendanceCorrection model.AttendanceCorrection
+	if h.middleware.IsSuperAdmin(c) {
+		attendanceCorrection, err = h.attendanceCorrectionService.RetrieveAttendanceCorrection(id)
+	} else if h.middleware.IsAdmin(c) {
+		attendanceCorrection, err = h.attendanceCorrectionService.RetrieveAttendanceCorrectionByOwner(id, currentUserID)
+	} else {
+		err = errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+	}
+
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	if attendanceCorrection.Status != model.CorrectionPending {
+		response.New(c).Error(http.StatusBadRequest, errors.New("pengajuan sudah diperiksa"))
+		return
+	}
+
+	var corrected model.Attendance
+	if data.Status == model.CorrectionApproved {
+		corrected, err = h.correctedAttendance(attendanceCorrection)
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	result, err := h.attendanceCorrectionService.ReviewAttendanceCorrection(id, currentUserID, data, corrected)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if result.Status == model.CorrectionApproved {
+		h.webhookDispatcher.Dispatch(model.WebhookEventStatusChanged, model.NewWebhookAttendanceData(result.Attendance))
+	}
+	h.notifier.Publish(notification.Event{
+		Type:      model.NotificationEventCorrectionReviewed,
+		Recipient: notification.Recipient{UserID: result.UserID, Name: result.User.FirstName, Email: result.User.Email},
+		Data: map[string]string{
+			"date":          converter.GetOnlyDateString(result.Date),
+			"schedule_name": result.Attendance.Schedule.Name,
+			"status":        result.Status,
+			"note":          data.Note,
+		},
+	})
+	response.New(c).Data(http.StatusOK, "sukses menyimpan keputusan", result)
+}
+
+// List ... List all Attendance Correction
+// @Summary List all Attendance Correction
+// @Description List all Attendance Correction, mahasiswa melihat pengajuan sendiri dan dosen melihat pengajuan untuk jadwal miliknya
+// @Tags Attendance Correction
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AttendanceCorrectionResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-correction/list [get]
+// @Security BearerTokenAuth
+func (h attendanceCorrectionHandler) List(c *gin.Context) {
+	pagination := pagination.GeneratePaginationFromRequest(c)
+	var data model.AttendanceCorrection
+	c.BindQuery(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		if h.middleware.IsAdmin(c) {
+			data.OwnerID = currentUserID
+		} else {
+			data.UserID = currentUserID
+		}
+	}
+
+	dataList, err := h.attendanceCorrectionService.ListAttendanceCorrection(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	metaList, err := h.attendanceCorrectionService.ListAttendanceCorrectionMeta(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	response.New(c).List(http.StatusOK, "sukses mengambil list data", dataList, metaList)
+}
+
+// correctedAttendance menghitung nilai presensi hasil koreksi dengan aturan yang sama seperti update presensi
+func (h attendanceCorrectionHandler) correctedAttendance(attendanceCorrection model.AttendanceCorrection) (model.Attendance, error) {
+	schedule, err := h.scheduleService.RetrieveSchedule(int(attendanceCorrection.ScheduleID))
+	if err != nil {
+		return model.Attendance{}, err
+	}
+
+	date := converter.GetOnlyDateString(attendanceCorrection.Date)
+	dailySchedules, err := retrieveDailySchedules(h.dailyScheduleService, h.scheduleExceptionService, &schedule, date)
+	if err != nil {
+		return model.Attendance{}, err
+	}
+	if len(dailySchedules) == 0 {
+		return model.Attendance{}, errors.New("tidak ada jadwal pada tanggal presensi")
+	}
+
+	// presensi sudah terikat ke satu blok jadwal harian, presensi lama dicocokkan dengan jam masuk
+	dailySchedule, isFound := model.FindDailySchedule(dailySchedules, attendanceCorrection.Attendance.DailyScheduleID)
+	if !isFound {
+		dailySchedule = model.MatchDailySchedule(dailySchedules, attendanceCorrection.ClockIn, attendanceCorrection.TimeZone, "clock_in")
+	}
+
+	data := model.Attendance{
+		ScheduleID:  schedule.ID,
+		ClockIn:     attendanceCorrection.ClockIn,
+		ClockOut:    attendanceCorrection.ClockOut,
+		TimeZoneIn:  attendanceCorrection.TimeZone,
+		TimeZoneOut: attendanceCorrection.TimeZone,
+		EarlyOut:    "00:00:00",
+	}
+	data.LateIn = calculation.CalculateLateDuration(date, dailySchedule.StartTime, data.ClockIn, data.TimeZoneIn, schedule.LateDuration)
+	if data.ClockOut > 0 {
+		data.EarlyOut = calculation.CalculateEarlyDuration(date, dailySchedule.EndTime, dailySchedule.IsOvernight(), data.ClockOut, data.TimeZoneOut)
+	}
+	data.StatusPresence = data.GenerateStatusPresence()
+	data.Status = data.GenerateStatus()
+	data = applyAttendancePolicy(h.attendancePolicyService, data, dailySchedule.IsClockOutClosed(date, time.Now()))
+	return data, nil
+}
@@ -0,0 +1,283 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/response"
+	"attendance-api/infra"
+	"attendance-api/model"
+	"attendance-api/service"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttendanceGradeHandler interface {
+	RetrieveFormula(c *gin.Context)
+	UpdateFormula(c *gin.Context)
+	Score(c *gin.Context)
+	Export(c *gin.Context)
+}
+
+type attendanceGradeHandler struct {
+	attendanceGradeService service.AttendanceGradeService
+	attendanceService      service.AttendanceService
+	scheduleService        service.ScheduleService
+	userScheduleService    service.UserScheduleService
+	infra                  infra.Infra
+	middleware             middleware.Middleware
+}
+
+func NewAttendanceGradeHandler(
+	attendanceGradeService service.AttendanceGradeService,
+	attendanceService service.AttendanceService,
+	scheduleService service.ScheduleService,
+	userScheduleService service.UserScheduleService,
+	infra infra.Infra,
+	middleware middleware.Middleware) AttendanceGradeHandler {
+	return &attendanceGradeHandler{
+		attendanceGradeService: attendanceGradeService,
+		attendanceService:      attendanceService,
+		scheduleService:        scheduleService,
+		userScheduleService:    userScheduleService,
+		infra:                  infra,
+		middleware:             middleware,
+	}
+}
+
+// RetrieveFormula ... Retrieve Attendance Grade Formula
+// @Summary Retrieve Attendance Grade Formula
+// @Description Rumus nilai kehadiran jadwal, jadwal yang belum diatur memakai rumus bawaan
+// @Tags Attendance Grade
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AttendanceGradeFormulaResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-grade/formula [get]
+// @Security BearerTokenAuth
+// @param schedule_id query string true "id schedule"
+func (h attendanceGradeHandler) RetrieveFormula(c *gin.Context) {
+	scheduleID, err := strconv.Atoi(c.Query("schedule_id"))
+	if scheduleID < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("schedule_id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	schedule, err := h.retrieveSchedule(c, scheduleID, false)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	response.New(c).Data(http.StatusOK, "sukses mengambil data", h.retrieveFormula(schedule))
+}
+
+// UpdateFormula ... Update Attendance Grade Formula
+// @Summary Update Attendance Grade Formula
+// @Description Poin setiap pertemuan 0 - 100 untuk hadir, terlambat, pulang cepat, sakit dan izin. exclude_excused
+// @Description membuat sakit dan izin tidak dihitung sebagai pertemuan, weight adalah bobot terhadap nilai akhir (persen)
+// @Tags Attendance Grade
+// @Accept       json
+// @Produce      json
+// @Param data body model.AttendanceGradeFormulaForm true "data"
+// @Success 200 {object} model.AttendanceGradeFormulaResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-grade/formula [put]
+// @Security BearerTokenAuth
+// @param schedule_id query string true "id schedule"
+func (h attendanceGradeHandler) UpdateFormula(c *gin.Context) {
+	scheduleID, err := strconv.Atoi(c.Query("schedule_id"))
+	if scheduleID < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("schedule_id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	schedule, err := h.retrieveSchedule(c, scheduleID, true)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var data model.AttendanceGradeFormula
+	c.BindJSON(&data)
+
+	if err := data.Validate(); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	data.ID = 0
+	data.ScheduleID = schedule.ID
+	data.OwnerID = int(schedule.OwnerID)
+	data.CreatedBy = currentUserID
+	data.CreatedAt = time.Now()
+	data.UpdatedBy = currentUserID
+	data.UpdatedAt = time.Now()
+
+	result, err := h.attendanceGradeService.SaveAttendanceGradeFormula(data)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
+}
+
+// Score ... Attendance Grade Score
+// @Summary Attendance Grade Score
+// @Description Nilai kehadiran setiap mahasiswa pada jadwal (0 - 100) dan komponen nilai akhir sesuai bobot.
+// @Description Mahasiswa hanya melihat nilainya sendiri
+// @Tags Attendance Grade
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AttendanceGradeResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-grade/score [get]
+// @Security BearerTokenAuth
+// @param schedule_id query string true "id schedule"
+func (h attendanceGradeHandler) Score(c *gin.Context) {
+	scheduleID, err := strconv.Atoi(c.Query("schedule_id"))
+	if scheduleID < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("schedule_id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	schedule, err := h.retrieveSchedule(c, scheduleID, false)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.report(schedule)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if h.middleware.IsUser(c) {
+		students := []model.AttendanceGrade{}
+		for _, student := range result.Students {
+			if student.UserID == currentUserID {
+				students = append(students, student)
+			}
+		}
+		result.Students = students
+	}
+
+	response.New(c).Data(http.StatusOK, "sukses mengambil nilai kehadiran", result)
+}
+
+// Export ... Export Attendance Grade
+// @Summary Export Attendance Grade
+// @Description Nilai kehadiran seluruh mahasiswa pada jadwal dalam format CSV untuk diimpor ke sistem nilai
+// @Tags Attendance Grade
+// @Produce      text/csv
+// @Success 200 {file} binary
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-grade/export [get]
+// @Security BearerTokenAuth
+// @param schedule_id query string true "id schedule"
+func (h attendanceGradeHandler) Export(c *gin.Context) {
+	scheduleID, err := strconv.Atoi(c.Query("schedule_id"))
+	if scheduleID < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("schedule_id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	schedule, err := h.retrieveSchedule(c, scheduleID, true)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.report(schedule)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="nilai-kehadiran-%s.csv"`, schedule.Code))
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	writer.Write([]string{"username", "name", "held_meeting", "presence", "late", "early_out", "late_early_out", "sick", "leave_attendance", "score", "grade_component"})
+	for _, student := range result.Students {
+		writer.Write([]string{
+			student.Username,
+			student.Name,
+			strconv.Itoa(student.HeldMeeting),
+			strconv.FormatFloat(student.Presence, 'f', -1, 64),
+			strconv.FormatFloat(student.Late, 'f', -1, 64),
+			strconv.FormatFloat(student.EarlyOut, 'f', -1, 64),
+			strconv.FormatFloat(student.LateEarlyOut, 'f', -1, 64),
+			strconv.Itoa(student.Sick),
+			strconv.Itoa(student.LeaveAttendance),
+			strconv.FormatFloat(student.Score, 'f', 2, 64),
+			strconv.FormatFloat(student.GradeComponent, 'f', 2, 64),
+		})
+	}
+	writer.Flush()
+}
+
+// retrieveSchedule jadwal yang boleh diakses, manage hanya untuk superadmin dan dosen pemilik jadwal
+func (h attendanceGradeHandler) retrieveSchedule(c *gin.Context, scheduleID int, manage bool) (model.Schedule, error) {
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		return model.Schedule{}, err
+	}
+
+	if h.middleware.IsSuperAdmin(c) {
+		return h.scheduleService.RetrieveSchedule(scheduleID)
+	}
+	if h.middleware.IsAdmin(c) {
+		return h.scheduleService.RetrieveScheduleByOwner(scheduleID, currentUserID)
+	}
+	if !manage && h.userScheduleService.CheckUserInSchedule(scheduleID, currentUserID) {
+		return h.scheduleService.RetrieveSchedule(scheduleID)
+	}
+	return model.Schedule{}, errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+}
+
+func (h attendanceGradeHandler) retrieveFormula(schedule model.Schedule) model.AttendanceGradeFormula {
+	formula, err := h.attendanceGradeService.RetrieveAttendanceGradeFormula(int(schedule.ID))
+	if err != nil {
+		return model.DefaultAttendanceGradeFormula(schedule.ID)
+	}
+	return formula
+}
+
+func (h attendanceGradeHandler) report(schedule model.Schedule) (model.AttendanceGradeReport, error) {
+	students, err := h.attendanceGradeService.ListAttendanceGrade(int(schedule.ID))
+	if err != nil {
+		return model.AttendanceGradeReport{}, err
+	}
+
+	result := model.AttendanceGradeReport{
+		ScheduleID:   schedule.ID,
+		ScheduleName: schedule.Name,
+		HeldMeeting:  h.attendanceService.CountHeldMeeting(int(schedule.ID)),
+		Formula:      h.retrieveFormula(schedule),
+		Students:     []model.AttendanceGrade{},
+	}
+	for _, student := range students {
+		student.HeldMeeting = result.HeldMeeting
+		result.Students = append(result.Students, result.Formula.Evaluate(student))
+	}
+	return result, nil
+}
@@ -0,0 +1,409 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/response"
+	"attendance-api/common/util/pagination"
+	"attendance-api/infra"
+	"attendance-api/model"
+	"attendance-api/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type AttendancePolicyHandler interface {
+	Create(c *gin.Context)
+	Retrieve(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	List(c *gin.Context)
+	Assign(c *gin.Context)
+	Recompute(c *gin.Context)
+}
+
+type attendancePolicyHandler struct {
+	attendancePolicyService service.AttendancePolicyService
+	attendanceService       service.AttendanceService
+	scheduleService         service.ScheduleService
+	infra                   infra.Infra
+	middleware              middleware.Middleware
+}
+
+func NewAttendancePolicyHandler(
+	attendancePolicyService service.AttendancePolicyService,
+	attendanceService service.AttendanceService,
+	scheduleService service.ScheduleService,
+	infra infra.Infra,
+	middleware middleware.Middleware) AttendancePolicyHandler {
+	return &attendancePolicyHandler{
+		attendancePolicyService: attendancePolicyService,
+		attendanceService:       attendanceService,
+		scheduleService:         scheduleService,
+		infra:                   infra,
+		middleware:              middleware,
+	}
+}
+
+// Create ... Create Attendance Policy
+// @Summary Create New Attendance Policy
+// @Description Create Attendance Policy, aturan: late / early_out (terlambat / pulang cepat lebih dari minutes menit),
+// @Description min_duration (durasi hadir kurang dari minutes menit), missing_clock_out (tidak absen keluar).
+// @Description Hasil aturan: presence, half_presence atau not_presence
+// @Tags Attendance Policy
+// @Accept       json
+// @Produce      json
+// @Param data body model.AttendancePolicyForm true "data"
+// @Success 200 {object} model.AttendancePolicyResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-policy/create [post]
+// @Security BearerTokenAuth
+func (h attendancePolicyHandler) Create(c *gin.Context) {
+	var data model.AttendancePolicy
+	c.BindJSON(&data)
+
+	if !h.middleware.IsSuperAdmin(c) {
+		err := errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("%v", err))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	data.GormCustom.CreatedBy = currentUserID
+	data.OwnerID = currentUserID
+
+	if err := h.validate(data); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	for i := range data.Rules {
+		data.Rules[i].CreatedBy = currentUserID
+	}
+
+	result, err := h.attendancePolicyService.CreateAttendancePolicy(data)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusCreated, "sukses membuat data", result)
+}
+
+// Retrieve ... Retrieve Attendance Policy
+// @Summary Retrieve Single Attendance Policy
+// @Description Retrieve Single Attendance Policy
+// @Tags Attendance Policy
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AttendancePolicyResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-policy/retrieve [get]
+// @Security BearerTokenAuth
+// @param id query string true "id attendance policy"
+func (h attendancePolicyHandler) Retrieve(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	result, err := h.attendancePolicyService.RetrieveAttendancePolicy(id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusCreated, "sukses mengambil data", result)
+}
+
+// Update ... Update Attendance Policy
+// @Summary Update Single Attendance Policy
+// @Description Update Single Attendance Policy, aturan lama diganti seluruhnya. Presensi lama dinilai ulang lewat recompute
+// @Tags Attendance Policy
+// @Accept       json
+// @Produce      json
+// @Param data body model.AttendancePolicyForm true "data"
+// @Success 200 {object} model.AttendancePolicyResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-policy/update [put]
+// @Security BearerTokenAuth
+// @param id query string true "id attendance policy"
+func (h attendancePolicyHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		err := errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("%v", err))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var data model.AttendancePolicy
+	c.BindJSON(&data)
+
+	data.UpdatedBy = currentUserID
+	data.UpdatedAt = time.Now()
+
+	if err := h.validate(data); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !h.attendancePolicyService.CheckIsExist(id) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("kebijakan presensi tidak ditemukan"))
+		return
+	}
+
+	for i := range data.Rules {
+		data.Rules[i].CreatedBy = currentUserID
+	}
+
+	result, err := h.attendancePolicyService.UpdateAttendancePolicy(id, data)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
+}
+
+// Delete ... Delete Attendance Policy
+// @Summary Delete Single Attendance Policy
+// @Description Delete Single Attendance Policy, jadwal dan fakultas yang memakai kebijakan kembali ke penilaian bawaan
+// @Description dan presensi jadwal tersebut dinilai ulang
+// @Tags Attendance Policy
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AttendancePolicyRecomputeResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-policy/delete [delete]
+// @Security BearerTokenAuth
+// @param id query string true "id attendance policy"
+func (h attendancePolicyHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		err := errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("%v", err))
+		return
+	}
+
+	// jadwal pemakai kebijakan dicatat sebelum dihapus agar presensinya dinilai ulang dengan aturan pengganti
+	scheduleIDs, err := h.attendancePolicyService.ListAttendancePolicyScheduleID(id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.attendancePolicyService.DeleteAttendancePolicy(id); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.recompute(scheduleIDs)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses menghapus data", result)
+}
+
+// List ... List all Attendance Policy
+// @Summary List all Attendance Policy
+// @Description List all Attendance Policy
+// @Tags Attendance Policy
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AttendancePolicyResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-policy/list [get]
+// @Security BearerTokenAuth
+func (h attendancePolicyHandler) List(c *gin.Context) {
+	pagination := pagination.GeneratePaginationFromRequest(c)
+	var data model.AttendancePolicy
+	c.BindQuery(&data)
+
+	dataList, err := h.attendancePolicyService.ListAttendancePolicy(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	metaList, err := h.attendancePolicyService.ListAttendancePolicyMeta(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	response.New(c).List(http.StatusOK, "sukses mengambil list data", dataList, metaList)
+}
+
+// Assign ... Assign Attendance Policy
+// @Summary Assign Attendance Policy
+// @Description Memasang kebijakan ke jadwal (schedule_id) atau fakultas (faculty_id), attendance_policy_id 0 untuk melepas.
+// @Description Dosen hanya bisa memasang pada jadwal miliknya, fakultas hanya oleh superadmin
+// @Tags Attendance Policy
+// @Accept       json
+// @Produce      json
+// @Param data body model.AttendancePolicyAssign true "data"
+// @Success 200 {object} model.Response
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-policy/assign [put]
+// @Security BearerTokenAuth
+func (h attendancePolicyHandler) Assign(c *gin.Context) {
+	var data model.AttendancePolicyAssign
+	c.BindJSON(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if (data.ScheduleID > 0) == (data.FacultyID > 0) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("isi salah satu dari schedule_id atau faculty_id"))
+		return
+	}
+
+	if data.AttendancePolicyID < 0 || (data.AttendancePolicyID > 0 && !h.attendancePolicyService.CheckIsExist(data.AttendancePolicyID)) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("attendance_policy_id: kebijakan presensi tidak ditemukan"))
+		return
+	}
+
+	if data.FacultyID > 0 {
+		if !h.middleware.IsSuperAdmin(c) {
+			response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+			return
+		}
+		if err := h.attendancePolicyService.AssignAttendancePolicyFaculty(data.FacultyID, data.AttendancePolicyID); err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+		response.New(c).Write(http.StatusOK, "sukses memasang kebijakan presensi")
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		if !h.middleware.IsAdmin(c) {
+			response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+			return
+		}
+		if _, err := h.scheduleService.RetrieveScheduleByOwner(data.ScheduleID, currentUserID); err != nil {
+			response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+			return
+		}
+	}
+
+	if err := h.attendancePolicyService.AssignAttendancePolicySchedule(data.ScheduleID, data.AttendancePolicyID); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Write(http.StatusOK, "sukses memasang kebijakan presensi")
+}
+
+// Recompute ... Recompute Attendance
+// @Summary Recompute Attendance By Policy
+// @Description Menilai ulang presensi hadir / tidak hadir dengan kebijakan yang berlaku. Isi id untuk semua jadwal
+// @Description yang memakai kebijakan tersebut atau schedule_id untuk satu jadwal. Izin dan sakit tidak diubah
+// @Tags Attendance Policy
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.AttendancePolicyRecomputeResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /attendance-policy/recompute [post]
+// @Security BearerTokenAuth
+// @param id query string false "id attendance policy"
+// @param schedule_id query string false "id schedule"
+func (h attendancePolicyHandler) Recompute(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Query("id"))
+	scheduleID, _ := strconv.Atoi(c.Query("schedule_id"))
+	if id < 1 && scheduleID < 1 {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id atau schedule_id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var scheduleIDs []int
+	if scheduleID > 0 {
+		if !h.middleware.IsSuperAdmin(c) {
+			if _, err := h.scheduleService.RetrieveScheduleByOwner(scheduleID, currentUserID); err != nil || !h.middleware.IsAdmin(c) {
+				response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+				return
+			}
+		}
+		scheduleIDs = []int{scheduleID}
+	} else {
+		if !h.middleware.IsSuperAdmin(c) {
+			response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+			return
+		}
+		scheduleIDs, err = h.attendancePolicyService.ListAttendancePolicyScheduleID(id)
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	result, err := h.recompute(scheduleIDs)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses menilai ulang presensi", result)
+}
+
+// recompute menilai ulang presensi tiap jadwal dengan kebijakan yang berlaku saat ini
+func (h attendancePolicyHandler) recompute(scheduleIDs []int) (model.AttendancePolicyRecompute, error) {
+	var result model.AttendancePolicyRecompute
+	for _, scheduleID := range scheduleIDs {
+		policy, err := h.attendancePolicyService.RetrieveAttendancePolicyBySchedule(scheduleID)
+		if err != nil {
+			return result, err
+		}
+		total, err := h.attendanceService.RecomputeAttendancePolicy(scheduleID, policy)
+		if err != nil {
+			return result, err
+		}
+		result.TotalSchedule++
+		result.TotalAttendance += total
+	}
+	return result, nil
+}
+
+func (h attendancePolicyHandler) validate(data model.AttendancePolicy) error {
+	if err := validation.Validate(data.Name, validation.Required, validation.Length(1, 100)); err != nil {
+		return fmt.Errorf("nama: %v", err)
+	}
+	for i, rule := range data.Rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("rules[%d].%v", i, err)
+		}
+		if err := validation.Validate(rule.Label, validation.Length(0, 100)); err != nil {
+			return fmt.Errorf("rules[%d].label: %v", i, err)
+		}
+	}
+	return nil
+}
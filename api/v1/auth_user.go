@@ -3,13 +3,13 @@ package v1
 import (
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"regexp"
 	"time"
 
-	"attendance-api/common/http/email"
+	"attendance-api/common/http/notification"
 	"attendance-api/common/http/response"
+	"attendance-api/common/http/webhook"
 	"attendance-api/common/util/activation"
 	"attendance-api/common/util/regex"
 	"attendance-api/common/util/token"
@@ -39,15 +39,19 @@ type authUserHandler struct {
 	userService               service.UserService
 	activationTokenService    service.ActivationTokenService
 	passwordResetTokenService service.PasswordResetTokenService
+	notifier                  notification.Notifier
+	webhookDispatcher         webhook.Dispatcher
 	infra                     infra.Infra
 }
 
-func NewAuthHandler(authService service.AuthService, userService service.UserService, activationTokenService service.ActivationTokenService, passwordResetTokenService service.PasswordResetTokenService, infra infra.Infra) AuthUserHandler {
+func NewAuthHandler(authService service.AuthService, userService service.UserService, activationTokenService service.ActivationTokenService, passwordResetTokenService service.PasswordResetTokenService, notifier notification.Notifier, webhookDispatcher webhook.Dispatcher, infra infra.Infra) AuthUserHandler {
 	return &authUserHandler{
 		authService:               authService,
 		userService:               userService,
 		activationTokenService:    activationTokenService,
 		passwordResetTokenService: passwordResetTokenService,
+		notifier:                  notifier,
+		webhookDispatcher:         webhookDispatcher,
 		infra:                     infra,
 	}
 }
@@ -126,14 +130,13 @@ func (h authUserHandler) Register(c *gin.Context) {
 			return
 		}
 
-		go func(user model.User) {
-			config := h.infra.Config().Sub("server")
-			urlActivation := fmt.Sprintf("%s/v1/auth/activation?token=%s", config.GetString("base_url"), activationData.Token)
-
-			if err := email.New(h.infra.GoMail(), h.infra.Config()).SendActivation(user.FirstName, user.Email, urlActivation); err != nil {
-				log.Printf("Error Send Email E: %v", err)
-			}
-		}(user)
+		config := h.infra.Config().Sub("server")
+		h.notifier.Publish(notification.Event{
+			Type:      model.NotificationEventActivation,
+			Recipient: notification.Recipient{UserID: int(user.ID), Name: user.FirstName, Email: user.Email},
+			Data:      map[string]string{"url": fmt.Sprintf("%s/v1/auth/activation?token=%s", config.GetString("base_url"), activationData.Token)},
+			Channels:  []string{model.NotificationChannelEmail},
+		})
 
 		response.New(c).Write(http.StatusCreated, "berhasil registrasi pengguna")
 		return
@@ -361,6 +364,9 @@ func (h authUserHandler) Activation(c *gin.Context) {
 		c.HTML(http.StatusOK, "verify_email.html", data)
 		return
 	}
+	if user, err := h.userService.RetrieveUser(int(userID)); err == nil {
+		h.webhookDispatcher.Dispatch(model.WebhookEventUserActivated, model.NewWebhookUserData(user))
+	}
 
 	data := gin.H{
 		"status":  "Sukses",
@@ -438,13 +444,15 @@ func (h authUserHandler) ForgotPassword(c *gin.Context) {
 			return
 		}
 
-		go func(user model.User) {
-			urlFrontEnd := frontEndBaseURL + resetPath + "?token=" + resetPasswordToken.Token
-
-			if err := email.New(h.infra.GoMail(), h.infra.Config()).SendForgotPassword(user.FirstName, user.Email, urlFrontEnd, expiredToken); err != nil {
-				log.Printf("Error Send Email E: %v", err)
-			}
-		}(user)
+		h.notifier.Publish(notification.Event{
+			Type:      model.NotificationEventForgotPassword,
+			Recipient: notification.Recipient{UserID: int(user.ID), Name: user.FirstName, Email: user.Email},
+			Data: map[string]string{
+				"url":         frontEndBaseURL + resetPath + "?token=" + resetPasswordToken.Token,
+				"valid_until": expiredToken.Format(time.RFC3339),
+			},
+			Channels: []string{model.NotificationChannelEmail},
+		})
 
 		response.New(c).Write(http.StatusCreated, "email untuk perubahan kata sandi telah terkirim")
 		return
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	v1 "attendance-api/api/v1"
+	"attendance-api/common/http/notification"
+	"attendance-api/common/http/webhook"
 	"attendance-api/infra"
 	"attendance-api/mocks"
 	"attendance-api/model"
@@ -42,7 +44,7 @@ func TestRegister(t *testing.T) {
 		gin := gin.New()
 		rec := httptest.NewRecorder()
 
-		authHandler := v1.NewAuthHandler(authServiceMock, userServiceMoc, activationTokenServiceMoc, passwordResetTokenServiceMoc, infra.New("../../config/config.json"))
+		authHandler := v1.NewAuthHandler(authServiceMock, userServiceMoc, activationTokenServiceMoc, passwordResetTokenServiceMoc, notification.New(notification.DefaultTemplates(), nil, nil), webhook.New(nil, nil), infra.New("../../config/config.json"))
 		gin.POST("/register", authHandler.Register)
 
 		body, err := json.Marshal(mockUser)
@@ -72,7 +74,7 @@ func TestLogin(t *testing.T) {
 		gin := gin.New()
 		rec := httptest.NewRecorder()
 
-		authHandler := v1.NewAuthHandler(authServiceMock, userServiceMoc, activationTokenServiceMoc, passwordResetTokenServiceMoc, infra.New("../../config/config.json"))
+		authHandler := v1.NewAuthHandler(authServiceMock, userServiceMoc, activationTokenServiceMoc, passwordResetTokenServiceMoc, notification.New(notification.DefaultTemplates(), nil, nil), webhook.New(nil, nil), infra.New("../../config/config.json"))
 		gin.POST("/login", authHandler.Login)
 
 		body, err := json.Marshal(mockUser)
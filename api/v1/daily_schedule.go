@@ -29,13 +29,17 @@ type DailyScheduleHandler interface {
 
 type dailyScheduleHandler struct {
 	dailyScheduleService service.DailyScheduleService
+	scheduleService      service.ScheduleService
+	sessionService       service.SessionService
 	infra                infra.Infra
 	middleware           middleware.Middleware
 }
 
-func NewDailyScheduleHandler(dailyScheduleService service.DailyScheduleService, infra infra.Infra, middleware middleware.Middleware) DailyScheduleHandler {
+func NewDailyScheduleHandler(dailyScheduleService service.DailyScheduleService, scheduleService service.ScheduleService, sessionService service.SessionService, infra infra.Infra, middleware middleware.Middleware) DailyScheduleHandler {
 	return &dailyScheduleHandler{
 		dailyScheduleService: dailyScheduleService,
+		scheduleService:      scheduleService,
+		sessionService:       sessionService,
 		infra:                infra,
 		middleware:           middleware,
 	}
@@ -50,8 +54,10 @@ func NewDailyScheduleHandler(dailyScheduleService service.DailyScheduleService,
 // @Param data body model.DailyScheduleForm true "data"
 // @Success 200 {object} model.DailyScheduleResponseData
 // @Failure 400,500 {object} model.Response
+// @Failure 409 {object} model.ScheduleConflictResponseData
 // @Router /daily-schedule/create [post]
 // @Security BearerTokenAuth
+// @param override query bool false "tetap simpan walaupun bentrok (super admin)"
 func (h dailyScheduleHandler) Create(c *gin.Context) {
 	var data model.DailySchedule
 	c.BindJSON(&data)
@@ -72,11 +78,29 @@ func (h dailyScheduleHandler) Create(c *gin.Context) {
 		return
 	}
 
+	if err := data.ClockWindow.Validate(); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !isOverrideConflict(c, h.middleware) {
+		conflicts, err := h.checkConflict(data)
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+		if len(conflicts) > 0 {
+			writeScheduleConflict(c, conflicts)
+			return
+		}
+	}
+
 	result, err := h.dailyScheduleService.CreateDailySchedule(data)
 	if err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
+	regenerateSession(h.sessionService, int(result.ScheduleID))
 	response.New(c).Data(http.StatusCreated, "sukses membuat data", result)
 }
 
@@ -130,9 +154,11 @@ func (h dailyScheduleHandler) Retrieve(c *gin.Context) {
 // @Param data body model.DailyScheduleForm true "data"
 // @Success 200 {object} model.DailyScheduleResponseData
 // @Failure 400,500 {object} model.Response
+// @Failure 409 {object} model.ScheduleConflictResponseData
 // @Router /daily-schedule/update [put]
 // @Security BearerTokenAuth
 // @param id query string true "id daily schedule"
+// @param override query bool false "tetap simpan walaupun bentrok (super admin)"
 func (h dailyScheduleHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Query("id"))
 	if id < 1 || err != nil {
@@ -157,6 +183,41 @@ func (h dailyScheduleHandler) Update(c *gin.Context) {
 		return
 	}
 
+	if err := data.ClockWindow.Validate(); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !isOverrideConflict(c, h.middleware) {
+		current, err := h.dailyScheduleService.RetrieveDailySchedule(id)
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+
+		proposed := data
+		proposed.ID = current.ID
+		if proposed.ScheduleID == 0 {
+			proposed.ScheduleID = current.ScheduleID
+		}
+		if proposed.StartTime == "" {
+			proposed.StartTime = current.StartTime
+		}
+		if proposed.EndTime == "" {
+			proposed.EndTime = current.EndTime
+		}
+
+		conflicts, err := h.checkConflict(proposed)
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+		if len(conflicts) > 0 {
+			writeScheduleConflict(c, conflicts)
+			return
+		}
+	}
+
 	var result model.DailySchedule
 	if h.middleware.IsSuperAdmin(c) {
 		result, err = h.dailyScheduleService.UpdateDailySchedule(id, data)
@@ -168,6 +229,9 @@ func (h dailyScheduleHandler) Update(c *gin.Context) {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
+	if current, err := h.dailyScheduleService.RetrieveDailySchedule(id); err == nil {
+		regenerateSession(h.sessionService, int(current.ScheduleID))
+	}
 	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
 }
 
@@ -195,6 +259,7 @@ func (h dailyScheduleHandler) Delete(c *gin.Context) {
 		return
 	}
 
+	current, _ := h.dailyScheduleService.RetrieveDailySchedule(id)
 	if h.middleware.IsSuperAdmin(c) {
 		if err := h.dailyScheduleService.DeleteDailySchedule(id); err != nil {
 			response.New(c).Error(http.StatusBadRequest, err)
@@ -207,6 +272,9 @@ func (h dailyScheduleHandler) Delete(c *gin.Context) {
 		}
 	}
 
+	if current.ScheduleID > 0 {
+		regenerateSession(h.sessionService, int(current.ScheduleID))
+	}
 	response.New(c).Write(http.StatusOK, "sukses menghapus data")
 }
 
@@ -279,3 +347,11 @@ func (h dailyScheduleHandler) DropDown(c *gin.Context) {
 
 	response.New(c).Data(http.StatusOK, "sukses mendapatkan data drop down", dataList)
 }
+
+func (h dailyScheduleHandler) checkConflict(data model.DailySchedule) ([]model.ScheduleConflict, error) {
+	schedule, err := h.scheduleService.RetrieveSchedule(int(data.ScheduleID))
+	if err != nil {
+		return nil, err
+	}
+	return checkScheduleConflict(h.scheduleService, schedule, []model.ScheduleSlot{schedule.GetSlot(data)})
+}
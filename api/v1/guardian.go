@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/response"
+	"attendance-api/infra"
+	"attendance-api/model"
+	"attendance-api/service"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GuardianHandler interface {
+	OptOut(c *gin.Context)
+}
+
+type guardianHandler struct {
+	guardianService service.GuardianService
+	infra           infra.Infra
+	middleware      middleware.Middleware
+}
+
+func NewGuardianHandler(
+	guardianService service.GuardianService,
+	infra infra.Infra,
+	middleware middleware.Middleware) GuardianHandler {
+	return &guardianHandler{
+		guardianService: guardianService,
+		infra:           infra,
+		middleware:      middleware,
+	}
+}
+
+// OptOut ... Guardian Opt Out
+// @Summary Guardian Opt Out
+// @Description Berhenti menerima ringkasan kehadiran harian, tautan dikirim pada setiap ringkasan ke wali
+// @Tags Guardian
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.Response
+// @Failure 400,500 {object} model.Response
+// @Router /guardian/opt-out [get]
+// @param token query string true "token berhenti berlangganan"
+func (h guardianHandler) OptOut(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		response.New(c).Error(http.StatusBadRequest, errors.New("token harus diisi"))
+		return
+	}
+
+	if err := h.guardianService.OptOutGuardian(token); err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("token tidak valid"))
+		return
+	}
+
+	response.New(c).Write(http.StatusOK, "berhasil berhenti berlangganan ringkasan kehadiran")
+}
+
+// validateGuardians kanal kosong diisi email, data diubah langsung pada slice yang dikirim
+func validateGuardians(guardians []model.Guardian) error {
+	for i := range guardians {
+		if guardians[i].PreferredChannel == "" {
+			guardians[i].PreferredChannel = model.GuardianChannelEmail
+		}
+		if err := guardians[i].Validate(); err != nil {
+			return fmt.Errorf("wali %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
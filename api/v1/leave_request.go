@@ -0,0 +1,383 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/notification"
+	"attendance-api/common/http/response"
+	"attendance-api/common/util/converter"
+	"attendance-api/common/util/pagination"
+	"attendance-api/infra"
+	"attendance-api/model"
+	"attendance-api/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type LeaveRequestHandler interface {
+	Create(c *gin.Context)
+	Retrieve(c *gin.Context)
+	Cancel(c *gin.Context)
+	Decide(c *gin.Context)
+	List(c *gin.Context)
+	Log(c *gin.Context)
+}
+
+type leaveRequestHandler struct {
+	leaveRequestService service.LeaveRequestService
+	notifier            notification.Notifier
+	infra               infra.Infra
+	middleware          middleware.Middleware
+}
+
+func NewLeaveRequestHandler(leaveRequestService service.LeaveRequestService, notifier notification.Notifier, infra infra.Infra, middleware middleware.Middleware) LeaveRequestHandler {
+	return &leaveRequestHandler{
+		leaveRequestService: leaveRequestService,
+		notifier:            notifier,
+		infra:               infra,
+		middleware:          middleware,
+	}
+}
+
+// Create ... Create Leave Request
+// @Summary Create New Leave Request
+// @Description Mahasiswa mengajukan izin atau sakit untuk satu atau beberapa tanggal, schedule_ids kosong berarti semua jadwal yang diikuti
+// @Tags Leave Request
+// @Accept       json
+// @Produce      json
+// @Param data body model.LeaveRequestForm true "data"
+// @Success 200 {object} model.LeaveRequestResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /leave-request/create [post]
+// @Security BearerTokenAuth
+func (h leaveRequestHandler) Create(c *gin.Context) {
+	var data model.LeaveRequest
+	c.BindJSON(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !h.middleware.IsUser(c) {
+		err = errors.New("maaf hanya role user yang bisa mengajukan izin")
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	leaveRequest := model.LeaveRequest{
+		GormCustom: model.GormCustom{
+			CreatedBy: currentUserID,
+			UpdatedBy: currentUserID,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		UserID:     currentUserID,
+		Type:       data.Type,
+		StartDate:  converter.GetOnlyDateString(data.StartDate),
+		EndDate:    converter.GetOnlyDateString(data.EndDate),
+		Reason:     data.Reason,
+		Attachment: data.Attachment,
+		Status:     model.LeavePending,
+	}
+	if leaveRequest.EndDate == "" {
+		leaveRequest.EndDate = leaveRequest.StartDate
+	}
+
+	if !leaveRequest.IsValidType() {
+		response.New(c).Error(http.StatusBadRequest, errors.New("tipe: tipe harus sick atau leave"))
+		return
+	}
+	if err := validation.Validate(leaveRequest.StartDate, validation.Required, validation.Date("2006-01-02")); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("tanggal mulai: %v", err))
+		return
+	}
+	if err := validation.Validate(leaveRequest.EndDate, validation.Required, validation.Date("2006-01-02")); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("tanggal selesai: %v", err))
+		return
+	}
+	if leaveRequest.EndDate < leaveRequest.StartDate {
+		response.New(c).Error(http.StatusBadRequest, errors.New("tanggal selesai: tidak boleh sebelum tanggal mulai"))
+		return
+	}
+	if err := validation.Validate(leaveRequest.Reason, validation.Required); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("alasan: %v", err))
+		return
+	}
+	if err := validation.Validate(leaveRequest.Attachment, validation.Length(0, 255)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("lampiran: %v", err))
+		return
+	}
+
+	schedules, err := h.leaveRequestService.ListLeaveScheduleCandidate(currentUserID, leaveRequest.StartDate, leaveRequest.EndDate)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	scheduleByID := map[uint]model.Schedule{}
+	for _, schedule := range schedules {
+		scheduleByID[schedule.ID] = schedule
+	}
+
+	scheduleIDs := data.ScheduleIDs
+	if len(scheduleIDs) == 0 {
+		for _, schedule := range schedules {
+			scheduleIDs = append(scheduleIDs, schedule.ID)
+		}
+	}
+	if len(scheduleIDs) == 0 {
+		response.New(c).Error(http.StatusBadRequest, errors.New("jadwal: tidak ada jadwal yang diikuti pada rentang tanggal tersebut"))
+		return
+	}
+
+	isAdded := map[uint]bool{}
+	for _, scheduleID := range scheduleIDs {
+		if isAdded[scheduleID] {
+			continue
+		}
+		schedule, ok := scheduleByID[scheduleID]
+		if !ok {
+			response.New(c).Error(http.StatusBadRequest, fmt.Errorf("jadwal: jadwal %d tidak diikuti pada rentang tanggal tersebut", scheduleID))
+			return
+		}
+		leaveRequest.Items = append(leaveRequest.Items, model.LeaveRequestItem{
+			GormCustom: model.GormCustom{
+				CreatedBy: currentUserID,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			},
+			ScheduleID: schedule.ID,
+			OwnerID:    int(schedule.OwnerID),
+			Status:     model.LeavePending,
+		})
+		isAdded[scheduleID] = true
+	}
+
+	result, err := h.leaveRequestService.CreateLeaveRequest(leaveRequest)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusCreated, "sukses membuat data", result)
+}
+
+// Retrieve ... Retrieve Leave Request
+// @Summary Retrieve Single Leave Request
+// @Description Retrieve Single Leave Request milik sendiri atau yang mencakup jadwal milik dosen
+// @Tags Leave Request
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.LeaveRequestResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /leave-request/retrieve [get]
+// @Security BearerTokenAuth
+// @param id query string true "id leave request"
+func (h leaveRequestHandler) Retrieve(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.retrieveLeaveRequest(c, id, currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusCreated, "sukses mengambil data", result)
+}
+
+// Cancel ... Cancel Leave Request
+// @Summary Cancel Leave Request
+// @Description Mahasiswa membatalkan pengajuan yang belum diputuskan
+// @Tags Leave Request
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.LeaveRequestResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /leave-request/cancel [post]
+// @Security BearerTokenAuth
+// @param id query string true "id leave request"
+func (h leaveRequestHandler) Cancel(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.leaveRequestService.CancelLeaveRequest(id, currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses membatalkan pengajuan", result)
+}
+
+// Decide ... Decide Leave Request
+// @Summary Approve or Reject Leave Request
+// @Description Dosen pemilik jadwal atau superadmin menyetujui atau menolak pengajuan, persetujuan mengubah status presensi pada rentang tanggal pengajuan
+// @Tags Leave Request
+// @Accept       json
+// @Produce      json
+// @Param data body model.LeaveRequestDecision true "data"
+// @Success 200 {object} model.LeaveRequestResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /leave-request/decide [put]
+// @Security BearerTokenAuth
+// @param id query string true "id leave request"
+func (h leaveRequestHandler) Decide(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var data model.LeaveRequestDecision
+	c.BindJSON(&data)
+
+	if !data.IsValidStatus() {
+		response.New(c).Error(http.StatusBadRequest, errors.New("status: status harus approved atau rejected"))
+		return
+	}
+
+	var result model.LeaveRequest
+	if h.middleware.IsSuperAdmin(c) {
+		result, err = h.leaveRequestService.DecideLeaveRequest(id, 0, currentUserID, data)
+	} else if h.middleware.IsAdmin(c) {
+		result, err = h.leaveRequestService.DecideLeaveRequest(id, currentUserID, currentUserID, data)
+	} else {
+		err = errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+	}
+
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	h.notifier.Publish(notification.Event{
+		Type:      model.NotificationEventLeaveRequestDecided,
+		Recipient: notification.Recipient{UserID: result.UserID, Name: result.User.FirstName, Email: result.User.Email},
+		Data: map[string]string{
+			"type":       result.Type,
+			"start_date": converter.GetOnlyDateString(result.StartDate),
+			"end_date":   converter.GetOnlyDateString(result.EndDate),
+			"status":     result.Status,
+			"note":       data.Note,
+		},
+	})
+	response.New(c).Data(http.StatusOK, "sukses menyimpan keputusan", result)
+}
+
+// List ... List all Leave Request
+// @Summary List all Leave Request
+// @Description List all Leave Request, mahasiswa melihat pengajuan sendiri dan dosen melihat pengajuan untuk jadwal miliknya
+// @Tags Leave Request
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.LeaveRequestResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /leave-request/list [get]
+// @Security BearerTokenAuth
+func (h leaveRequestHandler) List(c *gin.Context) {
+	pagination := pagination.GeneratePaginationFromRequest(c)
+	var data model.LeaveRequest
+	c.BindQuery(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		if h.middleware.IsAdmin(c) {
+			data.OwnerID = currentUserID
+		} else {
+			data.UserID = currentUserID
+		}
+	}
+
+	dataList, err := h.leaveRequestService.ListLeaveRequest(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	metaList, err := h.leaveRequestService.ListLeaveRequestMeta(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	response.New(c).List(http.StatusOK, "sukses mengambil list data", dataList, metaList)
+}
+
+// Log ... List Leave Request Log
+// @Summary List Leave Request Log
+// @Description Riwayat pengajuan dan setiap keputusan atas pengajuan
+// @Tags Leave Request
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.LeaveRequestLogResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /leave-request/log [get]
+// @Security BearerTokenAuth
+// @param id query string true "id leave request"
+func (h leaveRequestHandler) Log(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := h.retrieveLeaveRequest(c, id, currentUserID); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.leaveRequestService.ListLeaveRequestLog(id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses mengambil data", result)
+}
+
+// retrieveLeaveRequest superadmin melihat semua, selain itu pemilik pengajuan atau dosen pemilik jadwal
+func (h leaveRequestHandler) retrieveLeaveRequest(c *gin.Context, id int, currentUserID int) (model.LeaveRequest, error) {
+	if h.middleware.IsSuperAdmin(c) {
+		return h.leaveRequestService.RetrieveLeaveRequest(id)
+	}
+	if result, err := h.leaveRequestService.RetrieveLeaveRequestByUser(id, currentUserID); err == nil {
+		return result, nil
+	}
+	return h.leaveRequestService.RetrieveLeaveRequestByOwner(id, currentUserID)
+}
@@ -0,0 +1,461 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/response"
+	"attendance-api/common/util/converter"
+	"attendance-api/common/util/pagination"
+	"attendance-api/common/util/presence"
+	"attendance-api/infra"
+	"attendance-api/model"
+	"attendance-api/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type LectureJournalHandler interface {
+	Create(c *gin.Context)
+	Retrieve(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	List(c *gin.Context)
+	Submit(c *gin.Context)
+	Unlock(c *gin.Context)
+}
+
+type lectureJournalHandler struct {
+	lectureJournalService service.LectureJournalService
+	scheduleService       service.ScheduleService
+	dailyScheduleService  service.DailyScheduleService
+	sessionService        service.SessionService
+	infra                 infra.Infra
+	middleware            middleware.Middleware
+}
+
+func NewLectureJournalHandler(
+	lectureJournalService service.LectureJournalService,
+	scheduleService service.ScheduleService,
+	dailyScheduleService service.DailyScheduleService,
+	sessionService service.SessionService,
+	infra infra.Infra,
+	middleware middleware.Middleware,
+) LectureJournalHandler {
+	return &lectureJournalHandler{
+		lectureJournalService: lectureJournalService,
+		scheduleService:       scheduleService,
+		dailyScheduleService:  dailyScheduleService,
+		sessionService:        sessionService,
+		infra:                 infra,
+		middleware:            middleware,
+	}
+}
+
+// Create ... Create Lecture Journal
+// @Summary Create New Lecture Journal
+// @Description Buat berita acara perkuliahan untuk satu tanggal pertemuan, jumlah kehadiran diisi otomatis dari data presensi
+// @Tags Lecture Journal
+// @Accept       json
+// @Produce      json
+// @Param data body model.LectureJournalForm true "data"
+// @Success 200 {object} model.LectureJournalResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /lecture-journal/create [post]
+// @Security BearerTokenAuth
+func (h lectureJournalHandler) Create(c *gin.Context) {
+	var data model.LectureJournal
+	c.BindJSON(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	schedule, err := h.retrieveSchedule(c, int(data.ScheduleID), currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("jadwal: %v", err))
+		return
+	}
+
+	journal := model.LectureJournal{
+		GormCustom: model.GormCustom{
+			CreatedBy: currentUserID,
+			UpdatedBy: currentUserID,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		ScheduleID: schedule.ID,
+		Date:       converter.GetOnlyDateString(data.Date),
+		Topic:      data.Topic,
+		Method:     data.Method,
+		Notes:      data.Notes,
+		Status:     model.JournalDraft,
+		OwnerID:    int(schedule.OwnerID),
+	}
+
+	journal, err = h.validate(schedule, journal, 0)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	journal.ApplyCount(h.lectureJournalService.CountLectureJournalAttendance(int(schedule.ID), journal.Date))
+
+	result, err := h.lectureJournalService.CreateLectureJournal(journal)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusCreated, "sukses membuat data", result)
+}
+
+// Retrieve ... Retrieve Lecture Journal
+// @Summary Retrieve Single Lecture Journal
+// @Description Retrieve Single Lecture Journal, jumlah kehadiran jurnal yang belum dikunci selalu mengikuti data presensi terbaru
+// @Tags Lecture Journal
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.LectureJournalResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /lecture-journal/retrieve [get]
+// @Security BearerTokenAuth
+// @param id query string true "id lecture journal"
+func (h lectureJournalHandler) Retrieve(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.retrieveLectureJournal(c, id, currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !result.IsLocked() {
+		result.ApplyCount(h.lectureJournalService.CountLectureJournalAttendance(int(result.ScheduleID), converter.GetOnlyDateString(result.Date)))
+	}
+	response.New(c).Data(http.StatusCreated, "sukses mengambil data", result)
+}
+
+// Update ... Update Lecture Journal
+// @Summary Update Single Lecture Journal
+// @Description Update Single Lecture Journal, jurnal yang sudah disubmit tidak bisa diubah
+// @Tags Lecture Journal
+// @Accept       json
+// @Produce      json
+// @Param data body model.LectureJournalForm true "data"
+// @Success 200 {object} model.LectureJournalResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /lecture-journal/update [put]
+// @Security BearerTokenAuth
+// @param id query string true "id lecture journal"
+func (h lectureJournalHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	current, err := h.retrieveLectureJournal(c, id, currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	if current.IsLocked() {
+		response.New(c).Error(http.StatusBadRequest, errors.New("jurnal sudah disubmit dan dikunci"))
+		return
+	}
+
+	var data model.LectureJournal
+	c.BindJSON(&data)
+
+	// jadwal dan pemilik jurnal tidak bisa dipindah
+	journal := model.LectureJournal{
+		GormCustom: model.GormCustom{
+			UpdatedBy: currentUserID,
+			UpdatedAt: time.Now(),
+		},
+		ScheduleID: current.ScheduleID,
+		Date:       converter.GetOnlyDateString(data.Date),
+		Topic:      data.Topic,
+		Method:     data.Method,
+		Notes:      data.Notes,
+		OwnerID:    current.OwnerID,
+	}
+	if journal.Date == "" {
+		journal.Date = converter.GetOnlyDateString(current.Date)
+	}
+
+	journal, err = h.validate(current.Schedule, journal, id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var result model.LectureJournal
+	if h.middleware.IsSuperAdmin(c) {
+		result, err = h.lectureJournalService.UpdateLectureJournal(id, journal)
+	} else {
+		result, err = h.lectureJournalService.UpdateLectureJournalByOwner(id, currentUserID, journal)
+	}
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	count := h.lectureJournalService.CountLectureJournalAttendance(int(journal.ScheduleID), journal.Date)
+	if err := h.lectureJournalService.UpdateLectureJournalCount(id, count); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	result.ApplyCount(count)
+
+	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
+}
+
+// Delete ... Delete Lecture Journal
+// @Summary Delete Single Lecture Journal
+// @Description Delete Single Lecture Journal, jurnal yang sudah disubmit tidak bisa dihapus
+// @Tags Lecture Journal
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.LectureJournalResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /lecture-journal/delete [delete]
+// @Security BearerTokenAuth
+// @param id query string true "id lecture journal"
+func (h lectureJournalHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	current, err := h.retrieveLectureJournal(c, id, currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	if current.IsLocked() {
+		response.New(c).Error(http.StatusBadRequest, errors.New("jurnal sudah disubmit dan dikunci"))
+		return
+	}
+
+	if h.middleware.IsSuperAdmin(c) {
+		if err := h.lectureJournalService.DeleteLectureJournal(id); err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	} else {
+		if err := h.lectureJournalService.DeleteLectureJournalByOwner(id, currentUserID); err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	response.New(c).Write(http.StatusOK, "sukses menghapus data")
+}
+
+// List ... List all Lecture Journal
+// @Summary List all Lecture Journal
+// @Description List all Lecture Journal, filter dengan subject_id, owner_id (dosen), schedule_id, status, start_date dan end_date
+// @Tags Lecture Journal
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.LectureJournalResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /lecture-journal/list [get]
+// @Security BearerTokenAuth
+func (h lectureJournalHandler) List(c *gin.Context) {
+	pagination := pagination.GeneratePaginationFromRequest(c)
+	var data model.LectureJournal
+	c.BindQuery(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		data.OwnerID = currentUserID
+	}
+
+	dataList, err := h.lectureJournalService.ListLectureJournal(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	metaList, err := h.lectureJournalService.ListLectureJournalMeta(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	response.New(c).List(http.StatusOK, "sukses mengambil list data", dataList, metaList)
+}
+
+// Submit ... Submit Lecture Journal
+// @Summary Submit Lecture Journal
+// @Description Submit jurnal, jumlah kehadiran dihitung ulang lalu jurnal dikunci
+// @Tags Lecture Journal
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.LectureJournalResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /lecture-journal/submit [post]
+// @Security BearerTokenAuth
+// @param id query string true "id lecture journal"
+func (h lectureJournalHandler) Submit(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.retrieveLectureJournal(c, id, currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	if result.IsLocked() {
+		response.New(c).Error(http.StatusBadRequest, errors.New("jurnal sudah disubmit dan dikunci"))
+		return
+	}
+	if err := validation.Validate(result.Topic, validation.Required); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("topik: %v", err))
+		return
+	}
+
+	count := h.lectureJournalService.CountLectureJournalAttendance(int(result.ScheduleID), converter.GetOnlyDateString(result.Date))
+	if err := h.lectureJournalService.UpdateLectureJournalCount(id, count); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	submittedAt := time.Now()
+	if err := h.lectureJournalService.UpdateLectureJournalStatus(id, model.JournalSubmitted, &submittedAt); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result.ApplyCount(count)
+	result.Status = model.JournalSubmitted
+	result.SubmittedAt = &submittedAt
+	response.New(c).Data(http.StatusOK, "sukses submit jurnal", result)
+}
+
+// Unlock ... Unlock Lecture Journal
+// @Summary Unlock Lecture Journal
+// @Description Buka kunci jurnal yang sudah disubmit agar bisa diperbaiki, hanya superadmin
+// @Tags Lecture Journal
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.LectureJournalResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /lecture-journal/unlock [post]
+// @Security BearerTokenAuth
+// @param id query string true "id lecture journal"
+func (h lectureJournalHandler) Unlock(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		err := errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("%v", err))
+		return
+	}
+
+	result, err := h.lectureJournalService.RetrieveLectureJournal(id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.lectureJournalService.UpdateLectureJournalStatus(id, model.JournalDraft, nil); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result.Status = model.JournalDraft
+	result.SubmittedAt = nil
+	response.New(c).Data(http.StatusOK, "sukses membuka kunci jurnal", result)
+}
+
+func (h lectureJournalHandler) retrieveSchedule(c *gin.Context, scheduleID int, currentUserID int) (model.Schedule, error) {
+	if scheduleID < 1 {
+		return model.Schedule{}, errors.New("id jadwal harus diisi")
+	}
+	if h.middleware.IsSuperAdmin(c) {
+		return h.scheduleService.RetrieveSchedule(scheduleID)
+	}
+	return h.scheduleService.RetrieveScheduleByOwner(scheduleID, currentUserID)
+}
+
+func (h lectureJournalHandler) retrieveLectureJournal(c *gin.Context, id int, currentUserID int) (model.LectureJournal, error) {
+	if h.middleware.IsSuperAdmin(c) {
+		return h.lectureJournalService.RetrieveLectureJournal(id)
+	}
+	return h.lectureJournalService.RetrieveLectureJournalByOwner(id, currentUserID)
+}
+
+// validate tanggal jurnal harus tanggal pertemuan jadwal dan belum memiliki jurnal lain
+func (h lectureJournalHandler) validate(schedule model.Schedule, data model.LectureJournal, exceptID int) (model.LectureJournal, error) {
+	if err := validation.Validate(data.Date, validation.Required, validation.Date("2006-01-02")); err != nil {
+		return data, fmt.Errorf("tanggal: %v", err)
+	}
+	if isInRange, _ := presence.IsDateInRange(data.Date, schedule.StartDate, schedule.EndDate); !isInRange {
+		return data, errors.New("tanggal: tanggal di luar periode jadwal")
+	}
+
+	if session, err := h.sessionService.RetrieveSessionByDate(int(schedule.ID), data.Date); err == nil {
+		data.SessionID = session.ID
+	} else if isHave, _, _ := h.dailyScheduleService.CheckHaveDailySchedule(int(schedule.ID), data.Date); !isHave {
+		return data, errors.New("tanggal: tidak ada pertemuan pada tanggal tersebut")
+	}
+
+	if h.lectureJournalService.CheckIsExistByDate(int(schedule.ID), data.Date, exceptID) {
+		return data, errors.New("tanggal: jurnal untuk tanggal tersebut sudah ada")
+	}
+
+	if err := validation.Validate(data.Topic, validation.Length(0, 255)); err != nil {
+		return data, fmt.Errorf("topik: %v", err)
+	}
+	if err := validation.Validate(data.Method, validation.Length(0, 100)); err != nil {
+		return data, fmt.Errorf("metode: %v", err)
+	}
+	return data, nil
+}
@@ -92,8 +92,9 @@ func (h myScheduleHandler) Today(c *gin.Context) {
 	}
 
 	for i, result := range results {
-		if isExistAttendance := h.attendanceService.CheckIsExistByDate(currentUserID, int(result.ScheduleID), todayDate); isExistAttendance {
-			attendance, err := h.attendanceService.RetrieveAttendanceByDate(currentUserID, int(result.ScheduleID), todayDate)
+		// presensi ditampilkan per blok jadwal harian
+		if isExistAttendance := h.attendanceService.CheckIsExistByDate(currentUserID, int(result.ScheduleID), int(result.DailyScheduleID), todayDate); isExistAttendance {
+			attendance, err := h.attendanceService.RetrieveAttendanceByDate(currentUserID, int(result.ScheduleID), int(result.DailyScheduleID), todayDate)
 			if err != nil {
 				log.Printf("[Err] RetrieveAttendanceByDate E: %v\n", err)
 				results[i].AttendanceID = 0
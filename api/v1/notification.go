@@ -0,0 +1,194 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/response"
+	"attendance-api/common/util/pagination"
+	"attendance-api/infra"
+	"attendance-api/model"
+	"attendance-api/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler interface {
+	List(c *gin.Context)
+	UnreadCount(c *gin.Context)
+	Read(c *gin.Context)
+	ReadAll(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type notificationHandler struct {
+	notificationService service.NotificationService
+	infra               infra.Infra
+	middleware          middleware.Middleware
+}
+
+func NewNotificationHandler(
+	notificationService service.NotificationService,
+	infra infra.Infra,
+	middleware middleware.Middleware) NotificationHandler {
+	return &notificationHandler{
+		notificationService: notificationService,
+		infra:               infra,
+		middleware:          middleware,
+	}
+}
+
+// List ... List Notification
+// @Summary List Notification
+// @Description Kotak masuk pengguna yang sedang login, filter event, title dan unread_only
+// @Tags Notification
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.NotificationResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /notification/list [get]
+// @Security BearerTokenAuth
+func (h notificationHandler) List(c *gin.Context) {
+	pagination := pagination.GeneratePaginationFromRequest(c)
+	var data model.Notification
+	c.BindQuery(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	data.UserID = currentUserID
+
+	dataList, err := h.notificationService.ListNotification(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	metaList, err := h.notificationService.ListNotificationMeta(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	response.New(c).List(http.StatusOK, "sukses mengambil list data", dataList, metaList)
+}
+
+// UnreadCount ... Unread Notification Count
+// @Summary Unread Notification Count
+// @Description Jumlah pesan kotak masuk yang belum dibaca
+// @Tags Notification
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.NotificationCountResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /notification/unread-count [get]
+// @Security BearerTokenAuth
+func (h notificationHandler) UnreadCount(c *gin.Context) {
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	total, err := h.notificationService.CountUnreadNotification(currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	response.New(c).Data(http.StatusOK, "sukses mengambil data", model.NotificationCount{Total: total})
+}
+
+// Read ... Mark Notification As Read
+// @Summary Mark Notification As Read
+// @Description Menandai satu pesan kotak masuk sebagai sudah dibaca
+// @Tags Notification
+// @Accept       json
+// @Produce      json
+// @Param id query int true "Notification ID"
+// @Success 200 {object} model.NotificationResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /notification/read [put]
+// @Security BearerTokenAuth
+func (h notificationHandler) Read(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.notificationService.ReadNotification(id, currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
+}
+
+// ReadAll ... Mark All Notification As Read
+// @Summary Mark All Notification As Read
+// @Description Menandai seluruh pesan kotak masuk sebagai sudah dibaca, total berisi jumlah pesan yang berubah
+// @Tags Notification
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.NotificationCountResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /notification/read-all [put]
+// @Security BearerTokenAuth
+func (h notificationHandler) ReadAll(c *gin.Context) {
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	total, err := h.notificationService.ReadAllNotification(currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", model.NotificationCount{Total: total})
+}
+
+// Delete ... Delete Notification
+// @Summary Delete Notification
+// @Description Menghapus satu pesan dari kotak masuk
+// @Tags Notification
+// @Accept       json
+// @Produce      json
+// @Param id query int true "Notification ID"
+// @Success 200 {object} model.Response
+// @Failure 400,500 {object} model.Response
+// @Router /notification/delete [delete]
+// @Security BearerTokenAuth
+func (h notificationHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.notificationService.DeleteNotification(id, currentUserID); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	response.New(c).Write(http.StatusOK, "sukses menghapus data")
+}
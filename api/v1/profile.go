@@ -25,6 +25,10 @@ type ProfileHandler interface {
 	Teacher(c *gin.Context)
 	Update(c *gin.Context)
 	UpdatePassword(c *gin.Context)
+	Guardian(c *gin.Context)
+	UpdateGuardian(c *gin.Context)
+	NotificationPreference(c *gin.Context)
+	UpdateNotificationPreference(c *gin.Context)
 }
 
 type profileHandler struct {
@@ -32,6 +36,8 @@ type profileHandler struct {
 	studentService         service.StudentService
 	teacherService         service.TeacherService
 	activationTokenService service.ActivationTokenService
+	guardianService        service.GuardianService
+	notificationService    service.NotificationService
 	infra                  infra.Infra
 	middleware             middleware.Middleware
 }
@@ -41,6 +47,8 @@ func NewProfileHandler(
 	studentService service.StudentService,
 	teacherService service.TeacherService,
 	activationTokenService service.ActivationTokenService,
+	guardianService service.GuardianService,
+	notificationService service.NotificationService,
 	infra infra.Infra,
 	middleware middleware.Middleware,
 ) ProfileHandler {
@@ -49,6 +57,8 @@ func NewProfileHandler(
 		studentService:         studentService,
 		teacherService:         teacherService,
 		activationTokenService: activationTokenService,
+		guardianService:        guardianService,
+		notificationService:    notificationService,
 		infra:                  infra,
 		middleware:             middleware,
 	}
@@ -273,3 +283,141 @@ func (h profileHandler) UpdatePassword(c *gin.Context) {
 	}
 	response.New(c).Write(http.StatusOK, "berhasil memperbaharui kata sandi")
 }
+
+// Guardian ... Guardian Profile
+// @Summary Guardian Profile
+// @Description Kontak wali mahasiswa yang sedang login
+// @Tags Profile
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.GuardianResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /profile/guardian [get]
+// @Security BearerTokenAuth
+func (h profileHandler) Guardian(c *gin.Context) {
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	student, err := h.studentService.RetrieveStudentByUserID(currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	response.New(c).Data(http.StatusOK, "sukses mengambil data", student.Guardians)
+}
+
+// UpdateGuardian ... Update Guardian Profile
+// @Summary Update Guardian Profile
+// @Description Menyimpan seluruh kontak wali mahasiswa yang sedang login, kontak yang tidak dikirim akan dihapus.
+// @Description preferred_channel: email, sms atau whatsapp. opt_out true untuk berhenti menerima ringkasan harian
+// @Tags Profile
+// @Accept       json
+// @Produce      json
+// @Param data body []model.GuardianForm true "data"
+// @Success 200 {object} model.GuardianResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /profile/guardian [put]
+// @Security BearerTokenAuth
+func (h profileHandler) UpdateGuardian(c *gin.Context) {
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	student, err := h.studentService.RetrieveStudentByUserID(currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	data := []model.Guardian{}
+	c.BindJSON(&data)
+
+	if err := validateGuardians(data); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	for i := range data {
+		data[i].CreatedBy = currentUserID
+		data[i].UpdatedBy = currentUserID
+		data[i].UpdatedAt = time.Now()
+	}
+
+	result, err := h.guardianService.SaveStudentGuardian(int(student.ID), data)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
+}
+
+// NotificationPreference ... Notification Preference Profile
+// @Summary Notification Preference Profile
+// @Description Preferensi kanal notifikasi pengguna yang sedang login, kanal yang belum diatur mengikuti kanal bawaan
+// @Tags Profile
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.NotificationPreferenceResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /profile/notification-preference [get]
+// @Security BearerTokenAuth
+func (h profileHandler) NotificationPreference(c *gin.Context) {
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.notificationService.ListNotificationPreference(currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses mengambil data", result)
+}
+
+// UpdateNotificationPreference ... Update Notification Preference Profile
+// @Summary Update Notification Preference Profile
+// @Description Mengaktifkan / menonaktifkan kanal notifikasi, channel: email, gateway (sms / whatsapp) atau in_app.
+// @Description Notifikasi aktivasi akun dan lupa kata sandi selalu dikirim lewat email
+// @Tags Profile
+// @Accept       json
+// @Produce      json
+// @Param data body []model.NotificationPreferenceForm true "data"
+// @Success 200 {object} model.NotificationPreferenceResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /profile/notification-preference [put]
+// @Security BearerTokenAuth
+func (h profileHandler) UpdateNotificationPreference(c *gin.Context) {
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	data := []model.NotificationPreference{}
+	c.BindJSON(&data)
+
+	for i := range data {
+		if !model.IsNotificationChannel(data[i].Channel) {
+			response.New(c).Error(http.StatusBadRequest, fmt.Errorf("channel: kanal %s tidak valid", data[i].Channel))
+			return
+		}
+		data[i].CreatedBy = currentUserID
+		data[i].UpdatedBy = currentUserID
+		data[i].UpdatedAt = time.Now()
+	}
+
+	result, err := h.notificationService.SaveNotificationPreference(currentUserID, data)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
+}
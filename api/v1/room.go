@@ -0,0 +1,414 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/response"
+	"attendance-api/common/util/pagination"
+	"attendance-api/infra"
+	"attendance-api/model"
+	"attendance-api/service"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type RoomHandler interface {
+	Create(c *gin.Context)
+	Retrieve(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	List(c *gin.Context)
+	DropDown(c *gin.Context)
+}
+
+type roomHandler struct {
+	roomService         service.RoomService
+	geofenceZoneService service.GeofenceZoneService
+	infra               infra.Infra
+	middleware          middleware.Middleware
+}
+
+func NewRoomHandler(roomService service.RoomService, geofenceZoneService service.GeofenceZoneService, infra infra.Infra, middleware middleware.Middleware) RoomHandler {
+	return &roomHandler{
+		roomService:         roomService,
+		geofenceZoneService: geofenceZoneService,
+		infra:               infra,
+		middleware:          middleware,
+	}
+}
+
+// Create ... Create Room
+// @Summary Create New Room
+// @Description Create Room
+// @Tags Room
+// @Accept       json
+// @Produce      json
+// @Param data body model.RoomForm true "data"
+// @Success 200 {object} model.RoomResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /room/create [post]
+// @Security BearerTokenAuth
+func (h roomHandler) Create(c *gin.Context) {
+	var data model.Room
+	c.BindJSON(&data)
+
+	if !h.middleware.IsSuperAdmin(c) {
+		err := errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("%v", err))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	data.GormCustom.CreatedBy = currentUserID
+	if !h.middleware.IsSuperAdmin(c) {
+		data.OwnerID = currentUserID
+	}
+
+	if err := validation.Validate(data.Name, validation.Required, validation.Length(1, 255)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("nama: %v", err))
+		return
+	}
+
+	if err := validation.Validate(data.Code, validation.Required, validation.Length(1, 25)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("kode: %v", err))
+		return
+	}
+
+	if err := validation.Validate(data.Capacity, validation.Min(0)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("kapasitas: %v", err))
+		return
+	}
+
+	for _, zone := range data.GeofenceZone {
+		if err := zone.Validate(); err != nil {
+			response.New(c).Error(http.StatusBadRequest, fmt.Errorf("geofence: %v", err))
+			return
+		}
+	}
+
+	if h.roomService.CheckIsExistByName(data.Name, 0) {
+		err := errors.New("nama ruangan sudah ada")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("nama: %v", err))
+		return
+	}
+
+	if h.roomService.CheckIsExistByCode(data.Code, 0) {
+		err := errors.New("kode ruangan sudah ada")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("kode: %v", err))
+		return
+	}
+
+	for i := range data.GeofenceZone {
+		data.GeofenceZone[i].OwnerID = data.OwnerID
+	}
+
+	result, err := h.roomService.CreateRoom(data)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusCreated, "sukses membuat data", result)
+}
+
+// Retrieve ... Retrieve Room
+// @Summary Retrieve Single Room
+// @Description Retrieve Single Room
+// @Tags Room
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.RoomResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /room/retrieve [get]
+// @Security BearerTokenAuth
+// @param id query string true "id room"
+func (h roomHandler) Retrieve(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var result model.Room
+	if h.middleware.IsSuperAdmin(c) {
+		result, err = h.roomService.RetrieveRoom(id)
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	} else {
+		result, err = h.roomService.RetrieveRoomByOwner(id, currentUserID)
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	}
+	response.New(c).Data(http.StatusCreated, "sukses mengambil data", result)
+}
+
+// Update ... Update Room
+// @Summary Update Single Room
+// @Description Update Single Room
+// @Tags Room
+// @Accept       json
+// @Produce      json
+// @Param data body model.RoomForm true "data"
+// @Success 200 {object} model.RoomResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /room/update [put]
+// @Security BearerTokenAuth
+// @param id query string true "id room"
+func (h roomHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		err := errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("%v", err))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var data model.Room
+	c.BindJSON(&data)
+
+	data.UpdatedBy = currentUserID
+	data.UpdatedAt = time.Now()
+
+	if err := validation.Validate(data.Name, validation.Required, validation.Length(1, 255)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("nama: %v", err))
+		return
+	}
+
+	if err := validation.Validate(data.Code, validation.Required, validation.Length(1, 25)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("kode: %v", err))
+		return
+	}
+
+	if err := validation.Validate(data.Capacity, validation.Min(0)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("kapasitas: %v", err))
+		return
+	}
+
+	for _, zone := range data.GeofenceZone {
+		if err := zone.Validate(); err != nil {
+			response.New(c).Error(http.StatusBadRequest, fmt.Errorf("geofence: %v", err))
+			return
+		}
+	}
+
+	if h.roomService.CheckIsExistByName(data.Name, id) {
+		err := errors.New("nama ruangan sudah ada")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("nama: %v", err))
+		return
+	}
+
+	if h.roomService.CheckIsExistByCode(data.Code, id) {
+		err := errors.New("kode ruangan sudah ada")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("kode: %v", err))
+		return
+	}
+
+	current, err := h.roomService.RetrieveRoom(id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := model.CheckGeofenceZoneID(current.GeofenceZone, data.GeofenceZone); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("geofence: %v", err))
+		return
+	}
+
+	// zona disinkronkan terpisah agar tidak ikut disimpan sebagai asosiasi
+	geofenceZones := data.GeofenceZone
+	geofenceZoneID := data.GetListGeofenceZoneID()
+	data.GeofenceZone = nil
+
+	var result model.Room
+	if h.middleware.IsSuperAdmin(c) {
+		result, err = h.roomService.UpdateRoom(id, data)
+	} else {
+		result, err = h.roomService.UpdateRoomByOwner(id, currentUserID, data)
+	}
+
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if errDeleteZone := h.geofenceZoneService.DeleteGeofenceZoneByRoomIDAndExceptListID(id, geofenceZoneID); errDeleteZone != nil {
+		log.Printf("[Error Delete Geofence Zone] E: %v\n", errDeleteZone)
+	}
+	for _, zone := range geofenceZones {
+		zone.RoomID = uint(id)
+		zone.ScheduleID = 0
+		zone.OwnerID = currentUserID
+		if zone.ID > 0 {
+			zone.UpdatedAt = time.Now()
+			zone.UpdatedBy = currentUserID
+			_, err = h.geofenceZoneService.UpdateGeofenceZoneByRoomID(int(zone.ID), id, zone)
+		} else {
+			zone.CreatedAt = time.Now()
+			zone.CreatedBy = currentUserID
+			_, err = h.geofenceZoneService.CreateGeofenceZone(zone)
+		}
+		if err != nil {
+			log.Printf("[Error Sync Geofence Zone] E: %v\n", err)
+		}
+	}
+
+	result, err = h.roomService.RetrieveRoom(id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
+}
+
+// Delete ... Delete Room
+// @Summary Delete Single Room
+// @Description Delete Single Room
+// @Tags Room
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.RoomResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /room/delete [delete]
+// @Security BearerTokenAuth
+// @param id query string true "id room"
+func (h roomHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		err := errors.New("anda tidak memiliki akses untuk melakukan proses ini")
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("%v", err))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if h.roomService.CheckIsUsed(id) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("ruangan masih digunakan oleh jadwal"))
+		return
+	}
+
+	if h.middleware.IsSuperAdmin(c) {
+		if err := h.roomService.DeleteRoom(id); err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	} else {
+		if err := h.roomService.DeleteRoomByOwner(id, currentUserID); err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	if err := h.geofenceZoneService.DeleteGeofenceZoneByRoomIDAndExceptListID(id, nil); err != nil {
+		log.Printf("[Error Delete Geofence Zone] E: %v\n", err)
+	}
+
+	response.New(c).Write(http.StatusOK, "sukses menghapus data")
+}
+
+// List ... List all Room
+// @Summary List all Room
+// @Description List all Room
+// @Tags Room
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.RoomResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /room/list [get]
+// @Security BearerTokenAuth
+func (h roomHandler) List(c *gin.Context) {
+	pagination := pagination.GeneratePaginationFromRequest(c)
+	var data model.Room
+	c.BindQuery(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		data.OwnerID = currentUserID
+	}
+
+	dataList, err := h.roomService.ListRoom(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	metaList, err := h.roomService.ListRoomMeta(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	response.New(c).List(http.StatusOK, "sukses mengambil list data", dataList, metaList)
+}
+
+// Dropdown ... Dropdown all Room
+// @Summary Dropdown all Room
+// @Description Dropdown all Room
+// @Tags Room
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.RoomResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /room/drop-down [get]
+// @Security BearerTokenAuth
+func (h roomHandler) DropDown(c *gin.Context) {
+	var data model.Room
+	c.BindQuery(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		data.OwnerID = currentUserID
+	}
+
+	dataList, err := h.roomService.DropDownRoom(data)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	response.New(c).Data(http.StatusOK, "sukses mendapatkan data drop down", dataList)
+}
@@ -10,6 +10,7 @@ import (
 	"attendance-api/service"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
@@ -21,23 +22,36 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation"
 )
 
+const streamInterval = 2 * time.Second
+
 type ScheduleHandler interface {
 	Create(c *gin.Context)
 	Retrieve(c *gin.Context)
 	Update(c *gin.Context)
 	UpdateQRcode(c *gin.Context)
+	CurrentQRcode(c *gin.Context)
+	QRcodePNG(c *gin.Context)
+	QRcodeSVG(c *gin.Context)
+	StreamTicket(c *gin.Context)
+	Stream(c *gin.Context)
+	CheckConflict(c *gin.Context)
 	Delete(c *gin.Context)
 	List(c *gin.Context)
 	DropDown(c *gin.Context)
 }
 
 type scheduleHandler struct {
-	scheduleService      service.ScheduleService
-	subjectService       service.SubjectService
-	userScheduleService  service.UserScheduleService
-	dailyScheduleService service.DailyScheduleService
-	infra                infra.Infra
-	middleware           middleware.Middleware
+	scheduleService          service.ScheduleService
+	subjectService           service.SubjectService
+	userScheduleService      service.UserScheduleService
+	dailyScheduleService     service.DailyScheduleService
+	attendanceService        service.AttendanceService
+	geofenceZoneService      service.GeofenceZoneService
+	roomService              service.RoomService
+	scheduleExceptionService service.ScheduleExceptionService
+	sessionService           service.SessionService
+	infra                    infra.Infra
+	middleware               middleware.Middleware
 }
 
 func NewScheduleHandler(
@@ -45,16 +59,26 @@ func NewScheduleHandler(
 	subjectService service.SubjectService,
 	userScheduleService service.UserScheduleService,
 	dailyScheduleService service.DailyScheduleService,
+	attendanceService service.AttendanceService,
+	geofenceZoneService service.GeofenceZoneService,
+	roomService service.RoomService,
+	scheduleExceptionService service.ScheduleExceptionService,
+	sessionService service.SessionService,
 	infra infra.Infra,
 	middleware middleware.Middleware,
 ) ScheduleHandler {
 	return &scheduleHandler{
-		scheduleService:      scheduleService,
-		subjectService:       subjectService,
-		userScheduleService:  userScheduleService,
-		dailyScheduleService: dailyScheduleService,
-		infra:                infra,
-		middleware:           middleware,
+		scheduleService:          scheduleService,
+		subjectService:           subjectService,
+		userScheduleService:      userScheduleService,
+		dailyScheduleService:     dailyScheduleService,
+		attendanceService:        attendanceService,
+		geofenceZoneService:      geofenceZoneService,
+		roomService:              roomService,
+		scheduleExceptionService: scheduleExceptionService,
+		sessionService:           sessionService,
+		infra:                    infra,
+		middleware:               middleware,
 	}
 }
 
@@ -67,8 +91,10 @@ func NewScheduleHandler(
 // @Param data body model.ScheduleForm true "data"
 // @Success 200 {object} model.ScheduleResponseData
 // @Failure 400,500 {object} model.Response
+// @Failure 409 {object} model.ScheduleConflictResponseData
 // @Router /schedule/create [post]
 // @Security BearerTokenAuth
+// @param override query bool false "tetap simpan walaupun bentrok (super admin)"
 func (h scheduleHandler) Create(c *gin.Context) {
 	var data model.Schedule
 	c.BindJSON(&data)
@@ -101,6 +127,22 @@ func (h scheduleHandler) Create(c *gin.Context) {
 		return
 	}
 
+	if err := data.ClockWindow.Validate(); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("clock_window %v", err))
+		return
+	}
+
+	if err := validation.Validate(data.MinAttendance, validation.Min(0), validation.Max(100)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("min_attendance: %v", err))
+		return
+	}
+	for _, dailySchedule := range data.DailySchedule {
+		if err := dailySchedule.ClockWindow.Validate(); err != nil {
+			response.New(c).Error(http.StatusBadRequest, fmt.Errorf("daily_schedule %s %v", dailySchedule.Name, err))
+			return
+		}
+	}
+
 	if exist := h.scheduleService.CheckCodeIsExist(data.Code, 0); exist {
 		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("kode: %v", "kode tersebut sudah ada yang menggunakan"))
 		return
@@ -111,7 +153,47 @@ func (h scheduleHandler) Create(c *gin.Context) {
 		return
 	}
 
+	if data.RoomID != nil && !h.roomService.CheckIsExist(int(*data.RoomID)) {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("id ruangan: %v", "id ruangan tidak tersedia"))
+		return
+	}
+
+	for i, zone := range data.GeofenceZone {
+		if err := zone.Validate(); err != nil {
+			response.New(c).Error(http.StatusBadRequest, fmt.Errorf("geofence: %v", err))
+			return
+		}
+		data.GeofenceZone[i].OwnerID = int(data.OwnerID)
+	}
+
+	if data.QRMode == "" {
+		data.QRMode = myqr.ModeStatic
+	}
+
+	if err := validation.Validate(data.QRMode, validation.In(myqr.ModeStatic, myqr.ModeRotating)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("mode kode qr: %v", "mode kode qr harus static atau rotating"))
+		return
+	}
+
+	if data.QRSkew != nil && *data.QRSkew < 0 {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("toleransi kode qr: %v", "tidak boleh bernilai negatif"))
+		return
+	}
+
 	data.QRCode = myqr.GenerateQR(8)
+	data.QRSecret = myqr.GenerateSecret(16)
+
+	if !isOverrideConflict(c, h.middleware) {
+		conflicts, err := checkScheduleConflict(h.scheduleService, data, data.GetSlots())
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+		if len(conflicts) > 0 {
+			writeScheduleConflict(c, conflicts)
+			return
+		}
+	}
 
 	result, err := h.scheduleService.CreateSchedule(data)
 	if err != nil {
@@ -126,6 +208,7 @@ func (h scheduleHandler) Create(c *gin.Context) {
 	}
 
 	result.UserInRule = h.userScheduleService.CountByScheduleID(int(result.ID))
+	regenerateSession(h.sessionService, int(result.ID))
 
 	response.New(c).Data(http.StatusCreated, "sukses membuat data", result)
 }
@@ -182,9 +265,11 @@ func (h scheduleHandler) Retrieve(c *gin.Context) {
 // @Param data body model.ScheduleForm true "data"
 // @Success 200 {object} model.ScheduleResponseData
 // @Failure 400,500 {object} model.Response
+// @Failure 409 {object} model.ScheduleConflictResponseData
 // @Router /schedule/update [put]
 // @Security BearerTokenAuth
 // @param id query string true "id schedule"
+// @param override query bool false "tetap simpan walaupun bentrok (super admin)"
 func (h scheduleHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Query("id"))
 	if id < 1 || err != nil {
@@ -219,6 +304,22 @@ func (h scheduleHandler) Update(c *gin.Context) {
 		return
 	}
 
+	if err := data.ClockWindow.Validate(); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("clock_window %v", err))
+		return
+	}
+
+	if err := validation.Validate(data.MinAttendance, validation.Min(0), validation.Max(100)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("min_attendance: %v", err))
+		return
+	}
+	for _, dailySchedule := range data.DailySchedule {
+		if err := dailySchedule.ClockWindow.Validate(); err != nil {
+			response.New(c).Error(http.StatusBadRequest, fmt.Errorf("daily_schedule %s %v", dailySchedule.Name, err))
+			return
+		}
+	}
+
 	if exist := h.scheduleService.CheckCodeIsExist(data.Code, int(data.ID)); exist {
 		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("kode: %v", "kode tersebut sudah ada yang menggunakan"))
 		return
@@ -229,6 +330,66 @@ func (h scheduleHandler) Update(c *gin.Context) {
 		return
 	}
 
+	if data.RoomID != nil && !h.roomService.CheckIsExist(int(*data.RoomID)) {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("id ruangan: %v", "id ruangan tidak tersedia"))
+		return
+	}
+
+	if err := validation.Validate(data.QRMode, validation.In(myqr.ModeStatic, myqr.ModeRotating)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("mode kode qr: %v", "mode kode qr harus static atau rotating"))
+		return
+	}
+
+	if data.QRSkew != nil && *data.QRSkew < 0 {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("toleransi kode qr: %v", "tidak boleh bernilai negatif"))
+		return
+	}
+
+	for _, zone := range data.GeofenceZone {
+		if err := zone.Validate(); err != nil {
+			response.New(c).Error(http.StatusBadRequest, fmt.Errorf("geofence: %v", err))
+			return
+		}
+	}
+
+	var current model.Schedule
+	if h.middleware.IsSuperAdmin(c) {
+		current, err = h.scheduleService.RetrieveSchedule(id)
+	} else {
+		current, err = h.scheduleService.RetrieveScheduleByOwner(id, currentUserID)
+	}
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := model.CheckGeofenceZoneID(current.GeofenceZone, data.GeofenceZone); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("geofence: %v", err))
+		return
+	}
+
+	if !isOverrideConflict(c, h.middleware) {
+		proposed := mergeScheduleConflict(current, data)
+		conflicts, err := checkScheduleConflict(h.scheduleService, proposed, proposed.GetSlots())
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+		if len(conflicts) > 0 {
+			writeScheduleConflict(c, conflicts)
+			return
+		}
+	}
+
+	// zona disinkronkan terpisah agar tidak ikut disimpan sebagai asosiasi
+	geofenceZones := data.GeofenceZone
+	geofenceZoneID := data.GetListGeofenceZoneID()
+	data.GeofenceZone = nil
+
+	if data.QRMode == myqr.ModeRotating && current.QRSecret == "" {
+		data.QRSecret = myqr.GenerateSecret(16)
+	}
+
 	var result model.Schedule
 	if h.middleware.IsSuperAdmin(c) {
 		if errDeleteDaily := h.dailyScheduleService.DeleteDailyScheduleByScheduleIDAndExceptListID(id, data.GetListDailyScheduleID()); errDeleteDaily != nil {
@@ -276,10 +437,11 @@ func (h scheduleHandler) Update(c *gin.Context) {
 						UpdatedAt: time.Now(),
 						UpdatedBy: currentUserID,
 					},
-					ScheduleID: data.ID,
-					Name:       dailySchedule.Name,
-					StartTime:  dailySchedule.StartTime,
-					EndTime:    dailySchedule.EndTime,
+					ScheduleID:  data.ID,
+					Name:        dailySchedule.Name,
+					StartTime:   dailySchedule.StartTime,
+					EndTime:     dailySchedule.EndTime,
+					ClockWindow: dailySchedule.ClockWindow,
 				})
 			} else {
 				// Create
@@ -288,10 +450,11 @@ func (h scheduleHandler) Update(c *gin.Context) {
 						CreatedAt: time.Now(),
 						CreatedBy: currentUserID,
 					},
-					ScheduleID: data.ID,
-					Name:       dailySchedule.Name,
-					StartTime:  dailySchedule.StartTime,
-					EndTime:    dailySchedule.EndTime,
+					ScheduleID:  data.ID,
+					Name:        dailySchedule.Name,
+					StartTime:   dailySchedule.StartTime,
+					EndTime:     dailySchedule.EndTime,
+					ClockWindow: dailySchedule.ClockWindow,
 				})
 			}
 			wg.Done()
@@ -299,6 +462,28 @@ func (h scheduleHandler) Update(c *gin.Context) {
 	}
 	wg.Wait()
 
+	if errDeleteZone := h.geofenceZoneService.DeleteGeofenceZoneByScheduleIDAndExceptListID(id, geofenceZoneID); errDeleteZone != nil {
+		log.Printf("[Error Delete Geofence Zone] E: %v\n", errDeleteZone)
+	}
+	for _, zone := range geofenceZones {
+		zone.ScheduleID = result.ID
+		zone.OwnerID = int(result.OwnerID)
+		if zone.ID > 0 {
+			zone.UpdatedAt = time.Now()
+			zone.UpdatedBy = currentUserID
+			_, err = h.geofenceZoneService.UpdateGeofenceZoneByScheduleID(int(zone.ID), id, zone)
+		} else {
+			zone.CreatedAt = time.Now()
+			zone.CreatedBy = currentUserID
+			_, err = h.geofenceZoneService.CreateGeofenceZone(zone)
+		}
+		if err != nil {
+			log.Printf("[Error Sync Geofence Zone] E: %v\n", err)
+		}
+	}
+	result.GeofenceZone, _ = h.geofenceZoneService.ListGeofenceZoneByScheduleID(id)
+	regenerateSession(h.sessionService, id)
+
 	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
 }
 
@@ -333,17 +518,18 @@ func (h scheduleHandler) UpdateQRcode(c *gin.Context) {
 	}
 
 	qrCode := myqr.Generate(schedule.Code, 8)
+	qrSecret := myqr.GenerateSecret(16)
 
 	var result model.Schedule
 	if h.middleware.IsSuperAdmin(c) {
-		result, err = h.scheduleService.UpdateQRcode(id, qrCode)
+		result, err = h.scheduleService.UpdateQRcode(id, qrCode, qrSecret)
 		result.UserInRule = h.userScheduleService.CountByScheduleID(int(result.ID))
 		if err != nil {
 			response.New(c).Error(http.StatusBadRequest, err)
 			return
 		}
 	} else {
-		result, err = h.scheduleService.UpdateQRcodeByOwner(id, currentUserID, qrCode)
+		result, err = h.scheduleService.UpdateQRcodeByOwner(id, currentUserID, qrCode, qrSecret)
 		result.UserInRule = h.userScheduleService.CountByScheduleID(int(result.ID))
 		if err != nil {
 			response.New(c).Error(http.StatusBadRequest, err)
@@ -353,6 +539,342 @@ func (h scheduleHandler) UpdateQRcode(c *gin.Context) {
 	response.New(c).Data(http.StatusCreated, "berhasil memperbaharui kode qr", result)
 }
 
+// Current QR Code ... Current QR Code
+// @Summary Current QR Code
+// @Description Payload kode qr yang berlaku saat ini, untuk mode rotating berubah setiap interval
+// @Tags Schedule
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.ScheduleQRCodeResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /schedule/current-qr-code [get]
+// @Security BearerTokenAuth
+// @param id query string true "id schedule"
+func (h scheduleHandler) CurrentQRcode(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var schedule model.Schedule
+	if h.middleware.IsSuperAdmin(c) {
+		schedule, err = h.scheduleService.RetrieveSchedule(id)
+	} else {
+		schedule, err = h.scheduleService.RetrieveScheduleByOwner(id, currentUserID)
+	}
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	response.New(c).Data(http.StatusOK, "sukses mengambil data", schedule.GetQRCodeData(time.Now()))
+}
+
+// QR Code PNG ... QR Code PNG
+// @Summary QR Code PNG
+// @Description Gambar png kode qr jadwal
+// @Tags Schedule
+// @Produce      png
+// @Success 200 {file} binary
+// @Failure 400,500 {object} model.Response
+// @Router /schedule/qr-code/png [get]
+// @Security BearerTokenAuth
+// @param id query string true "id schedule"
+// @param size query int false "ukuran gambar dalam pixel (64 - 2048)"
+// @param level query string false "level koreksi (L, M, Q, H)"
+// @param caption query string false "keterangan dipisah koma (name, subject_code, teacher)"
+func (h scheduleHandler) QRcodePNG(c *gin.Context) {
+	h.renderQRcode(c, "image/png", myqr.RenderPNG)
+}
+
+// QR Code SVG ... QR Code SVG
+// @Summary QR Code SVG
+// @Description Gambar svg kode qr jadwal
+// @Tags Schedule
+// @Produce      image/svg+xml
+// @Success 200 {file} binary
+// @Failure 400,500 {object} model.Response
+// @Router /schedule/qr-code/svg [get]
+// @Security BearerTokenAuth
+// @param id query string true "id schedule"
+// @param size query int false "ukuran gambar dalam pixel (64 - 2048)"
+// @param level query string false "level koreksi (L, M, Q, H)"
+// @param caption query string false "keterangan dipisah koma (name, subject_code, teacher)"
+func (h scheduleHandler) QRcodeSVG(c *gin.Context) {
+	h.renderQRcode(c, "image/svg+xml", myqr.RenderSVG)
+}
+
+func (h scheduleHandler) renderQRcode(c *gin.Context, contentType string, render func(payload string, opt myqr.RenderOption) ([]byte, error)) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	size := 0
+	if c.Query("size") != "" {
+		size, err = strconv.Atoi(c.Query("size"))
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, errors.New("ukuran harus diisi dengan nomor yang valid"))
+			return
+		}
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var schedule model.Schedule
+	if h.middleware.IsSuperAdmin(c) {
+		schedule, err = h.scheduleService.RetrieveSchedule(id)
+	} else {
+		schedule, err = h.scheduleService.RetrieveScheduleByOwner(id, currentUserID)
+	}
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var caption []string
+	if c.Query("caption") != "" {
+		for _, item := range strings.Split(c.Query("caption"), ",") {
+			switch strings.TrimSpace(item) {
+			case "name":
+				caption = append(caption, schedule.Name)
+			case "subject_code":
+				caption = append(caption, schedule.Subject.Code)
+			case "teacher":
+				caption = append(caption, strings.TrimSpace(schedule.Owner.FirstName+" "+schedule.Owner.LastName))
+			default:
+				response.New(c).Error(http.StatusBadRequest, fmt.Errorf("keterangan: %v", "pilihan keterangan hanya name, subject_code, teacher"))
+				return
+			}
+		}
+	}
+
+	qrCode, _ := schedule.CurrentQRCode(time.Now())
+	image, err := render(qrCode, myqr.RenderOption{
+		Size:    size,
+		Level:   c.Query("level"),
+		Caption: caption,
+	})
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, contentType, image)
+}
+
+// Stream Ticket ... Stream Ticket
+// @Summary Stream Ticket
+// @Description Tiket berumur pendek untuk membuka stream satu jadwal, dikirim lewat query ticket pada /schedule/stream
+// @Tags Schedule
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.ScheduleStreamTicketResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /schedule/stream-ticket [get]
+// @Security BearerTokenAuth
+// @param id query string true "id schedule"
+func (h scheduleHandler) StreamTicket(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if h.middleware.IsSuperAdmin(c) {
+		_, err = h.scheduleService.RetrieveSchedule(id)
+	} else {
+		_, err = h.scheduleService.RetrieveScheduleByOwner(id, currentUserID)
+	}
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	ticket, err := h.middleware.GenerateStreamTicket(c, id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses membuat tiket stream", ticket)
+}
+
+// Stream ... Stream QR Code
+// @Summary Stream QR Code
+// @Description Server-Sent Events untuk layar proyektor, mengirim event qr_code saat kode qr berubah dan clock_in_count saat jumlah clock-in hari ini berubah.
+// @Description Tiket hanya diperiksa saat koneksi dibuka, buat tiket baru sebelum menyambung ulang
+// @Tags Schedule
+// @Produce      text/event-stream
+// @Success 200 {object} model.ScheduleStream
+// @Failure 400,500 {object} model.Response
+// @Router /schedule/stream [get]
+// @param id query string true "id schedule"
+// @param ticket query string true "tiket dari /schedule/stream-ticket (EventSource tidak bisa mengirim header)"
+func (h scheduleHandler) Stream(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	ticket, err := h.middleware.GetStreamTicket(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	retrieve := func() (model.Schedule, error) {
+		if ticket.IsSuperAdmin {
+			return h.scheduleService.RetrieveSchedule(id)
+		}
+		return h.scheduleService.RetrieveScheduleByOwner(id, int(ticket.UserID))
+	}
+
+	if _, err := retrieve(); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	ticker := time.NewTicker(streamInterval)
+	defer ticker.Stop()
+
+	lastQRCode := ""
+	lastCount := -1
+	var lastDailyScheduleID uint
+	push := func() bool {
+		schedule, err := retrieve()
+		if err != nil {
+			c.SSEvent("error", err.Error())
+			return false
+		}
+
+		now := time.Now()
+		qrCode := schedule.GetQRCodeData(now)
+		if qrCode.QRCode != lastQRCode {
+			lastQRCode = qrCode.QRCode
+			c.SSEvent("qr_code", qrCode)
+		}
+
+		// jumlah absen masuk pada blok jadwal harian yang sedang berlangsung
+		date := now.Format("2006-01-02")
+		dailySchedules, err := retrieveDailySchedules(h.dailyScheduleService, h.scheduleExceptionService, &schedule, date)
+		if err != nil {
+			log.Printf("[Error] [Schedule-Stream] E: %v\n", err)
+		}
+		dailySchedule := model.MatchDailySchedule(dailySchedules, now.UnixNano()/int64(time.Millisecond), 0, "clock_in")
+
+		count := h.attendanceService.CountClockInByDate(id, int(dailySchedule.ID), date)
+		if count != lastCount || dailySchedule.ID != lastDailyScheduleID {
+			lastCount = count
+			lastDailyScheduleID = dailySchedule.ID
+			c.SSEvent("clock_in_count", model.ScheduleClockInCount{
+				ScheduleID:      schedule.ID,
+				DailyScheduleID: dailySchedule.ID,
+				Date:            date,
+				Count:           count,
+				Total:           h.userScheduleService.CountByScheduleID(id),
+			})
+		}
+		return true
+	}
+
+	if !push() {
+		return
+	}
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-ticker.C:
+			return push()
+		}
+	})
+}
+
+// Check Conflict ... Check Conflict
+// @Summary Check Schedule Conflict
+// @Description Mengecek bentrok dosen, ruangan dan mahasiswa untuk jadwal usulan tanpa menyimpan, isi id untuk jadwal yang sudah ada
+// @Tags Schedule
+// @Accept       json
+// @Produce      json
+// @Param data body model.ScheduleForm true "data"
+// @Success 200 {object} model.ScheduleConflictResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /schedule/check-conflict [post]
+// @Security BearerTokenAuth
+// @param id query string false "id schedule"
+func (h scheduleHandler) CheckConflict(c *gin.Context) {
+	var data model.Schedule
+	c.BindJSON(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		data.OwnerID = uint(currentUserID)
+	}
+
+	if c.Query("id") != "" {
+		id, err := strconv.Atoi(c.Query("id"))
+		if id < 1 || err != nil {
+			response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+			return
+		}
+
+		var current model.Schedule
+		if h.middleware.IsSuperAdmin(c) {
+			current, err = h.scheduleService.RetrieveSchedule(id)
+		} else {
+			current, err = h.scheduleService.RetrieveScheduleByOwner(id, currentUserID)
+		}
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+		data = mergeScheduleConflict(current, data)
+	}
+
+	conflicts, err := checkScheduleConflict(h.scheduleService, data, data.GetSlots())
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	response.New(c).Data(http.StatusOK, "sukses mengecek bentrok jadwal", model.ScheduleConflictResult{
+		HasConflict: len(conflicts) > 0,
+		Conflicts:   conflicts,
+	})
+}
+
 // Delete ... Delete Schedule
 // @Summary Delete Schedule
 // @Description Delete Schedule
@@ -397,6 +919,14 @@ func (h scheduleHandler) Delete(c *gin.Context) {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
+	if err := h.scheduleExceptionService.DeleteScheduleExceptionByScheduleID(id); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	if err := h.sessionService.DeleteSessionByScheduleID(id); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
 
 	response.New(c).Write(http.StatusOK, "sukses menghapus data")
 }
@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/response"
+	"attendance-api/model"
+	"attendance-api/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// checkScheduleConflict mengumpulkan bentrok dosen, ruangan dan mahasiswa terdaftar untuk setiap slot jadwal
+func checkScheduleConflict(scheduleService service.ScheduleService, schedule model.Schedule, slots []model.ScheduleSlot) (conflicts []model.ScheduleConflict, err error) {
+	for _, slot := range slots {
+		if slot.Day == "" || slot.StartTime == "" || slot.EndTime == "" {
+			continue
+		}
+
+		if schedule.OwnerID > 0 {
+			results, err := scheduleService.ListTeacherConflict(int(schedule.OwnerID), int(schedule.ID), slot)
+			if err != nil {
+				return nil, err
+			}
+			conflicts = append(conflicts, results...)
+		}
+
+		if schedule.RoomID != nil && *schedule.RoomID > 0 {
+			results, err := scheduleService.ListRoomConflict(int(*schedule.RoomID), int(schedule.ID), slot)
+			if err != nil {
+				return nil, err
+			}
+			conflicts = append(conflicts, results...)
+		}
+
+		if schedule.ID > 0 {
+			results, err := scheduleService.ListEnrolledStudentConflict(int(schedule.ID), slot)
+			if err != nil {
+				return nil, err
+			}
+			conflicts = append(conflicts, results...)
+		}
+	}
+	return conflicts, nil
+}
+
+// mergeScheduleConflict melengkapi data usulan dengan data jadwal yang tersimpan agar pengecekan bentrok memakai nilai akhir
+func mergeScheduleConflict(current model.Schedule, data model.Schedule) model.Schedule {
+	data.ID = current.ID
+	if data.OwnerID == 0 {
+		data.OwnerID = current.OwnerID
+	}
+	if data.RoomID == nil {
+		data.RoomID = current.RoomID
+	}
+	if data.StartDate == "" {
+		data.StartDate = current.StartDate
+	}
+	if data.EndDate == "" {
+		data.EndDate = current.EndDate
+	}
+	if len(data.DailySchedule) == 0 {
+		data.DailySchedule = current.DailySchedule
+	}
+	return data
+}
+
+// isOverrideConflict hanya super admin yang boleh menyimpan jadwal walaupun bentrok
+func isOverrideConflict(c *gin.Context, m middleware.Middleware) bool {
+	return c.Query("override") == "true" && m.IsSuperAdmin(c)
+}
+
+func writeScheduleConflict(c *gin.Context, conflicts []model.ScheduleConflict) {
+	response.New(c).Data(http.StatusConflict, "jadwal bentrok, gunakan override=true untuk tetap menyimpan", model.ScheduleConflictResult{
+		HasConflict: true,
+		Conflicts:   conflicts,
+	})
+}
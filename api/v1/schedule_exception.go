@@ -0,0 +1,457 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/notification"
+	"attendance-api/common/http/response"
+	"attendance-api/common/util/converter"
+	"attendance-api/common/util/pagination"
+	"attendance-api/common/util/presence"
+	"attendance-api/common/util/regex"
+	"attendance-api/infra"
+	"attendance-api/model"
+	"attendance-api/service"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type ScheduleExceptionHandler interface {
+	Create(c *gin.Context)
+	Retrieve(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	List(c *gin.Context)
+}
+
+type scheduleExceptionHandler struct {
+	scheduleExceptionService service.ScheduleExceptionService
+	scheduleService          service.ScheduleService
+	dailyScheduleService     service.DailyScheduleService
+	roomService              service.RoomService
+	sessionService           service.SessionService
+	userScheduleService      service.UserScheduleService
+	notifier                 notification.Notifier
+	infra                    infra.Infra
+	middleware               middleware.Middleware
+}
+
+func NewScheduleExceptionHandler(
+	scheduleExceptionService service.ScheduleExceptionService,
+	scheduleService service.ScheduleService,
+	dailyScheduleService service.DailyScheduleService,
+	roomService service.RoomService,
+	sessionService service.SessionService,
+	userScheduleService service.UserScheduleService,
+	notifier notification.Notifier,
+	infra infra.Infra,
+	middleware middleware.Middleware,
+) ScheduleExceptionHandler {
+	return &scheduleExceptionHandler{
+		scheduleExceptionService: scheduleExceptionService,
+		scheduleService:          scheduleService,
+		dailyScheduleService:     dailyScheduleService,
+		roomService:              roomService,
+		sessionService:           sessionService,
+		userScheduleService:      userScheduleService,
+		notifier:                 notifier,
+		infra:                    infra,
+		middleware:               middleware,
+	}
+}
+
+// Create ... Create Schedule Exception
+// @Summary Create New Schedule Exception
+// @Description Batalkan (cancel), pindahkan (reschedule) atau tambah kelas pengganti (makeup) pada tanggal tertentu
+// @Tags Schedule Exception
+// @Accept       json
+// @Produce      json
+// @Param data body model.ScheduleExceptionForm true "data"
+// @Success 200 {object} model.ScheduleExceptionResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /schedule-exception/create [post]
+// @Security BearerTokenAuth
+func (h scheduleExceptionHandler) Create(c *gin.Context) {
+	var data model.ScheduleException
+	c.BindJSON(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	data.GormCustom.CreatedBy = currentUserID
+
+	schedule, err := h.retrieveSchedule(c, int(data.ScheduleID), currentUserID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("jadwal: %v", err))
+		return
+	}
+	data.OwnerID = int(schedule.OwnerID)
+
+	data, err = h.validate(schedule, data, 0)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.scheduleExceptionService.CreateScheduleException(data)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	regenerateSession(h.sessionService, int(result.ScheduleID))
+	h.publishChange(schedule, result.Describe())
+	response.New(c).Data(http.StatusCreated, "sukses membuat data", result)
+}
+
+// Retrieve ... Retrieve Schedule Exception
+// @Summary Retrieve Single Schedule Exception
+// @Description Retrieve Single Schedule Exception
+// @Tags Schedule Exception
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.ScheduleExceptionResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /schedule-exception/retrieve [get]
+// @Security BearerTokenAuth
+// @param id query string true "id schedule exception"
+func (h scheduleExceptionHandler) Retrieve(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var result model.ScheduleException
+	if h.middleware.IsSuperAdmin(c) {
+		result, err = h.scheduleExceptionService.RetrieveScheduleException(id)
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	} else {
+		result, err = h.scheduleExceptionService.RetrieveScheduleExceptionByOwner(id, currentUserID)
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	}
+	response.New(c).Data(http.StatusCreated, "sukses mengambil data", result)
+}
+
+// Update ... Update Schedule Exception
+// @Summary Update Single Schedule Exception
+// @Description Update Single Schedule Exception
+// @Tags Schedule Exception
+// @Accept       json
+// @Produce      json
+// @Param data body model.ScheduleExceptionForm true "data"
+// @Success 200 {object} model.ScheduleExceptionResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /schedule-exception/update [put]
+// @Security BearerTokenAuth
+// @param id query string true "id schedule exception"
+func (h scheduleExceptionHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var current model.ScheduleException
+	if h.middleware.IsSuperAdmin(c) {
+		current, err = h.scheduleExceptionService.RetrieveScheduleException(id)
+	} else {
+		current, err = h.scheduleExceptionService.RetrieveScheduleExceptionByOwner(id, currentUserID)
+	}
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var data model.ScheduleException
+	c.BindJSON(&data)
+
+	data.UpdatedBy = currentUserID
+	data.UpdatedAt = time.Now()
+	data.ScheduleID = current.ScheduleID
+	data.OwnerID = current.OwnerID
+	if data.Type == "" {
+		data.Type = current.Type
+	}
+
+	schedule, err := h.scheduleService.RetrieveSchedule(int(current.ScheduleID))
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("jadwal: %v", err))
+		return
+	}
+
+	data, err = h.validate(schedule, data, id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var result model.ScheduleException
+	if h.middleware.IsSuperAdmin(c) {
+		result, err = h.scheduleExceptionService.UpdateScheduleException(id, data)
+	} else {
+		result, err = h.scheduleExceptionService.UpdateScheduleExceptionByOwner(id, currentUserID, data)
+	}
+
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	regenerateSession(h.sessionService, int(current.ScheduleID))
+	h.publishChange(schedule, result.Describe())
+	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
+}
+
+// Delete ... Delete Schedule Exception
+// @Summary Delete Single Schedule Exception
+// @Description Delete Single Schedule Exception, pertemuan kembali mengikuti jadwal mingguan
+// @Tags Schedule Exception
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.ScheduleExceptionResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /schedule-exception/delete [delete]
+// @Security BearerTokenAuth
+// @param id query string true "id schedule exception"
+func (h scheduleExceptionHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var current model.ScheduleException
+	if h.middleware.IsSuperAdmin(c) {
+		current, err = h.scheduleExceptionService.RetrieveScheduleException(id)
+	} else {
+		current, err = h.scheduleExceptionService.RetrieveScheduleExceptionByOwner(id, currentUserID)
+	}
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if h.middleware.IsSuperAdmin(c) {
+		if err := h.scheduleExceptionService.DeleteScheduleException(id); err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	} else {
+		if err := h.scheduleExceptionService.DeleteScheduleExceptionByOwner(id, currentUserID); err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	regenerateSession(h.sessionService, int(current.ScheduleID))
+	if schedule, err := h.scheduleService.RetrieveSchedule(int(current.ScheduleID)); err == nil {
+		h.publishChange(schedule, fmt.Sprintf("%s tidak jadi dilaksanakan, pertemuan kembali mengikuti jadwal mingguan", current.Describe()))
+	}
+	response.New(c).Write(http.StatusOK, "sukses menghapus data")
+}
+
+// List ... List all Schedule Exception
+// @Summary List all Schedule Exception
+// @Description List all Schedule Exception, filter date mencocokkan tanggal asli maupun tanggal baru
+// @Tags Schedule Exception
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.ScheduleExceptionResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /schedule-exception/list [get]
+// @Security BearerTokenAuth
+func (h scheduleExceptionHandler) List(c *gin.Context) {
+	pagination := pagination.GeneratePaginationFromRequest(c)
+	var data model.ScheduleException
+	c.BindQuery(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		data.OwnerID = currentUserID
+	}
+
+	dataList, err := h.scheduleExceptionService.ListScheduleException(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	metaList, err := h.scheduleExceptionService.ListScheduleExceptionMeta(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	response.New(c).List(http.StatusOK, "sukses mengambil list data", dataList, metaList)
+}
+
+func (h scheduleExceptionHandler) retrieveSchedule(c *gin.Context, scheduleID int, currentUserID int) (model.Schedule, error) {
+	if scheduleID < 1 {
+		return model.Schedule{}, errors.New("id jadwal harus diisi")
+	}
+	if h.middleware.IsSuperAdmin(c) {
+		return h.scheduleService.RetrieveSchedule(scheduleID)
+	}
+	return h.scheduleService.RetrieveScheduleByOwner(scheduleID, currentUserID)
+}
+
+// publishChange memberi tahu setiap mahasiswa pada jadwal tentang perubahan pertemuan
+func (h scheduleExceptionHandler) publishChange(schedule model.Schedule, change string) {
+	users, err := h.userScheduleService.ListUserBySchedule(int(schedule.ID))
+	if err != nil {
+		log.Printf("[Error] [UserSchedule-ListUserBySchedule] E: %v\n", err)
+		return
+	}
+	for _, user := range users {
+		h.notifier.Publish(notification.Event{
+			Type:      model.NotificationEventScheduleException,
+			Recipient: notification.Recipient{UserID: int(user.ID), Name: user.FirstName, Email: user.Email, Phone: user.Handphone},
+			Data: map[string]string{
+				"schedule_name": schedule.Name,
+				"schedule_code": schedule.Code,
+				"change":        change,
+			},
+		})
+	}
+}
+
+// validate melengkapi dan memvalidasi data pengecualian, jam pertemuan pindahan mengikuti jadwal mingguan jika kosong
+func (h scheduleExceptionHandler) validate(schedule model.Schedule, data model.ScheduleException, exceptID int) (model.ScheduleException, error) {
+	if !data.IsValidType() {
+		return data, errors.New("tipe: tipe harus cancel, reschedule atau makeup")
+	}
+
+	data.Date = converter.GetOnlyDateString(data.Date)
+	if err := validation.Validate(data.Date, validation.Required, validation.Date("2006-01-02")); err != nil {
+		return data, fmt.Errorf("tanggal: %v", err)
+	}
+
+	switch data.Type {
+	case model.ExceptionCancel, model.ExceptionMakeup:
+		data.NewDate = data.Date
+	case model.ExceptionReschedule:
+		data.NewDate = converter.GetOnlyDateString(data.NewDate)
+		if data.NewDate == "" {
+			data.NewDate = data.Date
+		}
+		if err := validation.Validate(data.NewDate, validation.Date("2006-01-02")); err != nil {
+			return data, fmt.Errorf("tanggal baru: %v", err)
+		}
+	}
+
+	if isInRange, _ := presence.IsDateInRange(data.GetMeetingDate(), schedule.StartDate, schedule.EndDate); !isInRange {
+		return data, errors.New("tanggal: tanggal pertemuan harus berada dalam periode jadwal")
+	}
+
+	if data.Type == model.ExceptionMakeup {
+		data.DailyScheduleID = 0
+	} else {
+		// pembatalan dan pemindahan hanya untuk pertemuan mingguan yang memang ada, daily_schedule_id membatasi ke satu blok
+		var dailySchedule model.DailySchedule
+		if data.DailyScheduleID > 0 {
+			result, err := h.dailyScheduleService.RetrieveDailySchedule(int(data.DailyScheduleID))
+			if err != nil || result.ScheduleID != schedule.ID || result.Name != converter.GetDayNameFromDateString(data.Date) {
+				return data, errors.New("daily_schedule_id: blok jadwal harian tidak ada pada tanggal tersebut")
+			}
+			dailySchedule = result
+		} else {
+			result, err := h.dailyScheduleService.RetrieveDailyScheduleByDayName(int(schedule.ID), converter.GetDayNameFromDateString(data.Date))
+			if err != nil || result.ID == 0 {
+				return data, errors.New("tanggal: tidak ada pertemuan pada tanggal tersebut")
+			}
+			dailySchedule = result
+		}
+		if h.scheduleExceptionService.CheckIsExistByDate(int(schedule.ID), data.Date, int(data.DailyScheduleID), exceptID) {
+			return data, errors.New("tanggal: pertemuan pada tanggal tersebut sudah dibatalkan atau dipindah")
+		}
+		if data.Type == model.ExceptionReschedule {
+			if data.StartTime == "" {
+				data.StartTime = dailySchedule.StartTime
+			}
+			if data.EndTime == "" {
+				data.EndTime = dailySchedule.EndTime
+			}
+		}
+	}
+
+	if !data.IsMeeting() {
+		data.StartTime = ""
+		data.EndTime = ""
+		data.RoomID = nil
+		return data, nil
+	}
+
+	if err := validation.Validate(data.StartTime, validation.Required, validation.Match(regexp.MustCompile(regex.TIME))); err != nil {
+		return data, fmt.Errorf("jam mulai: %v", err)
+	}
+	if err := validation.Validate(data.EndTime, validation.Required, validation.Match(regexp.MustCompile(regex.TIME))); err != nil {
+		return data, fmt.Errorf("jam selesai: %v", err)
+	}
+	if data.EndTime <= data.StartTime {
+		return data, errors.New("jam selesai: jam selesai harus setelah jam mulai")
+	}
+	if data.RoomID != nil && *data.RoomID > 0 && !h.roomService.CheckIsExist(int(*data.RoomID)) {
+		return data, errors.New("ruangan: data ruangan tidak ditemukan")
+	}
+	if data.RoomID != nil && *data.RoomID == 0 {
+		data.RoomID = nil
+	}
+	if h.scheduleExceptionService.CheckIsExistByNewDate(int(schedule.ID), data.NewDate, exceptID) {
+		return data, errors.New("tanggal baru: jadwal sudah memiliki pertemuan pengganti pada tanggal tersebut")
+	}
+
+	// pertemuan pengganti tidak boleh beririsan dengan blok mingguan yang masih berlangsung pada tanggal tersebut
+	dailySchedules, err := h.dailyScheduleService.ListDailyScheduleByDate(int(schedule.ID), data.NewDate)
+	if err != nil {
+		return data, err
+	}
+	for _, dailySchedule := range dailySchedules {
+		if data.NewDate == data.Date && data.IsRemoving(dailySchedule.ID) {
+			continue
+		}
+		endTime := dailySchedule.EndTime
+		if dailySchedule.IsOvernight() {
+			endTime = "24:00"
+		}
+		if dailySchedule.StartTime < data.EndTime && data.StartTime < endTime {
+			return data, fmt.Errorf("jam: beririsan dengan pertemuan mingguan pukul %s - %s", dailySchedule.StartTime, dailySchedule.EndTime)
+		}
+	}
+	return data, nil
+}
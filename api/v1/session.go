@@ -0,0 +1,264 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/response"
+	"attendance-api/common/util/pagination"
+	"attendance-api/infra"
+	"attendance-api/model"
+	"attendance-api/service"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type SessionHandler interface {
+	Retrieve(c *gin.Context)
+	Update(c *gin.Context)
+	List(c *gin.Context)
+	Generate(c *gin.Context)
+}
+
+type sessionHandler struct {
+	sessionService  service.SessionService
+	scheduleService service.ScheduleService
+	teacherService  service.TeacherService
+	infra           infra.Infra
+	middleware      middleware.Middleware
+}
+
+func NewSessionHandler(
+	sessionService service.SessionService,
+	scheduleService service.ScheduleService,
+	teacherService service.TeacherService,
+	infra infra.Infra,
+	middleware middleware.Middleware,
+) SessionHandler {
+	return &sessionHandler{
+		sessionService:  sessionService,
+		scheduleService: scheduleService,
+		teacherService:  teacherService,
+		infra:           infra,
+		middleware:      middleware,
+	}
+}
+
+// Retrieve ... Retrieve Session
+// @Summary Retrieve Single Session
+// @Description Retrieve Single Session (pertemuan)
+// @Tags Session
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.SessionResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /session/retrieve [get]
+// @Security BearerTokenAuth
+// @param id query string true "id session"
+func (h sessionHandler) Retrieve(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var result model.Session
+	if h.middleware.IsSuperAdmin(c) {
+		result, err = h.sessionService.RetrieveSession(id)
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	} else {
+		result, err = h.sessionService.RetrieveSessionByOwner(id, currentUserID)
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	}
+	response.New(c).Data(http.StatusCreated, "sukses mengambil data", result)
+}
+
+// Update ... Update Session
+// @Summary Update Single Session
+// @Description Isi topik, catatan dosen, dosen pengganti atau status pertemuan
+// @Tags Session
+// @Accept       json
+// @Produce      json
+// @Param data body model.SessionForm true "data"
+// @Success 200 {object} model.SessionResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /session/update [put]
+// @Security BearerTokenAuth
+// @param id query string true "id session"
+func (h sessionHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var current model.Session
+	if h.middleware.IsSuperAdmin(c) {
+		current, err = h.sessionService.RetrieveSession(id)
+	} else {
+		current, err = h.sessionService.RetrieveSessionByOwner(id, currentUserID)
+	}
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	var data model.Session
+	c.BindJSON(&data)
+
+	// tanggal, jam dan urutan pertemuan mengikuti jadwal, hanya isi pertemuan yang bisa diubah
+	session := model.Session{
+		GormCustom: model.GormCustom{
+			UpdatedBy: currentUserID,
+			UpdatedAt: time.Now(),
+		},
+		Status:       data.Status,
+		Topic:        data.Topic,
+		Notes:        data.Notes,
+		SubstituteID: data.SubstituteID,
+	}
+
+	if session.Status != "" && !session.IsValidStatus() {
+		response.New(c).Error(http.StatusBadRequest, errors.New("status: status harus planned, held atau cancelled"))
+		return
+	}
+
+	if err := validation.Validate(session.Topic, validation.Length(0, 255)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("topik: %v", err))
+		return
+	}
+
+	if session.SubstituteID > 0 {
+		if !h.middleware.IsSuperAdmin(c) && current.OwnerID != currentUserID {
+			response.New(c).Error(http.StatusBadRequest, errors.New("dosen pengganti: hanya dosen pengampu yang bisa menunjuk dosen pengganti"))
+			return
+		}
+		if _, err := h.teacherService.RetrieveTeacherByUserID(session.SubstituteID); err != nil {
+			response.New(c).Error(http.StatusBadRequest, errors.New("dosen pengganti: data dosen tidak ditemukan"))
+			return
+		}
+	}
+
+	var result model.Session
+	if h.middleware.IsSuperAdmin(c) {
+		result, err = h.sessionService.UpdateSession(id, session)
+	} else {
+		result, err = h.sessionService.UpdateSessionByOwner(id, currentUserID, session)
+	}
+
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
+}
+
+// List ... List all Session
+// @Summary List all Session
+// @Description List all Session (pertemuan), filter dengan schedule_id, status, date atau number
+// @Tags Session
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.SessionResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /session/list [get]
+// @Security BearerTokenAuth
+func (h sessionHandler) List(c *gin.Context) {
+	pagination := pagination.GeneratePaginationFromRequest(c)
+	var data model.Session
+	c.BindQuery(&data)
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		data.OwnerID = currentUserID
+	}
+
+	dataList, err := h.sessionService.ListSession(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	metaList, err := h.sessionService.ListSessionMeta(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+	}
+
+	response.New(c).List(http.StatusOK, "sukses mengambil list data", dataList, metaList)
+}
+
+// Generate ... Generate Session
+// @Summary Generate Session From Schedule
+// @Description Generate ulang pertemuan dari jadwal mingguan, pengecualian jadwal dan kalender akademik tanpa menghapus isi pertemuan
+// @Tags Session
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.SessionGenerateResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /session/generate [post]
+// @Security BearerTokenAuth
+// @param schedule_id query string true "id schedule"
+func (h sessionHandler) Generate(c *gin.Context) {
+	scheduleID, err := strconv.Atoi(c.Query("schedule_id"))
+	if scheduleID < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("schedule_id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	if h.middleware.IsSuperAdmin(c) {
+		_, err = h.scheduleService.RetrieveSchedule(scheduleID)
+	} else {
+		_, err = h.scheduleService.RetrieveScheduleByOwner(scheduleID, currentUserID)
+	}
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.sessionService.GenerateSession(scheduleID)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses generate data pertemuan", result)
+}
+
+// regenerateSession menyusun ulang pertemuan setelah jadwal berubah, kegagalan hanya dicatat agar perubahan jadwal tetap tersimpan
+func regenerateSession(sessionService service.SessionService, scheduleID int) {
+	if _, err := sessionService.GenerateSession(scheduleID); err != nil {
+		log.Printf("[Error] [Session-GenerateSession] E: %v\n", err)
+	}
+}
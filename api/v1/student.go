@@ -1,8 +1,8 @@
 package v1
 
 import (
-	"attendance-api/common/http/email"
 	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/notification"
 	"attendance-api/common/http/response"
 	"attendance-api/common/util/activation"
 	"attendance-api/common/util/pagination"
@@ -12,7 +12,6 @@ import (
 	"attendance-api/service"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -37,15 +36,19 @@ type studentHandler struct {
 	userService            service.UserService
 	studentService         service.StudentService
 	activationTokenService service.ActivationTokenService
+	guardianService        service.GuardianService
+	notifier               notification.Notifier
 	infra                  infra.Infra
 	middleware             middleware.Middleware
 }
 
-func NewStudentHandler(userService service.UserService, studentService service.StudentService, activationTokenService service.ActivationTokenService, infra infra.Infra, middleware middleware.Middleware) StudentHandler {
+func NewStudentHandler(userService service.UserService, studentService service.StudentService, activationTokenService service.ActivationTokenService, guardianService service.GuardianService, notifier notification.Notifier, infra infra.Infra, middleware middleware.Middleware) StudentHandler {
 	return &studentHandler{
 		userService:            userService,
 		studentService:         studentService,
 		activationTokenService: activationTokenService,
+		guardianService:        guardianService,
+		notifier:               notifier,
 		infra:                  infra,
 		middleware:             middleware,
 	}
@@ -90,6 +93,13 @@ func (h studentHandler) Create(c *gin.Context) {
 		return
 	}
 
+	guardians := data.Guardians
+	data.Guardians = nil
+	if err := validateGuardians(guardians); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
 	if err := validation.Validate(data.User.Username, validation.Required, validation.Length(4, 30), is.Alphanumeric); err != nil {
 		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("nama pengguna: %v", err))
 		return
@@ -133,6 +143,14 @@ func (h studentHandler) Create(c *gin.Context) {
 			return
 		}
 
+		if guardians != nil {
+			result.Guardians, err = h.guardianService.SaveStudentGuardian(int(result.ID), guardians)
+			if err != nil {
+				response.New(c).Error(http.StatusBadRequest, err)
+				return
+			}
+		}
+
 		user, err := h.userService.RetrieveUserByUsername(data.User.Username)
 		if err != nil {
 			response.New(c).Error(http.StatusInternalServerError, err)
@@ -153,14 +171,13 @@ func (h studentHandler) Create(c *gin.Context) {
 			return
 		}
 
-		go func(user model.User) {
-			config := h.infra.Config().Sub("server")
-			urlActivation := fmt.Sprintf("%s/v1/auth/activation?token=%s", config.GetString("base_url"), activationData.Token)
-
-			if err := email.New(h.infra.GoMail(), h.infra.Config()).SendActivation(user.FirstName, user.Email, urlActivation); err != nil {
-				log.Printf("Error Send Email E: %v", err)
-			}
-		}(user)
+		config := h.infra.Config().Sub("server")
+		h.notifier.Publish(notification.Event{
+			Type:      model.NotificationEventActivation,
+			Recipient: notification.Recipient{UserID: int(user.ID), Name: user.FirstName, Email: user.Email},
+			Data:      map[string]string{"url": fmt.Sprintf("%s/v1/auth/activation?token=%s", config.GetString("base_url"), activationData.Token)},
+			Channels:  []string{model.NotificationChannelEmail},
+		})
 
 		response.New(c).Data(http.StatusCreated, "sukses membuat data", result)
 		return
@@ -210,7 +227,8 @@ func (h studentHandler) Retrieve(c *gin.Context) {
 
 // Update ... Update Student
 // @Summary Update Single Student
-// @Description Update Single Student
+// @Description Update Single Student, guardians yang tidak dikirim berarti kontak wali tidak diubah,
+// @Description guardians kosong menghapus seluruh kontak wali
 // @Tags Student
 // @Accept       json
 // @Produce      json
@@ -262,6 +280,13 @@ func (h studentHandler) Update(c *gin.Context) {
 		return
 	}
 
+	guardians := data.Guardians
+	data.Guardians = nil
+	if err := validateGuardians(guardians); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
 	data.GormCustom.UpdatedBy = currentUserID
 	data.GormCustom.UpdatedAt = time.Now()
 
@@ -345,6 +370,14 @@ func (h studentHandler) Update(c *gin.Context) {
 		response.New(c).Error(http.StatusBadRequest, err)
 		return
 	}
+
+	if guardians != nil {
+		result.Guardians, err = h.guardianService.SaveStudentGuardian(id, guardians)
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+	}
 	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
 }
 
@@ -75,6 +75,11 @@ func (h subjectHandler) Create(c *gin.Context) {
 		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("nama: %v", err))
 		return
 	}
+
+	if err := validation.Validate(data.MinAttendance, validation.Min(0), validation.Max(100)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("min_attendance: %v", err))
+		return
+	}
 	result, err := h.subjectService.CreateSubject(data)
 	if err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
@@ -172,6 +177,11 @@ func (h subjectHandler) Update(c *gin.Context) {
 		return
 	}
 
+	if err := validation.Validate(data.MinAttendance, validation.Min(0), validation.Max(100)); err != nil {
+		response.New(c).Error(http.StatusBadRequest, fmt.Errorf("min_attendance: %v", err))
+		return
+	}
+
 	var result model.Subject
 	if h.middleware.IsSuperAdmin(c) {
 		result, err = h.subjectService.UpdateSubject(id, data)
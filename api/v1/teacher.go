@@ -1,8 +1,8 @@
 package v1
 
 import (
-	"attendance-api/common/http/email"
 	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/notification"
 	"attendance-api/common/http/response"
 	"attendance-api/common/util/activation"
 	"attendance-api/common/util/pagination"
@@ -12,7 +12,6 @@ import (
 	"attendance-api/service"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -37,15 +36,17 @@ type teacherHandler struct {
 	userService            service.UserService
 	teacherService         service.TeacherService
 	activationTokenService service.ActivationTokenService
+	notifier               notification.Notifier
 	infra                  infra.Infra
 	middleware             middleware.Middleware
 }
 
-func NewTeacherHandler(userService service.UserService, teacherService service.TeacherService, activationTokenService service.ActivationTokenService, infra infra.Infra, middleware middleware.Middleware) TeacherHandler {
+func NewTeacherHandler(userService service.UserService, teacherService service.TeacherService, activationTokenService service.ActivationTokenService, notifier notification.Notifier, infra infra.Infra, middleware middleware.Middleware) TeacherHandler {
 	return &teacherHandler{
 		userService:            userService,
 		teacherService:         teacherService,
 		activationTokenService: activationTokenService,
+		notifier:               notifier,
 		infra:                  infra,
 		middleware:             middleware,
 	}
@@ -153,14 +154,13 @@ func (h teacherHandler) Create(c *gin.Context) {
 			return
 		}
 
-		go func(user model.User) {
-			config := h.infra.Config().Sub("server")
-			urlActivation := fmt.Sprintf("%s/v1/auth/activation?token=%s", config.GetString("base_url"), activationData.Token)
-
-			if err := email.New(h.infra.GoMail(), h.infra.Config()).SendActivation(user.FirstName, user.Email, urlActivation); err != nil {
-				log.Printf("Error Send Email E: %v", err)
-			}
-		}(user)
+		config := h.infra.Config().Sub("server")
+		h.notifier.Publish(notification.Event{
+			Type:      model.NotificationEventActivation,
+			Recipient: notification.Recipient{UserID: int(user.ID), Name: user.FirstName, Email: user.Email},
+			Data:      map[string]string{"url": fmt.Sprintf("%s/v1/auth/activation?token=%s", config.GetString("base_url"), activationData.Token)},
+			Channels:  []string{model.NotificationChannelEmail},
+		})
 
 		response.New(c).Data(http.StatusCreated, "sukses membuat data", result)
 		return
@@ -1,9 +1,10 @@
 package v1
 
 import (
-	"attendance-api/common/http/email"
 	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/notification"
 	"attendance-api/common/http/response"
+	"attendance-api/common/http/webhook"
 	"attendance-api/common/util/activation"
 	"attendance-api/common/util/pagination"
 	"attendance-api/common/util/regex"
@@ -12,7 +13,6 @@ import (
 	"attendance-api/service"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -39,14 +39,18 @@ type UserHandler interface {
 type userHandler struct {
 	userService            service.UserService
 	activationTokenService service.ActivationTokenService
+	notifier               notification.Notifier
+	webhookDispatcher      webhook.Dispatcher
 	infra                  infra.Infra
 	middleware             middleware.Middleware
 }
 
-func NewUserHandler(userService service.UserService, activationTokenService service.ActivationTokenService, infra infra.Infra, middleware middleware.Middleware) UserHandler {
+func NewUserHandler(userService service.UserService, activationTokenService service.ActivationTokenService, notifier notification.Notifier, webhookDispatcher webhook.Dispatcher, infra infra.Infra, middleware middleware.Middleware) UserHandler {
 	return &userHandler{
 		userService:            userService,
 		activationTokenService: activationTokenService,
+		notifier:               notifier,
+		webhookDispatcher:      webhookDispatcher,
 		infra:                  infra,
 		middleware:             middleware,
 	}
@@ -141,14 +145,13 @@ func (h userHandler) Create(c *gin.Context) {
 			return
 		}
 
-		go func(user model.User) {
-			config := h.infra.Config().Sub("server")
-			urlActivation := fmt.Sprintf("%s/v1/auth/activation?token=%s", config.GetString("base_url"), activationData.Token)
-
-			if err := email.New(h.infra.GoMail(), h.infra.Config()).SendActivation(user.FirstName, user.Email, urlActivation); err != nil {
-				log.Printf("Error Send Email E: %v", err)
-			}
-		}(user)
+		config := h.infra.Config().Sub("server")
+		h.notifier.Publish(notification.Event{
+			Type:      model.NotificationEventActivation,
+			Recipient: notification.Recipient{UserID: int(user.ID), Name: user.FirstName, Email: user.Email},
+			Data:      map[string]string{"url": fmt.Sprintf("%s/v1/auth/activation?token=%s", config.GetString("base_url"), activationData.Token)},
+			Channels:  []string{model.NotificationChannelEmail},
+		})
 
 		response.New(c).Write(http.StatusCreated, "berhasil registrasi pengguna")
 		return
@@ -379,6 +382,10 @@ func (h userHandler) SetActive(c *gin.Context) {
 	result, err := h.userService.SetActiveUser(id)
 	if err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	if user, err := h.userService.RetrieveUser(id); err == nil {
+		h.webhookDispatcher.Dispatch(model.WebhookEventUserActivated, model.NewWebhookUserData(user))
 	}
 	response.New(c).Data(http.StatusOK, "sukses mengatur data menjadi aktif", result)
 }
@@ -29,13 +29,15 @@ type UserScheduleHandler interface {
 
 type userScheduleHandler struct {
 	userScheduleService service.UserScheduleService
+	scheduleService     service.ScheduleService
 	infra               infra.Infra
 	middleware          middleware.Middleware
 }
 
-func NewUserScheduleHandler(userScheduleService service.UserScheduleService, infra infra.Infra, middleware middleware.Middleware) UserScheduleHandler {
+func NewUserScheduleHandler(userScheduleService service.UserScheduleService, scheduleService service.ScheduleService, infra infra.Infra, middleware middleware.Middleware) UserScheduleHandler {
 	return &userScheduleHandler{
 		userScheduleService: userScheduleService,
+		scheduleService:     scheduleService,
 		infra:               infra,
 		middleware:          middleware,
 	}
@@ -50,8 +52,10 @@ func NewUserScheduleHandler(userScheduleService service.UserScheduleService, inf
 // @Param data body model.UserScheduleForm true "data"
 // @Success 200 {object} model.UserScheduleResponseData
 // @Failure 400,500 {object} model.Response
+// @Failure 409 {object} model.ScheduleConflictResponseData
 // @Router /user-schedule/create [post]
 // @Security BearerTokenAuth
+// @param override query bool false "tetap simpan walaupun bentrok (super admin)"
 func (h userScheduleHandler) Create(c *gin.Context) {
 	var data model.UserSchedule
 	c.BindJSON(&data)
@@ -67,6 +71,28 @@ func (h userScheduleHandler) Create(c *gin.Context) {
 		data.OwnerID = currentUserID
 	}
 
+	if !isOverrideConflict(c, h.middleware) {
+		schedule, err := h.scheduleService.RetrieveSchedule(int(data.ScheduleID))
+		if err != nil {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+
+		var conflicts []model.ScheduleConflict
+		for _, slot := range schedule.GetSlots() {
+			results, err := h.scheduleService.ListStudentConflict(data.UserID, int(schedule.ID), slot)
+			if err != nil {
+				response.New(c).Error(http.StatusBadRequest, err)
+				return
+			}
+			conflicts = append(conflicts, results...)
+		}
+		if len(conflicts) > 0 {
+			writeScheduleConflict(c, conflicts)
+			return
+		}
+	}
+
 	result, err := h.userScheduleService.CreateUserSchedule(data)
 	if err != nil {
 		response.New(c).Error(http.StatusBadRequest, err)
@@ -3,6 +3,8 @@ package v1_test
 import (
 	v1 "attendance-api/api/v1"
 	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/notification"
+	"attendance-api/common/http/webhook"
 	"attendance-api/infra"
 	"attendance-api/manager"
 	"attendance-api/mocks"
@@ -28,7 +30,7 @@ func TestListUser(t *testing.T) {
 		gin := gin.New()
 		rec := httptest.NewRecorder()
 		infra := infra.New("../../config/config.json")
-		UserHandler := v1.NewUserHandler(userServiceMock, activationTokenServiceMoc, infra, middleware.NewMiddleware(infra.Config().GetString("secret.key"), manager.NewServiceManager(infra).AuthService()))
+		UserHandler := v1.NewUserHandler(userServiceMock, activationTokenServiceMoc, notification.New(notification.DefaultTemplates(), nil, nil), webhook.New(nil, nil), infra, middleware.NewMiddleware(infra.Config().GetString("secret.key"), manager.NewServiceManager(infra).AuthService()))
 		gin.GET("/user/list", UserHandler.List)
 
 		req := httptest.NewRequest(http.MethodGet, "/user/list", strings.NewReader(""))
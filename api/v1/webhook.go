@@ -0,0 +1,328 @@
+package v1
+
+import (
+	"attendance-api/common/http/middleware"
+	"attendance-api/common/http/response"
+	"attendance-api/common/http/webhook"
+	"attendance-api/common/util/myqr"
+	"attendance-api/common/util/pagination"
+	"attendance-api/infra"
+	"attendance-api/model"
+	"attendance-api/service"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler interface {
+	Create(c *gin.Context)
+	Retrieve(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	List(c *gin.Context)
+	DeliveryList(c *gin.Context)
+	Redeliver(c *gin.Context)
+}
+
+type webhookHandler struct {
+	webhookService    service.WebhookService
+	webhookDispatcher webhook.Dispatcher
+	infra             infra.Infra
+	middleware        middleware.Middleware
+}
+
+func NewWebhookHandler(
+	webhookService service.WebhookService,
+	webhookDispatcher webhook.Dispatcher,
+	infra infra.Infra,
+	middleware middleware.Middleware) WebhookHandler {
+	return &webhookHandler{
+		webhookService:    webhookService,
+		webhookDispatcher: webhookDispatcher,
+		infra:             infra,
+		middleware:        middleware,
+	}
+}
+
+// Create ... Create Webhook
+// @Summary Create New Webhook
+// @Description Langganan event untuk sistem luar, events dipisah koma (attendance.clock_in, attendance.clock_out, attendance.status_changed, user.activated).
+// @Description Setiap request ditandatangani: header X-Webhook-Signature berisi sha256=<hex HMAC-SHA256 secret dari "<X-Webhook-Timestamp>.<body>">.
+// @Description Secret dibuat otomatis jika kosong
+// @Tags Webhook
+// @Accept       json
+// @Produce      json
+// @Param data body model.WebhookForm true "data"
+// @Success 200 {object} model.WebhookResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /webhook/create [post]
+// @Security BearerTokenAuth
+func (h webhookHandler) Create(c *gin.Context) {
+	var data model.Webhook
+	c.BindJSON(&data)
+
+	if !h.middleware.IsSuperAdmin(c) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	data.GormCustom.CreatedBy = currentUserID
+	data.IsActive = true
+	data.FailureCount = 0
+	data.DisabledAt = nil
+
+	data.Events = strings.Join(data.EventList(), ",")
+	if data.Secret == "" {
+		data.Secret = myqr.GenerateSecret(32)
+	}
+	if err := data.Validate(); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.webhookService.CreateWebhook(data)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusCreated, "sukses membuat data", result)
+}
+
+// Retrieve ... Retrieve Webhook
+// @Summary Retrieve Single Webhook
+// @Description Retrieve Single Webhook
+// @Tags Webhook
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.WebhookResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /webhook/retrieve [get]
+// @Security BearerTokenAuth
+// @param id query string true "id webhook"
+func (h webhookHandler) Retrieve(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+		return
+	}
+
+	result, err := h.webhookService.RetrieveWebhook(id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses mengambil data", result)
+}
+
+// Update ... Update Webhook
+// @Summary Update Single Webhook
+// @Description Field yang tidak dikirim tetap memakai nilai sebelumnya. is_active true mengaktifkan kembali webhook yang dinonaktifkan otomatis
+// @Tags Webhook
+// @Accept       json
+// @Produce      json
+// @Param data body model.WebhookForm true "data"
+// @Success 200 {object} model.WebhookResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /webhook/update [put]
+// @Security BearerTokenAuth
+// @param id query string true "id webhook"
+func (h webhookHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+		return
+	}
+
+	currentUserID, err := h.middleware.GetUserID(c)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	data, err := h.webhookService.RetrieveWebhook(id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	c.BindJSON(&data)
+
+	data.UpdatedBy = currentUserID
+	data.UpdatedAt = time.Now()
+	data.Events = strings.Join(data.EventList(), ",")
+	if err := data.Validate(); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.webhookService.UpdateWebhook(id, data)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Data(http.StatusOK, "sukses memperbaharui data", result)
+}
+
+// Delete ... Delete Webhook
+// @Summary Delete Single Webhook
+// @Description Menghapus webhook beserta log pengirimannya
+// @Tags Webhook
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.Response
+// @Failure 400,500 {object} model.Response
+// @Router /webhook/delete [delete]
+// @Security BearerTokenAuth
+// @param id query string true "id webhook"
+func (h webhookHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(id); err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	response.New(c).Write(http.StatusOK, "sukses menghapus data")
+}
+
+// List ... List all Webhook
+// @Summary List all Webhook
+// @Description List all Webhook, filter name, url dan events (satu event)
+// @Tags Webhook
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.WebhookResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /webhook/list [get]
+// @Security BearerTokenAuth
+func (h webhookHandler) List(c *gin.Context) {
+	pagination := pagination.GeneratePaginationFromRequest(c)
+	var data model.Webhook
+	c.BindQuery(&data)
+
+	if !h.middleware.IsSuperAdmin(c) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+		return
+	}
+
+	dataList, err := h.webhookService.ListWebhook(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	metaList, err := h.webhookService.ListWebhookMeta(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	response.New(c).List(http.StatusOK, "sukses mengambil list data", dataList, metaList)
+}
+
+// DeliveryList ... List all Webhook Delivery
+// @Summary List all Webhook Delivery
+// @Description Log pengiriman webhook, filter webhook_id, event dan status (pending / success / failed)
+// @Tags Webhook
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.WebhookDeliveryResponseList
+// @Failure 400,500 {object} model.Response
+// @Router /webhook/delivery/list [get]
+// @Security BearerTokenAuth
+func (h webhookHandler) DeliveryList(c *gin.Context) {
+	pagination := pagination.GeneratePaginationFromRequest(c)
+	var data model.WebhookDelivery
+	c.BindQuery(&data)
+
+	if !h.middleware.IsSuperAdmin(c) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+		return
+	}
+
+	dataList, err := h.webhookService.ListWebhookDelivery(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	metaList, err := h.webhookService.ListWebhookDeliveryMeta(data, pagination)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+
+	response.New(c).List(http.StatusOK, "sukses mengambil list data", dataList, metaList)
+}
+
+// Redeliver ... Redeliver Webhook Delivery
+// @Summary Redeliver Webhook Delivery
+// @Description Mengirim ulang payload pengiriman sebagai pengiriman baru, header X-Webhook-Delivery tetap berisi id pengiriman asal
+// @Tags Webhook
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} model.WebhookDeliveryResponseData
+// @Failure 400,500 {object} model.Response
+// @Router /webhook/redeliver [post]
+// @Security BearerTokenAuth
+// @param id query string true "id pengiriman"
+func (h webhookHandler) Redeliver(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if id < 1 || err != nil {
+		response.New(c).Error(http.StatusBadRequest, errors.New("id harus diisi dengan nomor yang valid"))
+		return
+	}
+
+	if !h.middleware.IsSuperAdmin(c) {
+		response.New(c).Error(http.StatusBadRequest, errors.New("anda tidak memiliki akses untuk melakukan proses ini"))
+		return
+	}
+
+	delivery, err := h.webhookService.RetrieveWebhookDelivery(id)
+	if err != nil {
+		response.New(c).Error(http.StatusBadRequest, err)
+		return
+	}
+	if !delivery.Webhook.IsActive {
+		response.New(c).Error(http.StatusBadRequest, errors.New("webhook tidak aktif, aktifkan kembali sebelum mengirim ulang"))
+		return
+	}
+
+	result, err := h.webhookDispatcher.Redeliver(delivery)
+	if err != nil {
+		if result.ID == 0 {
+			response.New(c).Error(http.StatusBadRequest, err)
+			return
+		}
+		// sudah tercatat pending, dikirim oleh task webhook_retry
+		log.Printf("[Error] [Webhook-Redeliver] E: %v\n", err)
+	}
+	response.New(c).Data(http.StatusCreated, "sukses menjadwalkan pengiriman ulang", result)
+}
@@ -11,6 +11,7 @@ import (
 type Email interface {
 	SendActivation(toUserName string, toEmail string, urlActivation string) error
 	SendForgotPassword(toUserName string, toEmail string, urlActivation string, validUntil time.Time) error
+	SendNotification(toUserName string, toEmail string, title string, message string) error
 }
 
 type email struct {
@@ -58,3 +59,18 @@ func (m *email) SendForgotPassword(toUserName string, toEmail string, urlActivat
 	}
 	return nil
 }
+
+func (m *email) SendNotification(toUserName string, toEmail string, title string, message string) error {
+	notificationHTML := GenerateTemplateNotification(toUserName, toEmail, title, message, m.config)
+	mailer := gomail.NewMessage()
+	mailer.SetHeader("From", m.config.Sub("general").GetString("company_name")+" <"+m.config.Sub("general").GetString("company_email")+">")
+	mailer.SetHeader("To", toEmail)
+	mailer.SetHeader("Subject", title)
+	mailer.SetBody("text/html", notificationHTML)
+	err := m.m.DialAndSend(mailer)
+	if err != nil {
+		log.Printf("Err GOMAIL: %v", err)
+		return err
+	}
+	return nil
+}
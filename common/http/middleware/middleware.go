@@ -4,23 +4,31 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"attendance-api/common/http/response"
 	"attendance-api/common/util/token"
+	"attendance-api/model"
 	"attendance-api/service"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
 )
 
+// streamTicketTTL masa berlaku tiket stream, cukup untuk membuka koneksi EventSource
+const streamTicketTTL = time.Minute
+
 type Middleware interface {
 	CORS() gin.HandlerFunc
 	AUTH() gin.HandlerFunc
 	SUPERADMIN() gin.HandlerFunc
 	ADMIN() gin.HandlerFunc
 	USER() gin.HandlerFunc
+	STREAMTICKET() gin.HandlerFunc
+	GenerateStreamTicket(c *gin.Context, scheduleID int) (model.ScheduleStreamTicket, error)
+	GetStreamTicket(c *gin.Context) (model.StreamTicketPayload, error)
 	GetUserID(c *gin.Context) (userID int, err error)
 	HaveAccess(c *gin.Context, ownerID int) gin.HandlerFunc
 	IsSuperAdmin(c *gin.Context) bool
@@ -228,6 +236,65 @@ func (m *middleware) USER() gin.HandlerFunc {
 	}
 }
 
+// STREAMTICKET memvalidasi tiket stream dari query ticket, dipakai untuk EventSource yang tidak bisa
+// mengirim header sendiri sehingga token akses tidak perlu ditaruh di url
+func (m *middleware) STREAMTICKET() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, err := m.GetStreamTicket(c); err != nil {
+			response.New(c).Error(http.StatusUnauthorized, err)
+			c.Abort()
+		} else {
+			c.Next()
+		}
+	}
+}
+
+// GenerateStreamTicket tiket stream untuk satu jadwal dari token akses pengguna, berlaku selama streamTicketTTL
+func (m *middleware) GenerateStreamTicket(c *gin.Context, scheduleID int) (model.ScheduleStreamTicket, error) {
+	tokenData, validToken, err := ValidateToken(m, c)
+	if !validToken && err != nil {
+		return model.ScheduleStreamTicket{}, err
+	}
+	claims, ok := tokenData.Claims.(jwt.MapClaims)
+	if !ok || !tokenData.Valid {
+		return model.ScheduleStreamTicket{}, fmt.Errorf("token tidak valid")
+	}
+
+	expired, ticket := token.NewToken(m.secretKey).GenerateStreamTicket(model.StreamTicketPayload{
+		UserID:     uint(claims["user_id"].(float64)),
+		AuthUUID:   claims["auth_uuid"].(string),
+		ScheduleID: uint(scheduleID),
+		Expired:    time.Now().Add(streamTicketTTL).Unix(),
+	})
+	return model.ScheduleStreamTicket{
+		ScheduleID: uint(scheduleID),
+		Ticket:     ticket,
+		Expired:    expired,
+	}, nil
+}
+
+// GetStreamTicket tiket stream harus untuk jadwal pada query id dan sesi login pembuatnya belum keluar
+func (m *middleware) GetStreamTicket(c *gin.Context) (model.StreamTicketPayload, error) {
+	ticket, err := token.NewToken(m.secretKey).ValidateStreamTicket(c.Query("ticket"))
+	if err != nil {
+		return model.StreamTicketPayload{}, err
+	}
+	if c.Query("id") != strconv.Itoa(int(ticket.ScheduleID)) {
+		return model.StreamTicketPayload{}, errors.New("tiket tidak berlaku untuk jadwal ini")
+	}
+
+	authUser, err := m.authService.FetchAuth(ticket.UserID, ticket.AuthUUID)
+	if err != nil {
+		return model.StreamTicketPayload{}, errors.New("akses tidak sah ditolak")
+	}
+	user, err := m.authService.GetByID(authUser.UserID)
+	if err != nil {
+		return model.StreamTicketPayload{}, errors.New("akses tidak sah ditolak")
+	}
+	ticket.IsSuperAdmin = user.IsSuperAdmin
+	return ticket, nil
+}
+
 func (m *middleware) GetUserID(c *gin.Context) (userId int, err error) {
 	token, validToken, err := ValidateToken(m, c)
 	if !validToken && err != nil {
@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"attendance-api/common/http/email"
+	"attendance-api/model"
+	"errors"
+	"html"
+	"strings"
+	"time"
+)
+
+type emailChannel struct {
+	email email.Email
+}
+
+// NewEmailChannel kanal email SMTP, aktivasi dan lupa kata sandi memakai template html khusus
+func NewEmailChannel(email email.Email) Channel {
+	return &emailChannel{email: email}
+}
+
+func (c emailChannel) Name() string {
+	return model.NotificationChannelEmail
+}
+
+func (c emailChannel) Send(message Message) error {
+	if message.Recipient.Email == "" {
+		return errors.New("email penerima kosong")
+	}
+
+	switch message.Event {
+	case model.NotificationEventActivation:
+		return c.email.SendActivation(message.Recipient.Name, message.Recipient.Email, message.Data["url"])
+	case model.NotificationEventForgotPassword:
+		validUntil, _ := time.Parse(time.RFC3339, message.Data["valid_until"])
+		return c.email.SendForgotPassword(message.Recipient.Name, message.Recipient.Email, message.Data["url"], validUntil)
+	}
+
+	body := strings.ReplaceAll(html.EscapeString(message.Body), "\n", "<br>")
+	return c.email.SendNotification(message.Recipient.Name, message.Recipient.Email, message.Title, body)
+}
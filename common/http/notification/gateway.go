@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"attendance-api/model"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type gatewayChannel struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// GatewayPayload body yang dikirim ke gateway HTTP (sms / whatsapp)
+type GatewayPayload struct {
+	To      string `json:"to"`
+	Name    string `json:"name"`
+	Event   string `json:"event"`
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+// NewGatewayChannel kanal gateway HTTP generik, pesan dikirim sebagai POST json dengan token Bearer
+func NewGatewayChannel(url string, token string, client *http.Client) Channel {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &gatewayChannel{
+		url:    url,
+		token:  token,
+		client: client,
+	}
+}
+
+func (c gatewayChannel) Name() string {
+	return model.NotificationChannelGateway
+}
+
+func (c gatewayChannel) Send(message Message) error {
+	if message.Recipient.Phone == "" {
+		return errors.New("no telp penerima kosong")
+	}
+
+	body, err := json.Marshal(GatewayPayload{
+		To:      message.Recipient.Phone,
+		Name:    message.Recipient.Name,
+		Event:   message.Event,
+		Title:   message.Title,
+		Message: message.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return fmt.Errorf("gateway membalas %d: %s", response.StatusCode, string(detail))
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package notification
+
+import (
+	"attendance-api/model"
+	"errors"
+)
+
+type InAppStore interface {
+	CreateNotification(notification model.Notification) (model.Notification, error)
+}
+
+type inAppChannel struct {
+	store InAppStore
+}
+
+// NewInAppChannel kanal kotak masuk aplikasi, pesan disimpan ke tabel notifications
+func NewInAppChannel(store InAppStore) Channel {
+	return &inAppChannel{store: store}
+}
+
+func (c inAppChannel) Name() string {
+	return model.NotificationChannelInApp
+}
+
+func (c inAppChannel) Send(message Message) error {
+	if message.Recipient.UserID < 1 {
+		return errors.New("penerima bukan pengguna aplikasi")
+	}
+
+	_, err := c.store.CreateNotification(model.Notification{
+		UserID:  message.Recipient.UserID,
+		Event:   message.Event,
+		Title:   message.Title,
+		Message: message.Body,
+	})
+	return err
+}
@@ -0,0 +1,168 @@
+package notification
+
+import (
+	"attendance-api/common/http/email"
+	"attendance-api/model"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Recipient penerima pesan, UserID 0 untuk penerima di luar sistem (misal wali mahasiswa)
+type Recipient struct {
+	UserID int
+	Name   string
+	Email  string
+	Phone  string
+}
+
+// Event kejadian yang dipublikasikan handler / job, Channels kosong berarti mengikuti preferensi pengguna
+type Event struct {
+	Type      string
+	Recipient Recipient
+	Data      map[string]string
+	Channels  []string
+}
+
+// Message pesan hasil template yang dikirim ke kanal
+type Message struct {
+	Event     string
+	Recipient Recipient
+	Title     string
+	Body      string
+	Data      map[string]string
+}
+
+type Channel interface {
+	Name() string
+	Send(message Message) error
+}
+
+type PreferenceStore interface {
+	ListNotificationPreference(userID int) ([]model.NotificationPreference, error)
+}
+
+type Notifier interface {
+	// Publish mengirim di background, kegagalan hanya dicatat di log
+	Publish(event Event)
+	// Send mengirim ke setiap kanal dan mengembalikan kanal yang berhasil
+	Send(event Event) (sent []string, err error)
+}
+
+type notifier struct {
+	templates       Templates
+	defaultChannels []string
+	preferences     PreferenceStore
+	channels        map[string]Channel
+}
+
+func New(templates Templates, defaultChannels []string, preferences PreferenceStore, channels ...Channel) Notifier {
+	registered := map[string]Channel{}
+	for _, channel := range channels {
+		registered[channel.Name()] = channel
+	}
+	return &notifier{
+		templates:       templates,
+		defaultChannels: defaultChannels,
+		preferences:     preferences,
+		channels:        registered,
+	}
+}
+
+func (n notifier) Publish(event Event) {
+	go func(event Event) {
+		if _, err := n.Send(event); err != nil {
+			log.Printf("[Notification] [Error] [%s] E: %v\n", event.Type, err)
+		}
+	}(event)
+}
+
+func (n notifier) Send(event Event) (sent []string, err error) {
+	message, err := n.templates.Render(event)
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []string
+	for _, name := range n.resolveChannels(event) {
+		channel, ok := n.channels[name]
+		if !ok {
+			failures = append(failures, fmt.Sprintf("%s: kanal belum dikonfigurasi", name))
+			continue
+		}
+		if err := channel.Send(message); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		sent = append(sent, name)
+	}
+
+	if len(failures) > 0 {
+		return sent, errors.New(strings.Join(failures, "; "))
+	}
+	return sent, nil
+}
+
+// resolveChannels kanal event, jika kosong preferensi pengguna dengan kanal bawaan untuk kanal yang belum diatur
+func (n notifier) resolveChannels(event Event) []string {
+	if len(event.Channels) > 0 {
+		return event.Channels
+	}
+
+	enabled := map[string]bool{}
+	for _, name := range n.defaultChannels {
+		enabled[name] = true
+	}
+	if event.Recipient.UserID > 0 && n.preferences != nil {
+		preferences, err := n.preferences.ListNotificationPreference(event.Recipient.UserID)
+		if err != nil {
+			log.Printf("[Notification] [Error] [ListNotificationPreference] E: %v\n", err)
+		}
+		for _, preference := range preferences {
+			enabled[preference.Channel] = preference.IsEnabled
+		}
+	}
+
+	var channels []string
+	// penerima di luar sistem tidak memiliki kotak masuk
+	enabled[model.NotificationChannelInApp] = enabled[model.NotificationChannelInApp] && event.Recipient.UserID > 0
+
+	for _, name := range model.NotificationChannels {
+		if enabled[name] {
+			channels = append(channels, name)
+		}
+	}
+	return channels
+}
+
+type Store interface {
+	PreferenceStore
+	InAppStore
+}
+
+// NewFromConfig notifier dari config notification, kanal gateway hanya aktif jika gateway.url diisi
+func NewFromConfig(config *viper.Viper, mailer email.Email, store Store) Notifier {
+	defaultChannels := []string{model.NotificationChannelEmail, model.NotificationChannelInApp}
+	channels := []Channel{NewEmailChannel(mailer), NewInAppChannel(store)}
+	if config == nil {
+		return New(DefaultTemplates(), defaultChannels, store, channels...)
+	}
+
+	if config.IsSet("default_channels") {
+		defaultChannels = config.GetStringSlice("default_channels")
+	}
+	if url := config.GetString("gateway.url"); url != "" {
+		timeout := config.GetInt("gateway.timeout")
+		if timeout <= 0 {
+			timeout = 10
+		}
+		client := &http.Client{Timeout: time.Duration(timeout) * time.Second}
+		channels = append(channels, NewGatewayChannel(url, config.GetString("gateway.token"), client))
+	}
+	return New(LoadTemplates(config), defaultChannels, store, channels...)
+}
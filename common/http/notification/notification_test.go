@@ -0,0 +1,130 @@
+package notification
+
+import (
+	"attendance-api/model"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type memoryStore struct {
+	preferences   []model.NotificationPreference
+	notifications []model.Notification
+}
+
+func (s *memoryStore) ListNotificationPreference(userID int) ([]model.NotificationPreference, error) {
+	return s.preferences, nil
+}
+
+func (s *memoryStore) CreateNotification(notification model.Notification) (model.Notification, error) {
+	s.notifications = append(s.notifications, notification)
+	return notification, nil
+}
+
+type failChannel struct{}
+
+func (failChannel) Name() string               { return model.NotificationChannelEmail }
+func (failChannel) Send(message Message) error { return errors.New("smtp mati") }
+
+func TestGatewayChannel(t *testing.T) {
+	var got GatewayPayload
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body error = %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	channel := NewGatewayChannel(server.URL, "rahasia", server.Client())
+	err := channel.Send(Message{
+		Event:     model.NotificationEventAttendanceAlert,
+		Recipient: Recipient{Name: "Budi", Phone: "08123"},
+		Title:     "judul",
+		Body:      "isi",
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if authorization != "Bearer rahasia" {
+		t.Errorf("Authorization = %q", authorization)
+	}
+	want := GatewayPayload{To: "08123", Name: "Budi", Event: model.NotificationEventAttendanceAlert, Title: "judul", Message: "isi"}
+	if got != want {
+		t.Errorf("payload = %+v, want %+v", got, want)
+	}
+}
+
+func TestGatewayChannelError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nomor tidak valid", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	channel := NewGatewayChannel(server.URL, "", server.Client())
+	if err := channel.Send(Message{Recipient: Recipient{Phone: "08123"}}); err == nil {
+		t.Error("Send() expected error for status 400")
+	}
+	if err := channel.Send(Message{}); err == nil {
+		t.Error("Send() expected error for empty phone")
+	}
+}
+
+func TestRender(t *testing.T) {
+	templates := Templates{"test": {Title: "Hai {{.name}}", Body: "{{.rate}}% {{.unknown}}"}}
+	message, err := templates.Render(Event{
+		Type:      "test",
+		Recipient: Recipient{Name: "Budi"},
+		Data:      map[string]string{"rate": "70"},
+	})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if message.Title != "Hai Budi" || message.Body != "70% " {
+		t.Errorf("Render() = %q / %q", message.Title, message.Body)
+	}
+	if _, err := templates.Render(Event{Type: "tidak_ada"}); err == nil {
+		t.Error("Render() expected error for unknown event")
+	}
+}
+
+func TestSendPreference(t *testing.T) {
+	store := &memoryStore{preferences: []model.NotificationPreference{
+		{UserID: 1, Channel: model.NotificationChannelEmail, IsEnabled: false},
+	}}
+	n := New(DefaultTemplates(), []string{model.NotificationChannelEmail, model.NotificationChannelInApp}, store, failChannel{}, NewInAppChannel(store))
+
+	event := Event{
+		Type:      model.NotificationEventAttendanceAlert,
+		Recipient: Recipient{UserID: 1, Name: "Budi"},
+		Data:      map[string]string{"schedule_name": "Kalkulus"},
+	}
+	sent, err := n.Send(event)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if !reflect.DeepEqual(sent, []string{model.NotificationChannelInApp}) {
+		t.Errorf("sent = %v", sent)
+	}
+	if len(store.notifications) != 1 || store.notifications[0].UserID != 1 {
+		t.Errorf("notifications = %+v", store.notifications)
+	}
+
+	// kanal event mengabaikan preferensi
+	event.Channels = []string{model.NotificationChannelEmail}
+	if sent, err := n.Send(event); err == nil || len(sent) != 0 {
+		t.Errorf("Send() = %v, %v, want email error", sent, err)
+	}
+
+	// penerima di luar sistem tidak memakai kotak masuk
+	event.Channels = nil
+	event.Recipient.UserID = 0
+	if sent, _ := n.Send(event); len(sent) != 0 {
+		t.Errorf("sent = %v, want none", sent)
+	}
+}
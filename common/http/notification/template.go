@@ -0,0 +1,126 @@
+package notification
+
+import (
+	"attendance-api/model"
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/spf13/viper"
+)
+
+// Template judul dan isi pesan, ditulis dengan text/template dan diisi Event.Data
+type Template struct {
+	Title string
+	Body  string
+}
+
+// Templates template pesan per tipe event
+type Templates map[string]Template
+
+func DefaultTemplates() Templates {
+	return Templates{
+		model.NotificationEventActivation: {
+			Title: "Silahkan aktivasi akun mu",
+			Body:  "Hai {{.name}}, silahkan aktivasi akun anda melalui tautan berikut: {{.url}}",
+		},
+		model.NotificationEventForgotPassword: {
+			Title: "Atur Ulang Kata Sandi",
+			Body:  "Hai {{.name}}, silahkan atur ulang kata sandi anda melalui tautan berikut: {{.url}}. Tautan berlaku sampai {{.valid_until}}",
+		},
+		model.NotificationEventAttendanceAlert: {
+			Title: "Peringatan kehadiran {{.level}}: {{.schedule_name}}",
+			Body: "Kehadiran anda pada jadwal {{.schedule_name}} ({{.schedule_code}}) {{.rate}}% dari {{.held_meeting}} pertemuan, di bawah ambang {{.threshold}}%. " +
+				"Segera perbaiki kehadiran anda agar tetap memenuhi syarat mengikuti ujian.",
+		},
+		model.NotificationEventAttendanceAlertOwner: {
+			Title: "Peringatan kehadiran {{.level}}: {{.schedule_name}}",
+			Body:  "Mahasiswa {{.student_name}} ({{.username}}) pada jadwal {{.schedule_name}} ({{.schedule_code}}) kehadiran {{.rate}}% dari {{.held_meeting}} pertemuan, di bawah ambang {{.threshold}}%.",
+		},
+		model.NotificationEventGuardianDigest: {
+			Title: "Ringkasan kehadiran {{.date}}",
+			Body:  "Berikut catatan ketidakhadiran dan keterlambatan pada tanggal {{.date}}:\n\n{{.records}}\n\nJika tidak ingin menerima ringkasan ini lagi, kunjungi {{.opt_out_url}}",
+		},
+		model.NotificationEventClockIn: {
+			Title: "Absen masuk {{.schedule_name}}",
+			Body:  "Absen masuk pada jadwal {{.schedule_name}} ({{.schedule_code}}) tanggal {{.date}} berhasil dicatat dengan status {{.status}}.",
+		},
+		model.NotificationEventClockOut: {
+			Title: "Absen keluar {{.schedule_name}}",
+			Body:  "Absen keluar pada jadwal {{.schedule_name}} ({{.schedule_code}}) tanggal {{.date}} berhasil dicatat dengan status {{.status}}.",
+		},
+		model.NotificationEventLeaveRequestDecided: {
+			Title: "Pengajuan {{.type}} {{.status}}",
+			Body:  "Pengajuan {{.type}} anda untuk tanggal {{.start_date}} sampai {{.end_date}} telah diputuskan dengan status {{.status}}. Catatan: {{.note}}",
+		},
+		model.NotificationEventCorrectionReviewed: {
+			Title: "Koreksi presensi {{.status}}",
+			Body:  "Pengajuan koreksi presensi anda pada jadwal {{.schedule_name}} tanggal {{.date}} telah diperiksa dengan status {{.status}}. Catatan: {{.note}}",
+		},
+		model.NotificationEventScheduleException: {
+			Title: "Perubahan jadwal {{.schedule_name}}",
+			Body:  "Jadwal {{.schedule_name}} ({{.schedule_code}}): {{.change}}.",
+		},
+	}
+}
+
+// LoadTemplates template bawaan yang ditimpa dari config notification.templates.<event>.title / body
+func LoadTemplates(config *viper.Viper) Templates {
+	templates := DefaultTemplates()
+	if config == nil {
+		return templates
+	}
+	for event := range config.GetStringMap("templates") {
+		current := templates[event]
+		if title := config.GetString("templates." + event + ".title"); title != "" {
+			current.Title = title
+		}
+		if body := config.GetString("templates." + event + ".body"); body != "" {
+			current.Body = body
+		}
+		templates[event] = current
+	}
+	return templates
+}
+
+// Render pesan dari template event, nama penerima tersedia sebagai {{.name}}
+func (templates Templates) Render(event Event) (Message, error) {
+	tmpl, ok := templates[event.Type]
+	if !ok {
+		return Message{}, fmt.Errorf("template notifikasi %s tidak ditemukan", event.Type)
+	}
+
+	data := map[string]string{"name": event.Recipient.Name}
+	for key, value := range event.Data {
+		data[key] = value
+	}
+
+	title, err := execute(event.Type+".title", tmpl.Title, data)
+	if err != nil {
+		return Message{}, err
+	}
+	body, err := execute(event.Type+".body", tmpl.Body, data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		Event:     event.Type,
+		Recipient: event.Recipient,
+		Title:     title,
+		Body:      body,
+		Data:      data,
+	}, nil
+}
+
+func execute(name string, text string, data map[string]string) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"attendance-api/scheduler"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+type Queue interface {
+	// Enqueue memasukkan pengiriman ke antrian consumer, delay > 0 untuk percobaan ulang
+	Enqueue(deliveryID uint, delay time.Duration) error
+}
+
+type amqpQueue struct {
+	connect   func() *amqp.Connection
+	queueName string
+}
+
+// NewAMQPQueue antrian task "webhook" pada queue consumer. Percobaan ulang ditunda lewat queue <queue_name>_retry_<detik>
+// dengan TTL yang mengembalikan pesan ke queue consumer saat kedaluarsa, satu queue per jeda agar pesan tidak saling menunggu
+func NewAMQPQueue(connect func() *amqp.Connection, queueName string) Queue {
+	return &amqpQueue{
+		connect:   connect,
+		queueName: queueName,
+	}
+}
+
+func (q amqpQueue) Enqueue(deliveryID uint, delay time.Duration) error {
+	conn := q.connect()
+	if conn == nil || conn.IsClosed() {
+		return errors.New("koneksi amqp tidak tersedia")
+	}
+
+	amqpChannel, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer amqpChannel.Close()
+
+	body, err := json.Marshal(scheduler.AddTask{
+		Action:    "webhook",
+		Body:      strconv.Itoa(int(deliveryID)),
+		Date:      time.Now().Format("2006-01-02"),
+		TimeStamp: time.Now().Format("2006-01-02 15:04:05"),
+	})
+	if err != nil {
+		return err
+	}
+
+	queueName := q.queueName
+	var args amqp.Table
+	if seconds := int(delay / time.Second); seconds > 0 {
+		queueName = fmt.Sprintf("%s_retry_%d", q.queueName, seconds)
+		args = amqp.Table{
+			"x-message-ttl":             int32(seconds * 1000),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": q.queueName,
+		}
+	}
+
+	queue, err := amqpChannel.QueueDeclare(queueName, true, false, false, false, args)
+	if err != nil {
+		return fmt.Errorf(`could not declare "%s" queue: %v`, queueName, err)
+	}
+
+	return amqpChannel.Publish("", queue.Name, false, false, amqp.Publishing{
+		DeliveryMode: amqp.Persistent,
+		ContentType:  "text/plain",
+		Body:         body,
+	})
+}
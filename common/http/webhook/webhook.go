@@ -0,0 +1,167 @@
+package webhook
+
+import (
+	"attendance-api/model"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Sign tanda tangan HMAC-SHA256 dari "<timestamp>.<body>" dalam format sha256=<hex>
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Result hasil satu percobaan pengiriman
+type Result struct {
+	StatusCode int
+	Body       string
+	Err        error
+}
+
+func (result Result) IsSuccess() bool {
+	return result.Err == nil && result.StatusCode >= 200 && result.StatusCode <= 299
+}
+
+// Message pesan kegagalan untuk log pengiriman
+func (result Result) Message() string {
+	if result.Err != nil {
+		return result.Err.Error()
+	}
+	if !result.IsSuccess() {
+		return fmt.Sprintf("endpoint membalas %d", result.StatusCode)
+	}
+	return ""
+}
+
+type Sender interface {
+	Send(webhook model.Webhook, delivery model.WebhookDelivery) Result
+}
+
+type sender struct {
+	client *http.Client
+}
+
+func NewSender(client *http.Client) Sender {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &sender{client: client}
+}
+
+// Send POST payload delivery ke URL webhook dengan header tanda tangan
+func (s sender) Send(webhook model.Webhook, delivery model.WebhookDelivery) Result {
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	request, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return Result{Err: err}
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderEvent, delivery.Event)
+	request.Header.Set(HeaderDelivery, strconv.Itoa(int(delivery.OriginID())))
+	request.Header.Set(HeaderTimestamp, timestamp)
+	request.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, body))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return Result{Err: err}
+	}
+	defer response.Body.Close()
+
+	detail, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return Result{StatusCode: response.StatusCode, Body: string(detail)}
+}
+
+// Store penyimpanan webhook dan log pengiriman, dipenuhi WebhookService
+type Store interface {
+	ListActiveWebhookByEvent(event string) ([]model.Webhook, error)
+	CreateWebhookDelivery(delivery model.WebhookDelivery) (model.WebhookDelivery, error)
+}
+
+type Dispatcher interface {
+	// Dispatch membuat log pengiriman untuk setiap webhook yang melanggan event dan memasukkannya ke antrian di background
+	Dispatch(event string, data interface{})
+	// Redeliver membuat pengiriman baru dengan payload yang sama dan memasukkannya ke antrian
+	Redeliver(delivery model.WebhookDelivery) (model.WebhookDelivery, error)
+}
+
+type dispatcher struct {
+	store Store
+	queue Queue
+}
+
+func New(store Store, queue Queue) Dispatcher {
+	return &dispatcher{
+		store: store,
+		queue: queue,
+	}
+}
+
+func (d dispatcher) Dispatch(event string, data interface{}) {
+	go func(createdAt time.Time) {
+		payload, err := json.Marshal(model.WebhookPayload{
+			Event:     event,
+			CreatedAt: createdAt,
+			Data:      data,
+		})
+		if err != nil {
+			log.Printf("[Webhook] [Error] [%s] E: %v\n", event, err)
+			return
+		}
+
+		webhooks, err := d.store.ListActiveWebhookByEvent(event)
+		if err != nil {
+			log.Printf("[Webhook] [Error] [ListActiveWebhookByEvent] E: %v\n", err)
+			return
+		}
+		for _, webhook := range webhooks {
+			if _, err := d.deliver(model.WebhookDelivery{WebhookID: webhook.ID, Event: event, Payload: string(payload)}); err != nil {
+				log.Printf("[Webhook] [Error] [%s] [%v] E: %v\n", event, webhook.ID, err)
+			}
+		}
+	}(time.Now())
+}
+
+func (d dispatcher) Redeliver(delivery model.WebhookDelivery) (model.WebhookDelivery, error) {
+	return d.deliver(model.WebhookDelivery{
+		WebhookID:    delivery.WebhookID,
+		Event:        delivery.Event,
+		Payload:      delivery.Payload,
+		RedeliveryOf: delivery.OriginID(),
+	})
+}
+
+func (d dispatcher) deliver(delivery model.WebhookDelivery) (model.WebhookDelivery, error) {
+	delivery.Status = model.WebhookDeliveryPending
+	delivery, err := d.store.CreateWebhookDelivery(delivery)
+	if err != nil {
+		return model.WebhookDelivery{}, err
+	}
+
+	// pengiriman yang gagal masuk antrian diambil kembali oleh task webhook_retry
+	if err := d.queue.Enqueue(delivery.ID, 0); err != nil {
+		return delivery, fmt.Errorf("antrian: %v", err)
+	}
+	return delivery, nil
+}
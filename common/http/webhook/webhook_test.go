@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"attendance-api/model"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type memoryStore struct {
+	deliveries []model.WebhookDelivery
+}
+
+func (s *memoryStore) ListActiveWebhookByEvent(event string) ([]model.Webhook, error) {
+	return nil, nil
+}
+
+func (s *memoryStore) CreateWebhookDelivery(delivery model.WebhookDelivery) (model.WebhookDelivery, error) {
+	delivery.ID = uint(len(s.deliveries) + 10)
+	s.deliveries = append(s.deliveries, delivery)
+	return delivery, nil
+}
+
+type memoryQueue struct {
+	ids []uint
+}
+
+func (q *memoryQueue) Enqueue(deliveryID uint, delay time.Duration) error {
+	q.ids = append(q.ids, deliveryID)
+	return nil
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{"event":"user.activated"}' | openssl dgst -sha256 -hmac rahasia
+	got := Sign("rahasia", "1700000000", []byte(`{"event":"user.activated"}`))
+	want := "sha256=5763fc6b8c5b1dc3fd1f142870cfc94c1e76b76f4e8702c42319496ea1be7a44"
+	if got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+	if got == Sign("rahasia", "1700000001", []byte(`{"event":"user.activated"}`)) {
+		t.Error("Sign() sama untuk timestamp berbeda")
+	}
+}
+
+func TestSenderSend(t *testing.T) {
+	var header http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	hook := model.Webhook{URL: server.URL, Secret: "rahasia-webhook-1"}
+	delivery := model.WebhookDelivery{Event: model.WebhookEventClockIn, Payload: `{"event":"attendance.clock_in"}`, RedeliveryOf: 7}
+	delivery.ID = 9
+
+	result := NewSender(server.Client()).Send(hook, delivery)
+	if !result.IsSuccess() || result.Message() != "" {
+		t.Fatalf("Send() = %+v", result)
+	}
+	if string(body) != delivery.Payload {
+		t.Errorf("body = %s", body)
+	}
+	if header.Get(HeaderEvent) != model.WebhookEventClockIn || header.Get(HeaderDelivery) != "7" {
+		t.Errorf("header = %v", header)
+	}
+	if want := Sign(hook.Secret, header.Get(HeaderTimestamp), body); header.Get(HeaderSignature) != want {
+		t.Errorf("signature = %q, want %q", header.Get(HeaderSignature), want)
+	}
+}
+
+func TestSenderSendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	result := NewSender(server.Client()).Send(model.Webhook{URL: server.URL}, model.WebhookDelivery{})
+	if result.IsSuccess() || result.StatusCode != http.StatusServiceUnavailable || result.Message() == "" {
+		t.Errorf("Send() = %+v, want 503 failure", result)
+	}
+
+	result = NewSender(server.Client()).Send(model.Webhook{URL: "http://127.0.0.1:0"}, model.WebhookDelivery{})
+	if result.IsSuccess() || result.Err == nil {
+		t.Errorf("Send() = %+v, want connection error", result)
+	}
+}
+
+func TestRedeliver(t *testing.T) {
+	store := &memoryStore{}
+	queue := &memoryQueue{}
+	dispatcher := New(store, queue)
+
+	original := model.WebhookDelivery{WebhookID: 3, Event: model.WebhookEventUserActivated, Payload: `{}`, Status: model.WebhookDeliveryFailed}
+	original.ID = 5
+
+	result, err := dispatcher.Redeliver(original)
+	if err != nil {
+		t.Fatalf("Redeliver() error = %v", err)
+	}
+	if result.Status != model.WebhookDeliveryPending || result.RedeliveryOf != 5 || result.Payload != `{}` || result.WebhookID != 3 {
+		t.Errorf("Redeliver() = %+v", result)
+	}
+	if len(queue.ids) != 1 || queue.ids[0] != result.ID {
+		t.Errorf("queue = %v", queue.ids)
+	}
+
+	// pengiriman ulang dari pengiriman ulang tetap merujuk pengiriman asal
+	again, _ := dispatcher.Redeliver(result)
+	if again.RedeliveryOf != 5 {
+		t.Errorf("RedeliveryOf = %v, want 5", again.RedeliveryOf)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	rule := model.WebhookRule{BackoffBase: 30, BackoffMax: 100}
+	for attempt, want := range map[int]time.Duration{1: 30 * time.Second, 2: 60 * time.Second, 3: 100 * time.Second, 10: 100 * time.Second} {
+		if got := rule.Backoff(attempt); got != want {
+			t.Errorf("Backoff(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
@@ -6,22 +6,16 @@ import (
 	"time"
 )
 
-func CalculateLateDuration(scheduleIn string, clockIn int64, timeZoneIn int, lateTolerance int) (reuslt string) {
-	timeStringIn := converter.MillisToTimeString(clockIn, timeZoneIn)
-	timeIn, err := time.Parse("2006-01-02 15:04", "2006-01-02"+" "+timeStringIn)
+// CalculateLateDuration keterlambatan absen masuk terhadap jam mulai blok pada tanggal absensi
+func CalculateLateDuration(date string, scheduleIn string, clockIn int64, timeZoneIn int, lateTolerance int) (reuslt string) {
+	diff, err := DiffFromSchedule(date, scheduleIn, false, clockIn, timeZoneIn)
 	if err != nil {
 		log.Printf("kesalahan dalam mengurai waktu (masuk) : %v\n", err)
 		return "00:00:00"
 	}
-	timeSchedule, err := time.Parse("2006-01-02 15:04", "2006-01-02"+" "+scheduleIn)
-	if err != nil {
-		log.Printf("kesalahan dalam mengurai waktu (jadwal) : %v\n", err)
-		return "00:00:00"
-	}
 	if lateTolerance > 0 {
-		timeSchedule = timeSchedule.Add(time.Minute * time.Duration(lateTolerance))
+		diff -= time.Minute * time.Duration(lateTolerance)
 	}
-	diff := timeIn.Sub(timeSchedule)
 
 	if diff <= 0 {
 		return "00:00:00"
@@ -30,22 +24,35 @@ func CalculateLateDuration(scheduleIn string, clockIn int64, timeZoneIn int, lat
 	}
 }
 
-func CalculateEarlyDuration(scheduleOut string, clockOut int64, timeZoneOut int) (result string) {
-	timeStringOut := converter.MillisToTimeString(clockOut, timeZoneOut)
-	timeOut, err := time.Parse("2006-01-02 15:04", "2006-01-02"+" "+timeStringOut)
+// CalculateEarlyDuration pulang lebih awal terhadap jam selesai blok, jam selesai blok lintas tengah malam
+// (isOvernight) jatuh pada hari setelah tanggal absensi
+func CalculateEarlyDuration(date string, scheduleOut string, isOvernight bool, clockOut int64, timeZoneOut int) (result string) {
+	diff, err := DiffFromSchedule(date, scheduleOut, isOvernight, clockOut, timeZoneOut)
 	if err != nil {
-		log.Printf("kesalahan dalam mengurai waktu (masuk): %v\n", err)
+		log.Printf("kesalahan dalam mengurai waktu (keluar) : %v\n", err)
 		return "00:00:00"
 	}
-	timeSchedule, err := time.Parse("2006-01-02 15:04", "2006-01-02"+" "+scheduleOut)
-	if err != nil {
-		log.Printf("kesalahan dalam mengurai waktu (jadwal) : %v\n", err)
-		return "00:00:00"
-	}
-	diff := timeSchedule.Sub(timeOut)
+	diff = -diff
 	if diff <= 0 {
 		return "00:00:00"
 	} else {
 		return converter.FormatDuration(diff)
 	}
 }
+
+// DiffFromSchedule selisih waktu absen terhadap jam jadwal (15:04) pada tanggal absensi (2006-01-02),
+// isNextDay dipakai untuk jam jadwal yang jatuh pada hari berikutnya
+func DiffFromSchedule(date string, scheduleTime string, isNextDay bool, checkIn int64, timeZone int) (time.Duration, error) {
+	timeSchedule, err := time.Parse("2006-01-02 15:04", date+" "+scheduleTime)
+	if err != nil {
+		return 0, err
+	}
+	if isNextDay {
+		timeSchedule = timeSchedule.AddDate(0, 0, 1)
+	}
+	timeCheck, err := time.Parse("2006-01-02 15:04", converter.MillisToDateTimeString(checkIn, timeZone))
+	if err != nil {
+		return 0, err
+	}
+	return timeCheck.Sub(timeSchedule), nil
+}
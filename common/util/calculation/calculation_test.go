@@ -0,0 +1,56 @@
+package calculation_test
+
+import (
+	"attendance-api/common/util/calculation"
+	"testing"
+	"time"
+)
+
+func millis(dateTime string) int64 {
+	t, _ := time.ParseInLocation("2006-01-02 15:04", dateTime, time.FixedZone("", 7*3600))
+	return t.UnixMilli()
+}
+
+func TestCalculateLateDuration(t *testing.T) {
+	tests := []struct {
+		scheduleIn string
+		clockIn    string
+		tolerance  int
+		expected   string
+	}{
+		{"08:00", "2024-03-04 08:30", 0, "00:30:00"},
+		{"08:00", "2024-03-04 08:10", 15, "00:00:00"},
+		{"08:00", "2024-03-04 07:45", 0, "00:00:00"},
+		{"08:00", "2024-03-04 21:00", 0, "13:00:00"},
+		// blok lintas tengah malam, masuk setelah pukul 00:00
+		{"23:30", "2024-03-05 00:10", 0, "00:40:00"},
+		{"23:30", "2024-03-04 23:20", 0, "00:00:00"},
+	}
+	for _, test := range tests {
+		if result := calculation.CalculateLateDuration("2024-03-04", test.scheduleIn, millis(test.clockIn), 7, test.tolerance); result != test.expected {
+			t.Errorf("jadwal %s masuk %s: expected %s, got %s", test.scheduleIn, test.clockIn, test.expected, result)
+		}
+	}
+}
+
+func TestCalculateEarlyDuration(t *testing.T) {
+	tests := []struct {
+		scheduleOut string
+		isOvernight bool
+		clockOut    string
+		expected    string
+	}{
+		{"16:00", false, "2024-03-04 15:30", "00:30:00"},
+		{"16:00", false, "2024-03-04 16:05", "00:00:00"},
+		{"16:00", false, "2024-03-04 03:00", "13:00:00"},
+		// jadwal 22:00 - 02:00
+		{"02:00", true, "2024-03-04 23:00", "03:00:00"},
+		{"02:00", true, "2024-03-05 01:45", "00:15:00"},
+		{"02:00", true, "2024-03-05 02:30", "00:00:00"},
+	}
+	for _, test := range tests {
+		if result := calculation.CalculateEarlyDuration("2024-03-04", test.scheduleOut, test.isOvernight, millis(test.clockOut), 7); result != test.expected {
+			t.Errorf("jadwal %s keluar %s: expected %s, got %s", test.scheduleOut, test.clockOut, test.expected, result)
+		}
+	}
+}
@@ -33,6 +33,27 @@ func MillisToTimeString(timeMillis int64, timeZone int) (timeString string) {
 	}
 }
 
+// MillisToDateString tanggal (2006-01-02) dari waktu millis pada zona waktu (jam GMT)
+func MillisToDateString(timeMillis int64, timeZone int) (dateString string) {
+	if timeMillis <= 0 {
+		return ""
+	}
+	millisFinal := timeMillis + int64(timeZone)*3600000
+	return time.Unix(0, millisFinal*int64(time.Millisecond)).UTC().Format("2006-01-02")
+}
+
+// MillisToDateTimeString tanggal dan jam (2006-01-02 15:04) dari waktu millis, zona waktu 0 memakai waktu server
+func MillisToDateTimeString(timeMillis int64, timeZone int) (dateTimeString string) {
+	if timeMillis <= 0 {
+		return ""
+	}
+	if timeZone == 0 {
+		return time.Unix(0, timeMillis*int64(time.Millisecond)).Format("2006-01-02 15:04")
+	}
+	millisFinal := timeMillis + int64(timeZone)*3600000
+	return time.Unix(0, millisFinal*int64(time.Millisecond)).UTC().Format("2006-01-02 15:04")
+}
+
 func FormatDuration(d time.Duration) string {
 	d = d.Round(time.Minute)
 	h := d / time.Hour
@@ -56,6 +77,17 @@ func GetDayNameFromDateString(date string) (dayName string) {
 	return days[int(myTime.Weekday())]
 }
 
+// ShiftDayName nama hari (sunday - saturday) setelah digeser sejumlah hari, nilai negatif untuk hari sebelumnya
+func ShiftDayName(dayName string, shift int) string {
+	days := []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+	for i, day := range days {
+		if day == dayName {
+			return days[((i+shift)%7+7)%7]
+		}
+	}
+	return dayName
+}
+
 func MonthInterval(y int, m time.Month) (firstDay, lastDay time.Time) {
 	firstDay = time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
 	lastDay = time.Date(y, m+1, 1, 0, 0, 0, -1, time.UTC)
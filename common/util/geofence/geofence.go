@@ -0,0 +1,77 @@
+package geofence
+
+import "math"
+
+const earthRadius = 6371000 // in metter
+
+type Point struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Distance menghitung jarak dua titik dalam meter (haversine)
+func Distance(from Point, to Point) float64 {
+	lat1 := from.Latitude * math.Pi / 180
+	lat2 := to.Latitude * math.Pi / 180
+	deltaLat := (to.Latitude - from.Latitude) * math.Pi / 180
+	deltaLng := (to.Longitude - from.Longitude) * math.Pi / 180
+
+	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(deltaLng/2)*math.Sin(deltaLng/2)
+	return earthRadius * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// InPolygon mengecek titik berada di dalam polygon (ray casting)
+func InPolygon(point Point, polygon []Point) bool {
+	if len(polygon) < 3 {
+		return false
+	}
+
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Latitude > point.Latitude) != (b.Latitude > point.Latitude) &&
+			point.Longitude < (b.Longitude-a.Longitude)*(point.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// DistanceToPolygon menghitung jarak titik ke tepi polygon terdekat dalam meter, 0 jika di dalam
+func DistanceToPolygon(point Point, polygon []Point) float64 {
+	if InPolygon(point, polygon) {
+		return 0
+	}
+
+	nearest := math.MaxFloat64
+	for i := range polygon {
+		a := polygon[i]
+		b := polygon[(i+1)%len(polygon)]
+		if dist := distanceToSegment(point, a, b); dist < nearest {
+			nearest = dist
+		}
+	}
+	return nearest
+}
+
+// distanceToSegment memakai proyeksi equirectangular lokal, cukup akurat untuk skala gedung
+func distanceToSegment(point Point, a Point, b Point) float64 {
+	cosLat := math.Cos(point.Latitude * math.Pi / 180)
+	project := func(p Point) (x float64, y float64) {
+		x = (p.Longitude - point.Longitude) * math.Pi / 180 * earthRadius * cosLat
+		y = (p.Latitude - point.Latitude) * math.Pi / 180 * earthRadius
+		return
+	}
+
+	ax, ay := project(a)
+	bx, by := project(b)
+	dx, dy := bx-ax, by-ay
+
+	t := 0.0
+	if length := dx*dx + dy*dy; length > 0 {
+		t = -(ax*dx + ay*dy) / length
+		t = math.Max(0, math.Min(1, t))
+	}
+	return math.Hypot(ax+t*dx, ay+t*dy)
+}
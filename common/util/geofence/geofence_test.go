@@ -0,0 +1,43 @@
+package geofence_test
+
+import (
+	"attendance-api/common/util/geofence"
+	"math"
+	"testing"
+)
+
+func TestPolygon(t *testing.T) {
+	// gedung memanjang kira-kira 220m x 55m
+	building := []geofence.Point{
+		{Latitude: -7.7700, Longitude: 110.3770},
+		{Latitude: -7.7700, Longitude: 110.3790},
+		{Latitude: -7.7705, Longitude: 110.3790},
+		{Latitude: -7.7705, Longitude: 110.3770},
+	}
+
+	inside := geofence.Point{Latitude: -7.77025, Longitude: 110.3788}
+	if !geofence.InPolygon(inside, building) {
+		t.Errorf("expected point inside polygon")
+	}
+	if dist := geofence.DistanceToPolygon(inside, building); dist != 0 {
+		t.Errorf("expected distance 0, got %f", dist)
+	}
+
+	outside := geofence.Point{Latitude: -7.7710, Longitude: 110.3780}
+	if geofence.InPolygon(outside, building) {
+		t.Errorf("expected point outside polygon")
+	}
+
+	// 0.0005 derajat lintang kurang lebih 55.6 meter
+	if dist := geofence.DistanceToPolygon(outside, building); math.Abs(dist-55.6) > 1 {
+		t.Errorf("expected distance around 55.6m, got %f", dist)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	from := geofence.Point{Latitude: -7.7700, Longitude: 110.3770}
+	to := geofence.Point{Latitude: -7.7710, Longitude: 110.3770}
+	if dist := geofence.Distance(from, to); math.Abs(dist-111.2) > 1 {
+		t.Errorf("expected distance around 111.2m, got %f", dist)
+	}
+}
@@ -0,0 +1,123 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// Event satu VEVENT dengan tanggal inklusif dalam format 2006-01-02
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	StartDate   string
+	EndDate     string
+}
+
+// Parse membaca berkas iCalendar (RFC 5545) dan mengambil seluruh VEVENT di dalamnya
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current *Event
+	var allDay bool
+	var start, end time.Time
+	for _, line := range lines {
+		name, params, value := splitLine(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &Event{}
+			allDay = false
+			start, end = time.Time{}, time.Time{}
+		case name == "END" && value == "VEVENT":
+			if current == nil {
+				continue
+			}
+			if start.IsZero() {
+				return nil, errors.New("event " + current.Summary + " tidak memiliki DTSTART")
+			}
+			if end.IsZero() {
+				end = start
+			} else if allDay && end.After(start) {
+				// DTEND pada event seharian bersifat eksklusif
+				end = end.AddDate(0, 0, -1)
+			}
+			current.StartDate = start.Format("2006-01-02")
+			current.EndDate = end.Format("2006-01-02")
+			events = append(events, *current)
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = value
+		case name == "SUMMARY":
+			current.Summary = unescape(value)
+		case name == "DESCRIPTION":
+			current.Description = unescape(value)
+		case name == "DTSTART":
+			start, err = parseDate(value)
+			if err != nil {
+				return nil, err
+			}
+			allDay = strings.Contains(params, "VALUE=DATE") || len(value) == 8
+		case name == "DTEND":
+			end, err = parseDate(value)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return events, nil
+}
+
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func splitLine(line string) (name string, params string, value string) {
+	idx := strings.Index(line, ":")
+	if idx < 0 {
+		return strings.ToUpper(line), "", ""
+	}
+	name, value = line[:idx], line[idx+1:]
+	if p := strings.Index(name, ";"); p >= 0 {
+		name, params = name[:p], strings.ToUpper(name[p+1:])
+	}
+	return strings.ToUpper(name), params, value
+}
+
+func parseDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, errors.New("format tanggal ical tidak valid: " + value)
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, errors.New("format tanggal ical tidak valid: " + value)
+	}
+	return date, nil
+}
+
+func unescape(value string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return replacer.Replace(value)
+}
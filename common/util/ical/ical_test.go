@@ -0,0 +1,49 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	data := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:20240817@holiday\r\n" +
+		"DTSTART;VALUE=DATE:20240817\r\n" +
+		"DTEND;VALUE=DATE:20240818\r\n" +
+		"SUMMARY:Hari Proklamasi\r\n" +
+		"  Kemerdekaan\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:cuti-bersama\r\n" +
+		"DTSTART;VALUE=DATE:20240408\r\n" +
+		"DTEND;VALUE=DATE:20240413\r\n" +
+		"SUMMARY:Cuti Bersama Idul Fitri\\, 1445 H\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:ujian\r\n" +
+		"DTSTART:20240610T080000Z\r\n" +
+		"SUMMARY:Ujian\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	events, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Event{
+		{UID: "20240817@holiday", Summary: "Hari Proklamasi Kemerdekaan", StartDate: "2024-08-17", EndDate: "2024-08-17"},
+		{UID: "cuti-bersama", Summary: "Cuti Bersama Idul Fitri, 1445 H", StartDate: "2024-04-08", EndDate: "2024-04-12"},
+		{UID: "ujian", Summary: "Ujian", StartDate: "2024-06-10", EndDate: "2024-06-10"},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %d", len(expected), len(events))
+	}
+	for i, event := range events {
+		if event != expected[i] {
+			t.Errorf("event %d: expected %+v, got %+v", i, expected[i], event)
+		}
+	}
+}
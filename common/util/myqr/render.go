@@ -0,0 +1,166 @@
+package myqr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	DefaultImageSize = 256 // in pixel
+	MinImageSize     = 64
+	MaxImageSize     = 2048
+
+	captionLineHeight = 18 // in pixel
+	captionPadding    = 8
+)
+
+// RenderOption mengatur ukuran, level koreksi dan keterangan gambar kode qr
+type RenderOption struct {
+	Size    int
+	Level   string
+	Caption []string
+}
+
+// RecoveryLevel mengubah level koreksi (L, M, Q, H) ke level milik encoder
+func RecoveryLevel(level string) (qrcode.RecoveryLevel, error) {
+	switch strings.ToUpper(level) {
+	case "", "M":
+		return qrcode.Medium, nil
+	case "L":
+		return qrcode.Low, nil
+	case "Q":
+		return qrcode.High, nil
+	case "H":
+		return qrcode.Highest, nil
+	}
+	return qrcode.Medium, errors.New("level koreksi harus salah satu dari L, M, Q, H")
+}
+
+func (opt RenderOption) normalize() (RenderOption, error) {
+	if opt.Size == 0 {
+		opt.Size = DefaultImageSize
+	}
+	if opt.Size < MinImageSize || opt.Size > MaxImageSize {
+		return opt, fmt.Errorf("ukuran gambar harus antara %d dan %d pixel", MinImageSize, MaxImageSize)
+	}
+
+	var caption []string
+	for _, line := range opt.Caption {
+		if line = strings.TrimSpace(line); line != "" {
+			caption = append(caption, line)
+		}
+	}
+	opt.Caption = caption
+	return opt, nil
+}
+
+// RenderPNG menghasilkan gambar png dari payload kode qr
+func RenderPNG(payload string, opt RenderOption) ([]byte, error) {
+	opt, err := opt.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := RecoveryLevel(opt.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	qr, err := qrcode.New(payload, level)
+	if err != nil {
+		return nil, err
+	}
+
+	qrImage := qr.Image(opt.Size)
+	captionHeight := 0
+	if len(opt.Caption) > 0 {
+		captionHeight = len(opt.Caption)*captionLineHeight + captionPadding
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, opt.Size, opt.Size+captionHeight))
+	draw.Draw(canvas, canvas.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(canvas, qrImage.Bounds(), qrImage, image.Point{}, draw.Src)
+
+	face := basicfont.Face7x13
+	drawer := font.Drawer{
+		Dst:  canvas,
+		Src:  image.NewUniform(color.Black),
+		Face: face,
+	}
+	for i, line := range opt.Caption {
+		width := drawer.MeasureString(line).Ceil()
+		x := (opt.Size - width) / 2
+		if x < 0 {
+			x = 0
+		}
+		drawer.Dot = fixed.P(x, opt.Size+(i+1)*captionLineHeight-captionPadding/2)
+		drawer.DrawString(line)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, canvas); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderSVG menghasilkan gambar svg dari payload kode qr
+func RenderSVG(payload string, opt RenderOption) ([]byte, error) {
+	opt, err := opt.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := RecoveryLevel(opt.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	qr, err := qrcode.New(payload, level)
+	if err != nil {
+		return nil, err
+	}
+
+	bitmap := qr.Bitmap()
+	modules := len(bitmap)
+	captionHeight := 0
+	if len(opt.Caption) > 0 {
+		captionHeight = len(opt.Caption)*captionLineHeight + captionPadding
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		opt.Size, opt.Size+captionHeight, opt.Size, opt.Size+captionHeight)
+	buf.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>`)
+
+	scale := float64(opt.Size) / float64(modules)
+	fmt.Fprintf(&buf, `<g transform="scale(%.4f)" fill="#000000">`, scale)
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="1" height="1"/>`, x, y)
+			}
+		}
+	}
+	buf.WriteString(`</g>`)
+
+	for i, line := range opt.Caption {
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" font-family="sans-serif" font-size="14" text-anchor="middle" fill="#000000">%s</text>`,
+			opt.Size/2, opt.Size+(i+1)*captionLineHeight-captionPadding/2, html.EscapeString(line))
+	}
+	buf.WriteString(`</svg>`)
+
+	return buf.Bytes(), nil
+}
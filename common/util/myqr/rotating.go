@@ -0,0 +1,96 @@
+package myqr
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+const (
+	ModeStatic   = "static"
+	ModeRotating = "rotating"
+
+	// RotatingSeparator memisahkan kode dasar jadwal dengan token waktu
+	RotatingSeparator = "."
+
+	DefaultRotatingInterval = 30 // in second
+	DefaultRotatingSkew     = 1  // jumlah window sebelum/sesudah yang masih diterima
+	rotatingTokenLength     = 8
+)
+
+// GenerateSecret membuat secret acak (hex) untuk kode qr berputar
+func GenerateSecret(length int) (secret string) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return StringWithCharset(length*2, charset)
+	}
+	return hex.EncodeToString(b)
+}
+
+// WindowCounter mengembalikan nomor window waktu untuk interval tertentu
+func WindowCounter(t time.Time, interval int) int64 {
+	if interval <= 0 {
+		interval = DefaultRotatingInterval
+	}
+	return t.Unix() / int64(interval)
+}
+
+// WindowExpiredAt mengembalikan waktu berakhirnya window yang sedang berjalan
+func WindowExpiredAt(t time.Time, interval int) time.Time {
+	if interval <= 0 {
+		interval = DefaultRotatingInterval
+	}
+	return time.Unix((WindowCounter(t, interval)+1)*int64(interval), 0)
+}
+
+// RotatingToken menghasilkan token untuk window tertentu dari secret jadwal
+func RotatingToken(secret string, counter int64) (token string) {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	b := make([]byte, rotatingTokenLength)
+	for i := range b {
+		b[i] = charset[int(sum[i])%len(charset)]
+	}
+	return string(b)
+}
+
+// GenerateRotating menghasilkan payload kode qr yang berlaku pada waktu t
+func GenerateRotating(code string, secret string, t time.Time, interval int) (qrcode string) {
+	return code + RotatingSeparator + RotatingToken(secret, WindowCounter(t, interval))
+}
+
+// ValidateRotating mengecek token terhadap window saat ini dan toleransi skew
+func ValidateRotating(secret string, token string, t time.Time, interval int, skew int) bool {
+	if secret == "" || token == "" {
+		return false
+	}
+	if skew < 0 {
+		skew = 0
+	}
+	current := WindowCounter(t, interval)
+	for i := -skew; i <= skew; i++ {
+		expected := RotatingToken(secret, current+int64(i))
+		if hmac.Equal([]byte(expected), []byte(token)) {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitPayload memisahkan payload hasil scan menjadi kode dasar dan token
+func SplitPayload(payload string) (code string, token string) {
+	idx := strings.LastIndex(payload, RotatingSeparator)
+	if idx < 0 {
+		return payload, ""
+	}
+	return payload[:idx], payload[idx+1:]
+}
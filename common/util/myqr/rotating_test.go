@@ -0,0 +1,39 @@
+package myqr_test
+
+import (
+	"attendance-api/common/util/myqr"
+	"testing"
+	"time"
+)
+
+func TestValidateRotating(t *testing.T) {
+	secret := myqr.GenerateSecret(16)
+	now := time.Unix(1700000000, 0)
+	interval := 30
+
+	payload := myqr.GenerateRotating("ABCD1234", secret, now, interval)
+	code, token := myqr.SplitPayload(payload)
+	if code != "ABCD1234" {
+		t.Fatalf("expected code ABCD1234, got %s", code)
+	}
+
+	testCases := []struct {
+		name     string
+		at       time.Time
+		secret   string
+		expected bool
+	}{
+		{"same window", now, secret, true},
+		{"previous window within skew", now.Add(time.Duration(interval) * time.Second), secret, true},
+		{"outside skew", now.Add(time.Duration(interval*3) * time.Second), secret, false},
+		{"wrong secret", now, "other-secret", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := myqr.ValidateRotating(tc.secret, token, tc.at, interval, 1); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 const (
 	NAME = "^[a-zA-Z\\s]{2,40}$"
+	TIME = "^([01][0-9]|2[0-3]):[0-5][0-9]$"
 )
 
 var matchFirstCap = regexp.MustCompile("(.)([A-Z][a-z]+)")
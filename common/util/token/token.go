@@ -4,6 +4,7 @@ import (
 	"attendance-api/model"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
@@ -17,8 +18,13 @@ type Token interface {
 	ValidateRefreshToken(token string) (*jwt.Token, error)
 	ExtractToken(c *gin.Context) string
 	ExtractTokenAuth(c *gin.Context) (model.Auth, error)
+	GenerateStreamTicket(data model.StreamTicketPayload) (expiredDate int64, tokenData string)
+	ValidateStreamTicket(ticket string) (model.StreamTicketPayload, error)
 }
 
+// streamTicketKey tiket stream ditandatangani dengan kunci turunan agar tidak bisa dipakai sebagai token akses
+const streamTicketKey = ":stream-ticket"
+
 type token struct {
 	secretKey string
 }
@@ -41,6 +47,14 @@ type refreshClaims struct {
 	jwt.StandardClaims
 }
 
+type streamClaims struct {
+	UserID     uint   `json:"user_id"`
+	AuthUUID   string `json:"auth_uuid"`
+	ScheduleID uint   `json:"schedule_id"`
+	Expired    int64  `json:"expired"`
+	jwt.StandardClaims
+}
+
 func (t *token) GenerateToken(data model.UserTokenPayload) (expiredDate int64, tokenData string) {
 	claims := &authClaims{
 		data.UserID,
@@ -127,3 +141,44 @@ func (t *token) ExtractTokenAuth(c *gin.Context) (model.Auth, error) {
 		return model.Auth{}, fmt.Errorf("token otorisasi tidak valid")
 	}
 }
+
+// GenerateStreamTicket tiket berumur pendek untuk membuka stream satu jadwal lewat query
+func (t *token) GenerateStreamTicket(data model.StreamTicketPayload) (expiredDate int64, tokenData string) {
+	claims := &streamClaims{
+		data.UserID,
+		data.AuthUUID,
+		data.ScheduleID,
+		data.Expired,
+		jwt.StandardClaims{},
+	}
+
+	ctx := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token, err := ctx.SignedString([]byte(t.secretKey + streamTicketKey))
+	if err != nil {
+		logrus.Panic(err)
+	}
+
+	return data.Expired, token
+}
+
+func (t *token) ValidateStreamTicket(ticket string) (model.StreamTicketPayload, error) {
+	var claims streamClaims
+	_, err := jwt.ParseWithClaims(ticket, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, valid := token.Method.(*jwt.SigningMethodHMAC); !valid {
+			return nil, fmt.Errorf("tiket tidak valid : %v", token.Header["alg"])
+		}
+		return []byte(t.secretKey + streamTicketKey), nil
+	})
+	if err != nil {
+		return model.StreamTicketPayload{}, fmt.Errorf("tiket tidak valid : %v", err)
+	}
+	if time.Now().After(time.Unix(claims.Expired, 0)) {
+		return model.StreamTicketPayload{}, fmt.Errorf("tiket sudah kedaluarsa")
+	}
+	return model.StreamTicketPayload{
+		UserID:     claims.UserID,
+		AuthUUID:   claims.AuthUUID,
+		ScheduleID: claims.ScheduleID,
+		Expired:    claims.Expired,
+	}, nil
+}
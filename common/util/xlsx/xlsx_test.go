@@ -0,0 +1,67 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	writer, err := NewWriter(&buffer, "Presensi & Rekap")
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	if err := writer.WriteRow([]string{"nim", "name"}); err != nil {
+		t.Fatalf("WriteRow() error = %v", err)
+	}
+	if err := writer.WriteRow([]string{"2021001", "Budi <Santoso> & \"Ani\""}); err != nil {
+		t.Fatalf("WriteRow() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := writer.WriteRow([]string{"x"}); err == nil {
+		t.Error("WriteRow() setelah Close() seharusnya error")
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	files := map[string]string{}
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("Open(%s) error = %v", file.Name, err)
+		}
+		content, _ := io.ReadAll(reader)
+		reader.Close()
+		files[file.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("bagian %s tidak ada", name)
+		}
+	}
+	if !strings.Contains(files["xl/workbook.xml"], `name="Presensi &amp; Rekap"`) {
+		t.Errorf("workbook = %s", files["xl/workbook.xml"])
+	}
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<row r="1"><c t="inlineStr"><is><t xml:space="preserve">nim</t></is></c>`,
+		`<row r="2">`,
+		`Budi &lt;Santoso&gt; &amp; &#34;Ani&#34;`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet tidak berisi %q: %s", want, sheet)
+		}
+	}
+	if !strings.HasSuffix(sheet, "</sheetData></worksheet>") {
+		t.Errorf("sheet tidak ditutup: %s", sheet)
+	}
+}
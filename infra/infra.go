@@ -1,6 +1,7 @@
 package infra
 
 import (
+	"attendance-api/infra/storage"
 	"crypto/aes"
 	"crypto/cipher"
 	"errors"
@@ -29,6 +30,7 @@ type Infra interface {
 	Migrate(values ...interface{})
 	Port() string
 	AMQP() *amqp.Connection
+	Storage() storage.Storage
 }
 
 type infra struct {
@@ -260,3 +262,38 @@ func (i *infra) AMQP() *amqp.Connection {
 
 	return amqpConn
 }
+
+var (
+	storageOnce sync.Once
+	fileStorage storage.Storage
+)
+
+// Storage backend penyimpanan file sesuai storage.driver, "local" (default) atau "s3"
+func (i *infra) Storage() storage.Storage {
+	storageOnce.Do(func() {
+		config := i.Config().Sub("storage")
+		if config == nil {
+			config = viper.New()
+		}
+
+		if config.GetString("driver") == "s3" {
+			fileStorage = storage.NewS3(
+				config.GetString("s3.endpoint"),
+				config.GetString("s3.region"),
+				config.GetString("s3.bucket"),
+				config.GetString("s3.access_key"),
+				config.GetString("s3.secret_key"),
+			)
+			return
+		}
+
+		dir := config.GetString("local_dir")
+		if dir == "" {
+			dir = "./storage"
+		}
+		downloadURL := fmt.Sprintf("%s/v1/attachment/file", i.Config().Sub("server").GetString("base_url"))
+		fileStorage = storage.NewLocal(dir, downloadURL, i.Config().Sub("secret").GetString("key"))
+	})
+
+	return fileStorage
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Local menyimpan file di filesystem, link unduhan diarahkan ke DownloadURL dan diverifikasi dengan Verify
+type Local struct {
+	Dir         string
+	DownloadURL string
+	Secret      string
+	Now         func() time.Time
+}
+
+func NewLocal(dir string, downloadURL string, secret string) *Local {
+	return &Local{Dir: dir, DownloadURL: downloadURL, Secret: secret, Now: time.Now}
+}
+
+func (l *Local) Put(key string, body io.Reader, size int64, contentType string) error {
+	filePath, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return err
+	}
+
+	// tulis ke file sementara dulu agar file lama tidak rusak jika proses gagal
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), filePath)
+}
+
+func (l *Local) Get(key string) (io.ReadCloser, error) {
+	filePath, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(filePath)
+}
+
+func (l *Local) Delete(key string) error {
+	filePath, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (l *Local) SignedURL(key string, expires time.Duration) (string, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	expiresAt := l.Now().Add(expires).Unix()
+
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("expires", strconv.FormatInt(expiresAt, 10))
+	query.Set("signature", l.sign(key, expiresAt))
+	return fmt.Sprintf("%s?%s", l.DownloadURL, query.Encode()), nil
+}
+
+// Verify memeriksa link dari SignedURL, dipakai oleh handler unduhan
+func (l *Local) Verify(key string, expires string, signature string) error {
+	key, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(l.sign(key, expiresAt))) {
+		return ErrSignature
+	}
+	if l.Now().Unix() > expiresAt {
+		return ErrExpired
+	}
+	return nil
+}
+
+func (l *Local) sign(key string, expiresAt int64) string {
+	mac := hmac.New(sha256.New, []byte(l.Secret))
+	mac.Write([]byte(fmt.Sprintf("%s\n%d", key, expiresAt)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (l *Local) path(key string) (string, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(l.Dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTooLarge       = errors.New("ukuran file melebihi batas")
+	ErrTypeNotAllowed = errors.New("tipe file tidak diizinkan")
+	ErrEmpty          = errors.New("file kosong")
+	ErrInvalidKey     = errors.New("key file tidak valid")
+	ErrExpired        = errors.New("link file sudah kedaluwarsa")
+	ErrSignature      = errors.New("signature link file tidak valid")
+)
+
+// Storage backend penyimpanan file, key selalu berupa path relatif dengan pemisah "/"
+type Storage interface {
+	Put(key string, body io.Reader, size int64, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+	SignedURL(key string, expires time.Duration) (string, error)
+}
+
+// Limit batas file yang boleh disimpan, AllowedTypes kosong berarti semua tipe
+type Limit struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+// Object hasil penyimpanan file
+type Object struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Hash        string `json:"hash"`
+}
+
+// Save membaca file sesuai batas ukuran, mendeteksi tipe dari isi file lalu menyimpan dengan key berbasis hash sha256,
+// sehingga file yang sama di prefix yang sama tidak tersimpan dua kali
+func Save(s Storage, prefix string, name string, body io.Reader, limit Limit) (Object, error) {
+	reader := body
+	if limit.MaxSize > 0 {
+		reader = io.LimitReader(body, limit.MaxSize+1)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return Object{}, err
+	}
+	if len(data) == 0 {
+		return Object{}, ErrEmpty
+	}
+	if limit.MaxSize > 0 && int64(len(data)) > limit.MaxSize {
+		return Object{}, ErrTooLarge
+	}
+
+	contentType := DetectContentType(data)
+	if !IsAllowedType(contentType, limit.AllowedTypes) {
+		return Object{}, fmt.Errorf("%w: %s", ErrTypeNotAllowed, contentType)
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	key := path.Join(strings.Trim(prefix, "/"), time.Now().Format("2006/01"), hash+Extension(contentType))
+	if err := s.Put(key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return Object{}, err
+	}
+
+	return Object{
+		Key:         key,
+		Name:        path.Base(name),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Hash:        hash,
+	}, nil
+}
+
+func DetectContentType(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return contentType
+}
+
+// IsAllowedType mendukung wildcard seperti "image/*"
+func IsAllowedType(contentType string, allowedTypes []string) bool {
+	if len(allowedTypes) == 0 {
+		return true
+	}
+	for _, allowed := range allowedTypes {
+		if allowed == contentType {
+			return true
+		}
+		if strings.HasSuffix(allowed, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// extensions ekstensi baku untuk tipe yang umum diunggah, ExtensionsByType mengurutkan secara alfabet (image/jpeg menjadi .jfif)
+var extensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+	"text/plain":      ".txt",
+}
+
+// Extension diambil dari tipe hasil deteksi isi file, bukan dari nama file unggahan,
+// agar file polyglot tidak tersimpan dengan ekstensi yang dilayani sebagai tipe lain (misal .html)
+func Extension(contentType string) string {
+	if ext, ok := extensions[contentType]; ok {
+		return ext
+	}
+	if exts, err := mime.ExtensionsByType(contentType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+// CleanKey menolak key absolut atau yang keluar dari root penyimpanan
+func CleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	cleaned := path.Clean(key)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
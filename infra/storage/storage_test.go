@@ -0,0 +1,262 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestSigningKey(t *testing.T) {
+	// contoh dari dokumentasi AWS Signature Version 4
+	key := SigningKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	want := "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d"
+	if got := hex.EncodeToString(key); got != want {
+		t.Errorf("SigningKey() = %s, want %s", got, want)
+	}
+}
+
+func TestCleanKey(t *testing.T) {
+	valid := []string{"a.png", "attendance/2024/01/abc.png", "a/./b.png"}
+	for _, key := range valid {
+		if _, err := CleanKey(key); err != nil {
+			t.Errorf("CleanKey(%q) error = %v", key, err)
+		}
+	}
+	invalid := []string{"", "/etc/passwd", "../secret", "a/../../b", "a\\b", ".."}
+	for _, key := range invalid {
+		if _, err := CleanKey(key); err == nil {
+			t.Errorf("CleanKey(%q) expected error", key)
+		}
+	}
+}
+
+func TestIsAllowedType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		allowed     []string
+		want        bool
+	}{
+		{"image/png", nil, true},
+		{"image/png", []string{"image/*"}, true},
+		{"image/png", []string{"application/pdf"}, false},
+		{"application/pdf", []string{"image/*", "application/pdf"}, true},
+		{"imagex/png", []string{"image/*"}, false},
+	}
+	for _, tt := range tests {
+		if got := IsAllowedType(tt.contentType, tt.allowed); got != tt.want {
+			t.Errorf("IsAllowedType(%q, %v) = %v, want %v", tt.contentType, tt.allowed, got, tt.want)
+		}
+	}
+}
+
+func TestLocal(t *testing.T) {
+	now := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	local := NewLocal(t.TempDir(), "http://localhost/v1/attachment/file", "secret")
+	local.Now = func() time.Time { return now }
+
+	object, err := Save(local, "attendance", "selfie.png", bytes.NewReader(pngHeader), Limit{MaxSize: 1024, AllowedTypes: []string{"image/*"}})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if object.ContentType != "image/png" || object.Size != int64(len(pngHeader)) || len(object.Hash) != 64 {
+		t.Errorf("Save() object = %+v", object)
+	}
+	if !strings.HasPrefix(object.Key, "attendance/") || !strings.HasSuffix(object.Key, object.Hash+".png") {
+		t.Errorf("Save() key = %s", object.Key)
+	}
+
+	reader, err := local.Get(object.Key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	if !bytes.Equal(data, pngHeader) {
+		t.Errorf("Get() content mismatch")
+	}
+
+	signedURL, err := local.SignedURL(object.Key, time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL() error = %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, signedURL, nil)
+	query := req.URL.Query()
+	if err := local.Verify(query.Get("key"), query.Get("expires"), query.Get("signature")); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+	if err := local.Verify("attendance/other.png", query.Get("expires"), query.Get("signature")); !errors.Is(err, ErrSignature) {
+		t.Errorf("Verify() tampered key error = %v, want %v", err, ErrSignature)
+	}
+	now = now.Add(2 * time.Minute)
+	if err := local.Verify(query.Get("key"), query.Get("expires"), query.Get("signature")); !errors.Is(err, ErrExpired) {
+		t.Errorf("Verify() expired error = %v, want %v", err, ErrExpired)
+	}
+
+	if err := local.Delete(object.Key); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if _, err := local.Get(object.Key); err == nil {
+		t.Errorf("Get() after Delete expected error")
+	}
+}
+
+func TestSaveLimit(t *testing.T) {
+	local := NewLocal(t.TempDir(), "http://localhost/file", "secret")
+
+	if _, err := Save(local, "x", "a.png", bytes.NewReader(pngHeader), Limit{MaxSize: 4}); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Save() error = %v, want %v", err, ErrTooLarge)
+	}
+	if _, err := Save(local, "x", "a.txt", strings.NewReader("hello"), Limit{AllowedTypes: []string{"image/*"}}); !errors.Is(err, ErrTypeNotAllowed) {
+		t.Errorf("Save() error = %v, want %v", err, ErrTypeNotAllowed)
+	}
+	if _, err := Save(local, "x", "a.txt", strings.NewReader(""), Limit{}); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Save() error = %v, want %v", err, ErrEmpty)
+	}
+}
+
+// fakeS3 pengganti MinIO untuk pengujian, memverifikasi signature header maupun presigned URL
+type fakeS3 struct {
+	t       *testing.T
+	backend *S3
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !f.verify(r) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("SignatureDoesNotMatch"))
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = data
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("NoSuchKey"))
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeS3) verify(r *http.Request) bool {
+	now := f.backend.Now().UTC()
+	scope := f.backend.scope(now)
+
+	query := r.URL.Query()
+	if signature := query.Get("X-Amz-Signature"); signature != "" {
+		query.Del("X-Amz-Signature")
+		canonicalRequest := strings.Join([]string{
+			r.Method,
+			r.URL.EscapedPath(),
+			canonicalQuery(query),
+			"host:" + r.Host + "\n",
+			"host",
+			s3UnsignedPayload,
+		}, "\n")
+		return signature == f.backend.signature(now, query.Get("X-Amz-Date"), scope, canonicalRequest)
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		canonicalQuery(query),
+		"host:" + r.Host + "\nx-amz-content-sha256:" + r.Header.Get("X-Amz-Content-Sha256") + "\nx-amz-date:" + amzDate + "\n",
+		"host;x-amz-content-sha256;x-amz-date",
+		s3UnsignedPayload,
+	}, "\n")
+	return strings.HasSuffix(r.Header.Get("Authorization"), "Signature="+f.backend.signature(now, amzDate, scope, canonicalRequest))
+}
+
+func TestS3(t *testing.T) {
+	now := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	fake := &fakeS3{t: t, objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	s3 := NewS3(server.URL, "", "attendance", "minio", "minio123")
+	s3.Now = func() time.Time { return now }
+	fake.backend = s3
+
+	object, err := Save(s3, "medical certificate", "surat dokter.png", bytes.NewReader(pngHeader), Limit{MaxSize: 1024})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reader, err := s3.Get(object.Key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	if !bytes.Equal(data, pngHeader) {
+		t.Errorf("Get() content mismatch")
+	}
+
+	signedURL, err := s3.SignedURL(object.Key, time.Hour)
+	if err != nil {
+		t.Fatalf("SignedURL() error = %v", err)
+	}
+	res, err := http.Get(signedURL)
+	if err != nil {
+		t.Fatalf("GET signed url error = %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("GET signed url status = %d", res.StatusCode)
+	}
+
+	res, err = http.Get(strings.Replace(signedURL, "X-Amz-Expires=3600", "X-Amz-Expires=7200", 1))
+	if err != nil {
+		t.Fatalf("GET tampered url error = %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusForbidden {
+		t.Errorf("GET tampered url status = %d, want %d", res.StatusCode, http.StatusForbidden)
+	}
+
+	if err := s3.Delete(object.Key); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if _, err := s3.Get(object.Key); err == nil {
+		t.Errorf("Get() after Delete expected error")
+	}
+}
+
+func TestSaveExtensionFromContent(t *testing.T) {
+	local := NewLocal(t.TempDir(), "http://localhost/v1/attachment/file", "secret")
+
+	// GIF yang berisi HTML diunggah dengan nama .html tetap disimpan sebagai .gif
+	polyglot := []byte("GIF89a<html><script>alert(1)</script></html>")
+	object, err := Save(local, "attendance", "x.html", bytes.NewReader(polyglot), Limit{AllowedTypes: []string{"image/*"}})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if object.ContentType != "image/gif" || !strings.HasSuffix(object.Key, ".gif") {
+		t.Errorf("Save() object = %+v", object)
+	}
+
+	if got := Extension("image/jpeg"); got != ".jpg" {
+		t.Errorf("Extension(image/jpeg) = %q, want .jpg", got)
+	}
+}
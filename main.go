@@ -4,6 +4,7 @@ import (
 	"attendance-api/api"
 	_ "attendance-api/docs"
 	"attendance-api/infra"
+	"attendance-api/manager"
 	"attendance-api/model"
 	"attendance-api/scheduler/consumer"
 	"attendance-api/scheduler/publisher"
@@ -51,6 +52,8 @@ func handleCommand() {
 			i.SetMode()
 			i.Migrate(
 				&model.Faculty{},
+				&model.AcademicCalendar{},
+				&model.Room{},
 				&model.Major{},
 				&model.StudyProgram{},
 				&model.User{},
@@ -62,11 +65,44 @@ func handleCommand() {
 				&model.Subject{},
 				&model.Schedule{},
 				&model.DailySchedule{},
+				&model.ScheduleException{},
+				&model.Session{},
+				&model.LectureJournal{},
+				&model.LeaveRequest{},
+				&model.LeaveRequestItem{},
+				&model.LeaveRequestLog{},
+				&model.Attachment{},
+				&model.GeofenceZone{},
 				&model.UserSchedule{},
 				&model.Attendance{},
 				&model.AttendanceLog{},
+				&model.AttendanceCorrection{},
+				&model.AttendancePolicy{},
+				&model.AttendancePolicyRule{},
+				&model.AttendanceGradeFormula{},
+				&model.AttendanceAlert{},
+				&model.Guardian{},
+				&model.Notification{},
+				&model.NotificationPreference{},
+				&model.Webhook{},
+				&model.WebhookDelivery{},
 				&model.RoleAbility{},
 			)
+			totalRoom, err := manager.NewRepoManager(i).RoomRepo().MigrateRoomFromSchedule()
+			if err != nil {
+				log.Printf("[Error Migrate Room] E: %v\n", err)
+			}
+			log.Printf("Berhasil membuat %d ruangan dari koordinat jadwal\n", totalRoom)
+			totalAttendance, err := manager.NewRepoManager(i).AttendanceRepo().MigrateAttendanceDailySchedule()
+			if err != nil {
+				log.Printf("[Error Migrate Attendance] E: %v\n", err)
+			}
+			log.Printf("Berhasil menautkan %d presensi ke jadwal harian\n", totalAttendance)
+			totalScore, err := manager.NewRepoManager(i).AttendanceRepo().MigrateAttendancePresenceScore()
+			if err != nil {
+				log.Printf("[Error Migrate Attendance] E: %v\n", err)
+			}
+			log.Printf("Berhasil mengisi bobot kehadiran %d presensi\n", totalScore)
 			log.Printf("Berhasil Melakukan Migrasi Database!\n")
 			os.Exit(0)
 		case "help":
@@ -25,6 +25,21 @@ type RepoManager interface {
 	StudyProgramRepo() repo.StudyProgramRepo
 	DashboardRepo() repo.DashboardRepo
 	RoleAbilityRepo() repo.RoleAbilityRepo
+	GeofenceZoneRepo() repo.GeofenceZoneRepo
+	RoomRepo() repo.RoomRepo
+	AcademicCalendarRepo() repo.AcademicCalendarRepo
+	ScheduleExceptionRepo() repo.ScheduleExceptionRepo
+	SessionRepo() repo.SessionRepo
+	LectureJournalRepo() repo.LectureJournalRepo
+	LeaveRequestRepo() repo.LeaveRequestRepo
+	AttachmentRepo() repo.AttachmentRepo
+	AttendanceCorrectionRepo() repo.AttendanceCorrectionRepo
+	AttendancePolicyRepo() repo.AttendancePolicyRepo
+	AttendanceGradeRepo() repo.AttendanceGradeRepo
+	AttendanceAlertRepo() repo.AttendanceAlertRepo
+	GuardianRepo() repo.GuardianRepo
+	NotificationRepo() repo.NotificationRepo
+	WebhookRepo() repo.WebhookRepo
 }
 
 type repoManager struct {
@@ -36,40 +51,70 @@ func NewRepoManager(infra infra.Infra) RepoManager {
 }
 
 var (
-	facultyRepoOnce            sync.Once
-	majorRepoOnce              sync.Once
-	studyProgramRepoOnce       sync.Once
-	authRepoOnce               sync.Once
-	userRepoOnce               sync.Once
-	studentRepoOnce            sync.Once
-	teacherRepoOnce            sync.Once
-	passwordResetTokenRepoOnce sync.Once
-	activationTokenRepoOnce    sync.Once
-	subjectRepoOnce            sync.Once
-	dailyScheduleRepoOnce      sync.Once
-	scheduleRepoOnce           sync.Once
-	userScheduleRepoOnce       sync.Once
-	attendanceLogRepoOnce      sync.Once
-	attendanceRepoOnce         sync.Once
-	dashboardRepoOnce          sync.Once
-	roleAbilityRepoOnce        sync.Once
-	facultyRepo                repo.FacultyRepo
-	majorRepo                  repo.MajorRepo
-	studyProgramRepo           repo.StudyProgramRepo
-	authRepo                   repo.AuthRepo
-	userRepo                   repo.UserRepo
-	studentRepo                repo.StudentRepo
-	teacherRepo                repo.TeacherRepo
-	passwordResetTokenRepo     repo.PasswordResetTokenRepo
-	activationTokenRepo        repo.ActivationTokenRepo
-	subjectRepo                repo.SubjectRepo
-	dailyScheduleRepo          repo.DailyScheduleRepo
-	scheduleRepo               repo.ScheduleRepo
-	userScheduleRepo           repo.UserScheduleRepo
-	attendanceLogRepo          repo.AttendanceLogRepo
-	attendanceRepo             repo.AttendanceRepo
-	dashboardRepo              repo.DashboardRepo
-	roleAbilityRepo            repo.RoleAbilityRepo
+	facultyRepoOnce              sync.Once
+	majorRepoOnce                sync.Once
+	studyProgramRepoOnce         sync.Once
+	authRepoOnce                 sync.Once
+	userRepoOnce                 sync.Once
+	studentRepoOnce              sync.Once
+	teacherRepoOnce              sync.Once
+	passwordResetTokenRepoOnce   sync.Once
+	activationTokenRepoOnce      sync.Once
+	subjectRepoOnce              sync.Once
+	dailyScheduleRepoOnce        sync.Once
+	scheduleRepoOnce             sync.Once
+	userScheduleRepoOnce         sync.Once
+	attendanceLogRepoOnce        sync.Once
+	attendanceRepoOnce           sync.Once
+	dashboardRepoOnce            sync.Once
+	roleAbilityRepoOnce          sync.Once
+	geofenceZoneRepoOnce         sync.Once
+	roomRepoOnce                 sync.Once
+	academicCalendarRepoOnce     sync.Once
+	scheduleExceptionRepoOnce    sync.Once
+	sessionRepoOnce              sync.Once
+	lectureJournalRepoOnce       sync.Once
+	leaveRequestRepoOnce         sync.Once
+	attachmentRepoOnce           sync.Once
+	attendanceCorrectionRepoOnce sync.Once
+	attendancePolicyRepoOnce     sync.Once
+	attendanceGradeRepoOnce      sync.Once
+	attendanceAlertRepoOnce      sync.Once
+	guardianRepoOnce             sync.Once
+	notificationRepoOnce         sync.Once
+	webhookRepoOnce              sync.Once
+	facultyRepo                  repo.FacultyRepo
+	majorRepo                    repo.MajorRepo
+	studyProgramRepo             repo.StudyProgramRepo
+	authRepo                     repo.AuthRepo
+	userRepo                     repo.UserRepo
+	studentRepo                  repo.StudentRepo
+	teacherRepo                  repo.TeacherRepo
+	passwordResetTokenRepo       repo.PasswordResetTokenRepo
+	activationTokenRepo          repo.ActivationTokenRepo
+	subjectRepo                  repo.SubjectRepo
+	dailyScheduleRepo            repo.DailyScheduleRepo
+	scheduleRepo                 repo.ScheduleRepo
+	userScheduleRepo             repo.UserScheduleRepo
+	attendanceLogRepo            repo.AttendanceLogRepo
+	attendanceRepo               repo.AttendanceRepo
+	dashboardRepo                repo.DashboardRepo
+	roleAbilityRepo              repo.RoleAbilityRepo
+	geofenceZoneRepo             repo.GeofenceZoneRepo
+	roomRepo                     repo.RoomRepo
+	academicCalendarRepo         repo.AcademicCalendarRepo
+	scheduleExceptionRepo        repo.ScheduleExceptionRepo
+	sessionRepo                  repo.SessionRepo
+	lectureJournalRepo           repo.LectureJournalRepo
+	leaveRequestRepo             repo.LeaveRequestRepo
+	attachmentRepo               repo.AttachmentRepo
+	attendanceCorrectionRepo     repo.AttendanceCorrectionRepo
+	attendancePolicyRepo         repo.AttendancePolicyRepo
+	attendanceGradeRepo          repo.AttendanceGradeRepo
+	attendanceAlertRepo          repo.AttendanceAlertRepo
+	guardianRepo                 repo.GuardianRepo
+	notificationRepo             repo.NotificationRepo
+	webhookRepo                  repo.WebhookRepo
 )
 
 func (rm *repoManager) FacultyRepo() repo.FacultyRepo {
@@ -190,3 +235,108 @@ func (rm *repoManager) RoleAbilityRepo() repo.RoleAbilityRepo {
 	})
 	return roleAbilityRepo
 }
+
+func (rm *repoManager) GeofenceZoneRepo() repo.GeofenceZoneRepo {
+	geofenceZoneRepoOnce.Do(func() {
+		geofenceZoneRepo = repo.NewGeofenceZoneRepo(rm.infra.GormDB())
+	})
+	return geofenceZoneRepo
+}
+
+func (rm *repoManager) RoomRepo() repo.RoomRepo {
+	roomRepoOnce.Do(func() {
+		roomRepo = repo.NewRoomRepo(rm.infra.GormDB())
+	})
+	return roomRepo
+}
+
+func (rm *repoManager) AcademicCalendarRepo() repo.AcademicCalendarRepo {
+	academicCalendarRepoOnce.Do(func() {
+		academicCalendarRepo = repo.NewAcademicCalendarRepo(rm.infra.GormDB())
+	})
+	return academicCalendarRepo
+}
+
+func (rm *repoManager) ScheduleExceptionRepo() repo.ScheduleExceptionRepo {
+	scheduleExceptionRepoOnce.Do(func() {
+		scheduleExceptionRepo = repo.NewScheduleExceptionRepo(rm.infra.GormDB())
+	})
+	return scheduleExceptionRepo
+}
+
+func (rm *repoManager) SessionRepo() repo.SessionRepo {
+	sessionRepoOnce.Do(func() {
+		sessionRepo = repo.NewSessionRepo(rm.infra.GormDB())
+	})
+	return sessionRepo
+}
+
+func (rm *repoManager) LectureJournalRepo() repo.LectureJournalRepo {
+	lectureJournalRepoOnce.Do(func() {
+		lectureJournalRepo = repo.NewLectureJournalRepo(rm.infra.GormDB())
+	})
+	return lectureJournalRepo
+}
+
+func (rm *repoManager) LeaveRequestRepo() repo.LeaveRequestRepo {
+	leaveRequestRepoOnce.Do(func() {
+		leaveRequestRepo = repo.NewLeaveRequestRepo(rm.infra.GormDB())
+	})
+	return leaveRequestRepo
+}
+
+func (rm *repoManager) AttachmentRepo() repo.AttachmentRepo {
+	attachmentRepoOnce.Do(func() {
+		attachmentRepo = repo.NewAttachmentRepo(rm.infra.GormDB())
+	})
+	return attachmentRepo
+}
+
+func (rm *repoManager) AttendanceCorrectionRepo() repo.AttendanceCorrectionRepo {
+	attendanceCorrectionRepoOnce.Do(func() {
+		attendanceCorrectionRepo = repo.NewAttendanceCorrectionRepo(rm.infra.GormDB())
+	})
+	return attendanceCorrectionRepo
+}
+
+func (rm *repoManager) AttendancePolicyRepo() repo.AttendancePolicyRepo {
+	attendancePolicyRepoOnce.Do(func() {
+		attendancePolicyRepo = repo.NewAttendancePolicyRepo(rm.infra.GormDB())
+	})
+	return attendancePolicyRepo
+}
+
+func (rm *repoManager) AttendanceGradeRepo() repo.AttendanceGradeRepo {
+	attendanceGradeRepoOnce.Do(func() {
+		attendanceGradeRepo = repo.NewAttendanceGradeRepo(rm.infra.GormDB())
+	})
+	return attendanceGradeRepo
+}
+
+func (rm *repoManager) AttendanceAlertRepo() repo.AttendanceAlertRepo {
+	attendanceAlertRepoOnce.Do(func() {
+		attendanceAlertRepo = repo.NewAttendanceAlertRepo(rm.infra.GormDB())
+	})
+	return attendanceAlertRepo
+}
+
+func (rm *repoManager) GuardianRepo() repo.GuardianRepo {
+	guardianRepoOnce.Do(func() {
+		guardianRepo = repo.NewGuardianRepo(rm.infra.GormDB())
+	})
+	return guardianRepo
+}
+
+func (rm *repoManager) NotificationRepo() repo.NotificationRepo {
+	notificationRepoOnce.Do(func() {
+		notificationRepo = repo.NewNotificationRepo(rm.infra.GormDB())
+	})
+	return notificationRepo
+}
+
+func (rm *repoManager) WebhookRepo() repo.WebhookRepo {
+	webhookRepoOnce.Do(func() {
+		webhookRepo = repo.NewWebhookRepo(rm.infra.GormDB())
+	})
+	return webhookRepo
+}
@@ -3,6 +3,9 @@ package manager
 import (
 	"sync"
 
+	"attendance-api/common/http/email"
+	"attendance-api/common/http/notification"
+	"attendance-api/common/http/webhook"
 	"attendance-api/infra"
 	"attendance-api/service"
 )
@@ -25,6 +28,24 @@ type ServiceManager interface {
 	AttendanceService() service.AttendanceService
 	DashboardService() service.DashboardService
 	RoleAbilityService() service.RoleAbilityService
+	GeofenceZoneService() service.GeofenceZoneService
+	RoomService() service.RoomService
+	AcademicCalendarService() service.AcademicCalendarService
+	ScheduleExceptionService() service.ScheduleExceptionService
+	SessionService() service.SessionService
+	LectureJournalService() service.LectureJournalService
+	LeaveRequestService() service.LeaveRequestService
+	AttachmentService() service.AttachmentService
+	AttendanceCorrectionService() service.AttendanceCorrectionService
+	AttendancePolicyService() service.AttendancePolicyService
+	AttendanceGradeService() service.AttendanceGradeService
+	AttendanceAlertService() service.AttendanceAlertService
+	GuardianService() service.GuardianService
+	NotificationService() service.NotificationService
+	Notifier() notification.Notifier
+	WebhookService() service.WebhookService
+	WebhookQueue() webhook.Queue
+	WebhookDispatcher() webhook.Dispatcher
 }
 
 type serviceManager struct {
@@ -40,40 +61,76 @@ func NewServiceManager(infra infra.Infra) ServiceManager {
 }
 
 var (
-	facultyServiceOnce            sync.Once
-	majorServiceOnce              sync.Once
-	studyProgramServiceOnce       sync.Once
-	authServiceOnce               sync.Once
-	userServiceOnce               sync.Once
-	studentServiceOnce            sync.Once
-	teacherServiceOnce            sync.Once
-	passwordResetTokenServiceOnce sync.Once
-	activationTokenServiceOnce    sync.Once
-	subjectServiceOnce            sync.Once
-	dailyScheduleServiceOnce      sync.Once
-	scheduleServiceOnce           sync.Once
-	userScheduleServiceOnce       sync.Once
-	attendanceLogServiceOnce      sync.Once
-	attendanceServiceOnce         sync.Once
-	dashboardServiceOnce          sync.Once
-	roleAbilityServiceOnce        sync.Once
-	facultyService                service.FacultyService
-	majorService                  service.MajorService
-	studyProgramService           service.StudyProgramService
-	authService                   service.AuthService
-	userService                   service.UserService
-	studentService                service.StudentService
-	teacherService                service.TeacherService
-	passwordResetTokenService     service.PasswordResetTokenService
-	activationTokenService        service.ActivationTokenService
-	subjectService                service.SubjectService
-	dailyScheduleService          service.DailyScheduleService
-	scheduleService               service.ScheduleService
-	userScheduleService           service.UserScheduleService
-	attendanceLogService          service.AttendanceLogService
-	attendanceService             service.AttendanceService
-	dashboardService              service.DashboardService
-	roleAbilityService            service.RoleAbilityService
+	facultyServiceOnce              sync.Once
+	majorServiceOnce                sync.Once
+	studyProgramServiceOnce         sync.Once
+	authServiceOnce                 sync.Once
+	userServiceOnce                 sync.Once
+	studentServiceOnce              sync.Once
+	teacherServiceOnce              sync.Once
+	passwordResetTokenServiceOnce   sync.Once
+	activationTokenServiceOnce      sync.Once
+	subjectServiceOnce              sync.Once
+	dailyScheduleServiceOnce        sync.Once
+	scheduleServiceOnce             sync.Once
+	userScheduleServiceOnce         sync.Once
+	attendanceLogServiceOnce        sync.Once
+	attendanceServiceOnce           sync.Once
+	dashboardServiceOnce            sync.Once
+	roleAbilityServiceOnce          sync.Once
+	geofenceZoneServiceOnce         sync.Once
+	roomServiceOnce                 sync.Once
+	academicCalendarServiceOnce     sync.Once
+	scheduleExceptionServiceOnce    sync.Once
+	sessionServiceOnce              sync.Once
+	lectureJournalServiceOnce       sync.Once
+	leaveRequestServiceOnce         sync.Once
+	attachmentServiceOnce           sync.Once
+	attendanceCorrectionServiceOnce sync.Once
+	attendancePolicyServiceOnce     sync.Once
+	attendanceGradeServiceOnce      sync.Once
+	attendanceAlertServiceOnce      sync.Once
+	guardianServiceOnce             sync.Once
+	notificationServiceOnce         sync.Once
+	notifierOnce                    sync.Once
+	webhookServiceOnce              sync.Once
+	webhookQueueOnce                sync.Once
+	webhookDispatcherOnce           sync.Once
+	facultyService                  service.FacultyService
+	majorService                    service.MajorService
+	studyProgramService             service.StudyProgramService
+	authService                     service.AuthService
+	userService                     service.UserService
+	studentService                  service.StudentService
+	teacherService                  service.TeacherService
+	passwordResetTokenService       service.PasswordResetTokenService
+	activationTokenService          service.ActivationTokenService
+	subjectService                  service.SubjectService
+	dailyScheduleService            service.DailyScheduleService
+	scheduleService                 service.ScheduleService
+	userScheduleService             service.UserScheduleService
+	attendanceLogService            service.AttendanceLogService
+	attendanceService               service.AttendanceService
+	dashboardService                service.DashboardService
+	roleAbilityService              service.RoleAbilityService
+	geofenceZoneService             service.GeofenceZoneService
+	roomService                     service.RoomService
+	academicCalendarService         service.AcademicCalendarService
+	scheduleExceptionService        service.ScheduleExceptionService
+	sessionService                  service.SessionService
+	lectureJournalService           service.LectureJournalService
+	leaveRequestService             service.LeaveRequestService
+	attachmentService               service.AttachmentService
+	attendanceCorrectionService     service.AttendanceCorrectionService
+	attendancePolicyService         service.AttendancePolicyService
+	attendanceGradeService          service.AttendanceGradeService
+	attendanceAlertService          service.AttendanceAlertService
+	guardianService                 service.GuardianService
+	notificationService             service.NotificationService
+	notifier                        notification.Notifier
+	webhookService                  service.WebhookService
+	webhookQueue                    webhook.Queue
+	webhookDispatcher               webhook.Dispatcher
 )
 
 func (sm *serviceManager) FacultyService() service.FacultyService {
@@ -198,3 +255,129 @@ func (sm *serviceManager) RoleAbilityService() service.RoleAbilityService {
 	})
 	return roleAbilityService
 }
+
+func (sm *serviceManager) GeofenceZoneService() service.GeofenceZoneService {
+	geofenceZoneServiceOnce.Do(func() {
+		geofenceZoneService = sm.repo.GeofenceZoneRepo()
+	})
+	return geofenceZoneService
+}
+
+func (sm *serviceManager) RoomService() service.RoomService {
+	roomServiceOnce.Do(func() {
+		roomService = sm.repo.RoomRepo()
+	})
+	return roomService
+}
+
+func (sm *serviceManager) AcademicCalendarService() service.AcademicCalendarService {
+	academicCalendarServiceOnce.Do(func() {
+		academicCalendarService = sm.repo.AcademicCalendarRepo()
+	})
+	return academicCalendarService
+}
+
+func (sm *serviceManager) ScheduleExceptionService() service.ScheduleExceptionService {
+	scheduleExceptionServiceOnce.Do(func() {
+		scheduleExceptionService = sm.repo.ScheduleExceptionRepo()
+	})
+	return scheduleExceptionService
+}
+
+func (sm *serviceManager) SessionService() service.SessionService {
+	sessionServiceOnce.Do(func() {
+		sessionService = sm.repo.SessionRepo()
+	})
+	return sessionService
+}
+
+func (sm *serviceManager) LectureJournalService() service.LectureJournalService {
+	lectureJournalServiceOnce.Do(func() {
+		lectureJournalService = sm.repo.LectureJournalRepo()
+	})
+	return lectureJournalService
+}
+
+func (sm *serviceManager) LeaveRequestService() service.LeaveRequestService {
+	leaveRequestServiceOnce.Do(func() {
+		leaveRequestService = sm.repo.LeaveRequestRepo()
+	})
+	return leaveRequestService
+}
+
+func (sm *serviceManager) AttachmentService() service.AttachmentService {
+	attachmentServiceOnce.Do(func() {
+		attachmentService = sm.repo.AttachmentRepo()
+	})
+	return attachmentService
+}
+
+func (sm *serviceManager) AttendanceCorrectionService() service.AttendanceCorrectionService {
+	attendanceCorrectionServiceOnce.Do(func() {
+		attendanceCorrectionService = sm.repo.AttendanceCorrectionRepo()
+	})
+	return attendanceCorrectionService
+}
+
+func (sm *serviceManager) AttendancePolicyService() service.AttendancePolicyService {
+	attendancePolicyServiceOnce.Do(func() {
+		attendancePolicyService = sm.repo.AttendancePolicyRepo()
+	})
+	return attendancePolicyService
+}
+
+func (sm *serviceManager) AttendanceGradeService() service.AttendanceGradeService {
+	attendanceGradeServiceOnce.Do(func() {
+		attendanceGradeService = sm.repo.AttendanceGradeRepo()
+	})
+	return attendanceGradeService
+}
+
+func (sm *serviceManager) AttendanceAlertService() service.AttendanceAlertService {
+	attendanceAlertServiceOnce.Do(func() {
+		attendanceAlertService = sm.repo.AttendanceAlertRepo()
+	})
+	return attendanceAlertService
+}
+
+func (sm *serviceManager) GuardianService() service.GuardianService {
+	guardianServiceOnce.Do(func() {
+		guardianService = sm.repo.GuardianRepo()
+	})
+	return guardianService
+}
+
+func (sm *serviceManager) NotificationService() service.NotificationService {
+	notificationServiceOnce.Do(func() {
+		notificationService = sm.repo.NotificationRepo()
+	})
+	return notificationService
+}
+
+func (sm *serviceManager) Notifier() notification.Notifier {
+	notifierOnce.Do(func() {
+		notifier = notification.NewFromConfig(sm.infra.Config().Sub("notification"), email.New(sm.infra.GoMail(), sm.infra.Config()), sm.NotificationService())
+	})
+	return notifier
+}
+
+func (sm *serviceManager) WebhookService() service.WebhookService {
+	webhookServiceOnce.Do(func() {
+		webhookService = sm.repo.WebhookRepo()
+	})
+	return webhookService
+}
+
+func (sm *serviceManager) WebhookQueue() webhook.Queue {
+	webhookQueueOnce.Do(func() {
+		webhookQueue = webhook.NewAMQPQueue(sm.infra.AMQP, sm.infra.Config().Sub("amqp").GetString("queue_name"))
+	})
+	return webhookQueue
+}
+
+func (sm *serviceManager) WebhookDispatcher() webhook.Dispatcher {
+	webhookDispatcherOnce.Do(func() {
+		webhookDispatcher = webhook.New(sm.WebhookService(), sm.WebhookQueue())
+	})
+	return webhookDispatcher
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"attendance-api/common/util/converter"
+	"time"
+)
+
+const (
+	CalendarHoliday = "holiday"
+	CalendarExam    = "exam"
+	CalendarBreak   = "break"
+)
+
+// AcademicCalendar hari tidak ada perkuliahan, FacultyID 0 berarti berlaku untuk seluruh kampus
+type AcademicCalendar struct {
+	GormCustom
+	Name      string `json:"name" gorm:"type:varchar(255)" query:"name" form:"name"`
+	Type      string `json:"type" gorm:"type:enum('holiday','exam','break');default:'holiday'" query:"type" form:"type"`
+	StartDate string `json:"start_date" gorm:"type:date;index" query:"start_date" form:"start_date"`
+	EndDate   string `json:"end_date" gorm:"type:date;index" query:"end_date" form:"end_date"`
+	FacultyID uint   `json:"faculty_id" gorm:"index" query:"faculty_id" form:"faculty_id"`
+	UID       string `json:"uid" gorm:"type:varchar(255);index" query:"uid" form:"uid"`
+	Summary   string `json:"summary" gorm:"type:text" query:"summary" form:"summary"`
+	OwnerID   int    `json:"owner_id" gorm:"not null" query:"owner_id" form:"owner_id"`
+}
+
+type AcademicCalendarImport struct {
+	Total   int `json:"total"`
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+}
+
+func (data AcademicCalendar) IsValidType() bool {
+	switch data.Type {
+	case CalendarHoliday, CalendarExam, CalendarBreak:
+		return true
+	}
+	return false
+}
+
+// IsValidRange memastikan tanggal mulai dan selesai valid dan tidak terbalik
+func (data AcademicCalendar) IsValidRange() bool {
+	startDate, err := time.Parse("2006-01-02", converter.GetOnlyDateString(data.StartDate))
+	if err != nil {
+		return false
+	}
+	endDate, err := time.Parse("2006-01-02", converter.GetOnlyDateString(data.EndDate))
+	if err != nil {
+		return false
+	}
+	return !endDate.Before(startDate)
+}
+
+// IsNonTeachingDate mengecek apakah tanggal (2006-01-02) jatuh pada salah satu kalender
+func IsNonTeachingDate(calendars []AcademicCalendar, date string) bool {
+	for _, calendar := range calendars {
+		if date >= converter.GetOnlyDateString(calendar.StartDate) && date <= converter.GetOnlyDateString(calendar.EndDate) {
+			return true
+		}
+	}
+	return false
+}
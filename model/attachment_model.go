@@ -0,0 +1,30 @@
+package model
+
+const (
+	AttachmentAttendance   = "attendance"
+	AttachmentUser         = "user"
+	AttachmentLeaveRequest = "leave_request"
+	AttachmentCorrection   = "attendance_correction"
+)
+
+// Attachment file yang tersimpan di storage dan ditautkan ke satu data (record_type + record_id)
+type Attachment struct {
+	GormCustom
+	RecordType  string `json:"record_type" gorm:"type:varchar(50);index:idx_attachment_record" query:"record_type" form:"record_type"`
+	RecordID    uint   `json:"record_id" gorm:"index:idx_attachment_record" query:"record_id" form:"record_id"`
+	Key         string `json:"key" gorm:"type:varchar(255);index" query:"key" form:"key"`
+	Name        string `json:"name" gorm:"type:varchar(255)" query:"name" form:"name"`
+	ContentType string `json:"content_type" gorm:"type:varchar(100)" query:"content_type" form:"content_type"`
+	Size        int64  `json:"size" query:"size" form:"size"`
+	Hash        string `json:"hash" gorm:"type:varchar(64)" query:"hash" form:"hash"`
+	OwnerID     int    `json:"owner_id" gorm:"not null" query:"owner_id" form:"owner_id"`
+	URL         string `json:"url" gorm:"-" query:"url" form:"url"`
+}
+
+func (data Attachment) IsValidRecordType() bool {
+	switch data.RecordType {
+	case AttachmentAttendance, AttachmentUser, AttachmentLeaveRequest, AttachmentCorrection:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package model
+
+import "github.com/spf13/viper"
+
+const (
+	AlertLevelWarning  = "warning"
+	AlertLevelCritical = "critical"
+)
+
+// AlertLevels urutan level peringatan dari yang paling ringan
+var AlertLevels = []string{AlertLevelWarning, AlertLevelCritical}
+
+// AttendanceAlert riwayat peringatan kehadiran mahasiswa per jadwal. Peringatan aktif (IsRecovered false)
+// mencegah notifikasi berulang, peringatan ditutup saat kehadiran kembali di atas ambang
+type AttendanceAlert struct {
+	GormCustom
+	UserID          int      `json:"user_id" gorm:"index" query:"user_id" form:"user_id"`
+	User            User     `json:"user" gorm:"foreignKey:UserID" query:"user" form:"user"`
+	ScheduleID      uint     `json:"schedule_id" gorm:"index" query:"schedule_id" form:"schedule_id"`
+	Schedule        Schedule `json:"schedule" gorm:"foreignKey:ScheduleID" query:"schedule" form:"schedule"`
+	Level           string   `json:"level" gorm:"type:enum('warning','critical');default:'warning'" query:"level" form:"level"`
+	Rate            float64  `json:"rate" query:"rate" form:"rate"`                // persentase kehadiran saat ambang terlewati
+	Threshold       int      `json:"threshold" query:"threshold" form:"threshold"` // ambang persentase kehadiran
+	HeldMeeting     int      `json:"held_meeting" query:"held_meeting" form:"held_meeting"`
+	Attended        float64  `json:"attended" query:"attended" form:"attended"`
+	NotifiedStudent bool     `json:"notified_student" query:"notified_student" form:"notified_student"`
+	NotifiedOwner   bool     `json:"notified_owner" query:"notified_owner" form:"notified_owner"`
+	IsRecovered     bool     `json:"is_recovered" gorm:"default:false" query:"is_recovered" form:"is_recovered"`
+	OwnerID         int      `json:"owner_id" gorm:"index" query:"owner_id" form:"owner_id"`
+}
+
+// AttendanceAlertRule ambang peringatan dalam persen, peringatan baru dikirim setelah MinHeldMeeting pertemuan
+type AttendanceAlertRule struct {
+	Warning        int `json:"warning"`
+	Critical       int `json:"critical"`
+	MinHeldMeeting int `json:"min_held_meeting"`
+}
+
+func DefaultAttendanceAlertRule() AttendanceAlertRule {
+	return AttendanceAlertRule{
+		Warning:        80,
+		Critical:       75,
+		MinHeldMeeting: 3,
+	}
+}
+
+// NewAttendanceAlertRule ambang peringatan dari config, key yang kosong memakai ambang bawaan
+func NewAttendanceAlertRule(config *viper.Viper) AttendanceAlertRule {
+	rule := DefaultAttendanceAlertRule()
+	if config == nil {
+		return rule
+	}
+	if config.IsSet("warning") {
+		rule.Warning = config.GetInt("warning")
+	}
+	if config.IsSet("critical") {
+		rule.Critical = config.GetInt("critical")
+	}
+	if config.IsSet("min_held_meeting") {
+		rule.MinHeldMeeting = config.GetInt("min_held_meeting")
+	}
+	return rule
+}
+
+// Threshold ambang level peringatan
+func (rule AttendanceAlertRule) Threshold(level string) int {
+	if level == AlertLevelCritical {
+		return rule.Critical
+	}
+	return rule.Warning
+}
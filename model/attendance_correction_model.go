@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+const (
+	CorrectionPending   = "pending"
+	CorrectionApproved  = "approved"
+	CorrectionRejected  = "rejected"
+	CorrectionCancelled = "cancelled"
+)
+
+// AttendanceCorrection pengajuan koreksi presensi oleh mahasiswa (misal GPS gagal atau QR tidak terbaca),
+// diperiksa dosen pemilik jadwal. Nilai presensi sebelum koreksi disimpan pada kolom original_*
+type AttendanceCorrection struct {
+	GormCustom
+	AttendanceID           uint       `json:"attendance_id" gorm:"index;not null" query:"attendance_id" form:"attendance_id"`
+	Attendance             Attendance `json:"attendance" gorm:"foreignKey:AttendanceID" query:"attendance" form:"attendance"`
+	UserID                 int        `json:"user_id" gorm:"index;not null" query:"user_id" form:"user_id"`
+	User                   User       `json:"user" gorm:"foreignKey:UserID" query:"user" form:"user"`
+	ScheduleID             uint       `json:"schedule_id" gorm:"index;not null" query:"schedule_id" form:"schedule_id"`
+	OwnerID                int        `json:"owner_id" gorm:"index;not null" query:"owner_id" form:"owner_id"`
+	Date                   string     `json:"date" gorm:"type:date" query:"date" form:"date"`
+	ClockIn                int64      `json:"clock_in" query:"clock_in" form:"clock_in"`
+	ClockOut               int64      `json:"clock_out" query:"clock_out" form:"clock_out"`
+	TimeZone               int        `json:"time_zone" query:"time_zone" form:"time_zone"`
+	Reason                 string     `json:"reason" gorm:"type:text" query:"reason" form:"reason"`
+	Evidence               string     `json:"evidence" gorm:"type:varchar(255)" query:"evidence" form:"evidence"`
+	Status                 string     `json:"status" gorm:"type:enum('pending','approved','rejected','cancelled');default:'pending'" query:"status" form:"status"`
+	ReviewNote             string     `json:"review_note" gorm:"type:text" query:"review_note" form:"review_note"`
+	ReviewedBy             int        `json:"reviewed_by" query:"reviewed_by" form:"reviewed_by"`
+	ReviewedAt             *time.Time `json:"reviewed_at" query:"reviewed_at" form:"reviewed_at"`
+	OriginalClockIn        int64      `json:"original_clock_in" query:"original_clock_in" form:"original_clock_in"`
+	OriginalClockOut       int64      `json:"original_clock_out" query:"original_clock_out" form:"original_clock_out"`
+	OriginalStatus         string     `json:"original_status" gorm:"type:varchar(30)" query:"original_status" form:"original_status"`
+	OriginalStatusPresence string     `json:"original_status_presence" gorm:"type:varchar(30)" query:"original_status_presence" form:"original_status_presence"`
+	OriginalLateIn         string     `json:"original_late_in" gorm:"type:varchar(8)" query:"original_late_in" form:"original_late_in"`
+	OriginalEarlyOut       string     `json:"original_early_out" gorm:"type:varchar(8)" query:"original_early_out" form:"original_early_out"`
+	StartDate              string     `json:"-" gorm:"-" query:"start_date" form:"start_date"` // filter
+	EndDate                string     `json:"-" gorm:"-" query:"end_date" form:"end_date"`     // filter
+}
+
+type AttendanceCorrectionReview struct {
+	Status string `json:"status" query:"status" form:"status"` // approved atau rejected
+	Note   string `json:"note" query:"note" form:"note"`
+}
+
+func (data AttendanceCorrectionReview) IsValidStatus() bool {
+	return data.Status == CorrectionApproved || data.Status == CorrectionRejected
+}
+
+// SetOriginal menyimpan nilai presensi sebelum koreksi diterapkan
+func (data *AttendanceCorrection) SetOriginal(attendance Attendance) {
+	data.OriginalClockIn = attendance.ClockIn
+	data.OriginalClockOut = attendance.ClockOut
+	data.OriginalStatus = attendance.Status
+	data.OriginalStatusPresence = attendance.StatusPresence
+	data.OriginalLateIn = attendance.LateIn
+	data.OriginalEarlyOut = attendance.EarlyOut
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"math"
+
+	"github.com/spf13/viper"
+)
+
+// EligibilityRule aturan kelayakan mengikuti ujian akhir. Status presensi yang dihitung hadir dan izin
+// diatur lewat config, presensi hadir dihitung sesuai bobot kehadiran kebijakan presensi
+type EligibilityRule struct {
+	MinAttendance  int      `json:"min_attendance"`  // persentase minimal kehadiran
+	AttendedStatus []string `json:"attended_status"` // status_presence yang dihitung hadir
+	ExcusedStatus  []string `json:"excused_status"`  // status_presence yang dihitung izin
+	ExcludeExcused bool     `json:"exclude_excused"` // izin tidak dihitung sebagai pertemuan
+}
+
+type AttendanceEligibility struct {
+	UserID      int     `json:"user_id"`
+	Username    string  `json:"username"`
+	Name        string  `json:"name"`
+	HeldMeeting int     `json:"held_meeting"`
+	Attended    float64 `json:"attended"`
+	Excused     int     `json:"excused"`
+	Percentage  float64 `json:"percentage"`
+	Eligible    bool    `json:"eligible"`
+}
+
+type AttendanceEligibilityReport struct {
+	ScheduleID   uint                    `json:"schedule_id"`
+	ScheduleName string                  `json:"schedule_name"`
+	HeldMeeting  int                     `json:"held_meeting"`
+	Rule         EligibilityRule         `json:"rule"`
+	Students     []AttendanceEligibility `json:"students"`
+}
+
+func DefaultEligibilityRule() EligibilityRule {
+	return EligibilityRule{
+		MinAttendance:  75,
+		AttendedStatus: []string{"presence"},
+		ExcusedStatus:  []string{"sick", "leave_attendance"},
+		ExcludeExcused: true,
+	}
+}
+
+// NewEligibilityRule aturan kelayakan ujian dari config, key yang kosong memakai aturan bawaan
+func NewEligibilityRule(config *viper.Viper) EligibilityRule {
+	rule := DefaultEligibilityRule()
+	if config == nil {
+		return rule
+	}
+	if config.IsSet("min_attendance") {
+		rule.MinAttendance = config.GetInt("min_attendance")
+	}
+	if config.IsSet("attended_status") {
+		rule.AttendedStatus = config.GetStringSlice("attended_status")
+	}
+	if config.IsSet("excused_status") {
+		rule.ExcusedStatus = config.GetStringSlice("excused_status")
+	}
+	if config.IsSet("exclude_excused") {
+		rule.ExcludeExcused = config.GetBool("exclude_excused")
+	}
+	return rule
+}
+
+// Evaluate menghitung persentase kehadiran dan kelayakan, tanpa pertemuan yang dihitung dianggap belum layak
+func (rule EligibilityRule) Evaluate(data AttendanceEligibility) AttendanceEligibility {
+	total := float64(rule.EffectiveMeeting(data))
+	data.Percentage = 0
+	if total > 0 {
+		data.Percentage = math.Round(math.Min(data.Attended/total, 1)*10000) / 100
+	}
+	data.Eligible = total > 0 && data.Percentage >= float64(rule.MinAttendance)
+	return data
+}
+
+// EffectiveMeeting jumlah pertemuan yang menjadi pembagi persentase kehadiran, izin dikurangi jika tidak dihitung
+func (rule EligibilityRule) EffectiveMeeting(data AttendanceEligibility) int {
+	total := data.HeldMeeting
+	if rule.ExcludeExcused {
+		total -= data.Excused
+	}
+	if total < 0 {
+		return 0
+	}
+	return total
+}
+
+// GetMinAttendance ambang kehadiran jadwal, jika kosong memakai ambang mata kuliah lalu ambang bawaan
+func (data Schedule) GetMinAttendance(defaultMinAttendance int) int {
+	if data.MinAttendance > 0 {
+		return data.MinAttendance
+	}
+	if data.Subject.MinAttendance > 0 {
+		return data.Subject.MinAttendance
+	}
+	return defaultMinAttendance
+}
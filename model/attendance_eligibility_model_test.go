@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestEligibilityRuleEvaluate(t *testing.T) {
+	rule := DefaultEligibilityRule()
+	ruleWithExcused := rule
+	ruleWithExcused.ExcludeExcused = false
+
+	tests := []struct {
+		name               string
+		rule               EligibilityRule
+		data               AttendanceEligibility
+		expectedPercentage float64
+		expectedEligible   bool
+	}{
+		{"memenuhi ambang", rule, AttendanceEligibility{HeldMeeting: 14, Attended: 12}, 85.71, true},
+		{"tepat ambang", rule, AttendanceEligibility{HeldMeeting: 4, Attended: 3}, 75, true},
+		{"di bawah ambang", rule, AttendanceEligibility{HeldMeeting: 14, Attended: 10}, 71.43, false},
+		// bobot kehadiran setengah dari kebijakan presensi
+		{"bobot setengah", rule, AttendanceEligibility{HeldMeeting: 14, Attended: 10.5}, 75, true},
+		{"izin tidak dihitung pertemuan", rule, AttendanceEligibility{HeldMeeting: 14, Attended: 10, Excused: 2}, 83.33, true},
+		{"izin dihitung pertemuan", ruleWithExcused, AttendanceEligibility{HeldMeeting: 14, Attended: 10, Excused: 2}, 71.43, false},
+		{"persentase maksimal 100", rule, AttendanceEligibility{HeldMeeting: 5, Attended: 5, Excused: 1}, 100, true},
+		{"belum ada pertemuan", rule, AttendanceEligibility{}, 0, false},
+		{"izin seluruh pertemuan", rule, AttendanceEligibility{HeldMeeting: 3, Excused: 3}, 0, false},
+	}
+	for _, test := range tests {
+		result := test.rule.Evaluate(test.data)
+		if result.Percentage != test.expectedPercentage || result.Eligible != test.expectedEligible {
+			t.Errorf("%s: expected %v %v, got %v %v", test.name, test.expectedPercentage, test.expectedEligible, result.Percentage, result.Eligible)
+		}
+	}
+}
+
+func TestEligibilityRuleEffectiveMeeting(t *testing.T) {
+	rule := DefaultEligibilityRule()
+	ruleWithExcused := rule
+	ruleWithExcused.ExcludeExcused = false
+
+	tests := []struct {
+		name     string
+		rule     EligibilityRule
+		data     AttendanceEligibility
+		expected int
+	}{
+		{"izin tidak dihitung pertemuan", rule, AttendanceEligibility{HeldMeeting: 14, Excused: 2}, 12},
+		{"izin dihitung pertemuan", ruleWithExcused, AttendanceEligibility{HeldMeeting: 14, Excused: 2}, 14},
+		// mahasiswa izin pada seluruh pertemuan tidak mendapat peringatan
+		{"izin seluruh pertemuan", rule, AttendanceEligibility{HeldMeeting: 3, Excused: 3}, 0},
+		{"izin melebihi pertemuan", rule, AttendanceEligibility{HeldMeeting: 3, Excused: 4}, 0},
+	}
+	for _, test := range tests {
+		if result := test.rule.EffectiveMeeting(test.data); result != test.expected {
+			t.Errorf("%s: expected %d, got %d", test.name, test.expected, result)
+		}
+	}
+}
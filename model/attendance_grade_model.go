@@ -0,0 +1,97 @@
+package model
+
+import (
+	"errors"
+	"math"
+)
+
+// AttendanceGradeFormula rumus nilai kehadiran per jadwal, poin dalam skala 0 - 100 untuk setiap pertemuan
+type AttendanceGradeFormula struct {
+	GormCustom
+	ScheduleID     uint    `json:"schedule_id" gorm:"uniqueIndex" query:"schedule_id" form:"schedule_id"`
+	PresencePoint  float64 `json:"presence_point" query:"presence_point" form:"presence_point"`
+	LatePoint      float64 `json:"late_point" query:"late_point" form:"late_point"`
+	EarlyOutPoint  float64 `json:"early_out_point" query:"early_out_point" form:"early_out_point"`
+	SickPoint      float64 `json:"sick_point" query:"sick_point" form:"sick_point"`
+	LeavePoint     float64 `json:"leave_point" query:"leave_point" form:"leave_point"`
+	ExcludeExcused bool    `json:"exclude_excused" query:"exclude_excused" form:"exclude_excused"` // sakit dan izin tidak dihitung sebagai pertemuan
+	Weight         float64 `json:"weight" query:"weight" form:"weight"`                            // bobot terhadap nilai akhir dalam persen
+	OwnerID        int     `json:"owner_id" gorm:"not null" query:"owner_id" form:"owner_id"`
+}
+
+// AttendanceGrade rekap kehadiran mahasiswa, jumlah hadir sudah dikalikan bobot kehadiran kebijakan presensi
+type AttendanceGrade struct {
+	UserID          int     `json:"user_id"`
+	Username        string  `json:"username"`
+	Name            string  `json:"name"`
+	HeldMeeting     int     `json:"held_meeting"`
+	Presence        float64 `json:"presence"`
+	Late            float64 `json:"late"`
+	EarlyOut        float64 `json:"early_out"`
+	LateEarlyOut    float64 `json:"late_early_out"`
+	Sick            int     `json:"sick"`
+	LeaveAttendance int     `json:"leave_attendance"`
+	Score           float64 `json:"score"`
+	GradeComponent  float64 `json:"grade_component"`
+}
+
+type AttendanceGradeReport struct {
+	ScheduleID   uint                   `json:"schedule_id"`
+	ScheduleName string                 `json:"schedule_name"`
+	HeldMeeting  int                    `json:"held_meeting"`
+	Formula      AttendanceGradeFormula `json:"formula"`
+	Students     []AttendanceGrade      `json:"students"`
+}
+
+func DefaultAttendanceGradeFormula(scheduleID uint) AttendanceGradeFormula {
+	return AttendanceGradeFormula{
+		ScheduleID:     scheduleID,
+		PresencePoint:  100,
+		LatePoint:      50,
+		EarlyOutPoint:  50,
+		ExcludeExcused: true,
+		Weight:         10,
+	}
+}
+
+func (formula AttendanceGradeFormula) Validate() error {
+	points := []struct {
+		key   string
+		value float64
+	}{
+		{"presence_point", formula.PresencePoint},
+		{"late_point", formula.LatePoint},
+		{"early_out_point", formula.EarlyOutPoint},
+		{"sick_point", formula.SickPoint},
+		{"leave_point", formula.LeavePoint},
+		{"weight", formula.Weight},
+	}
+	for _, point := range points {
+		if point.value < 0 || point.value > 100 {
+			return errors.New(point.key + ": harus bernilai 0 sampai 100")
+		}
+	}
+	return nil
+}
+
+// Evaluate menghitung nilai kehadiran 0 - 100 dan komponen nilai akhir sesuai bobot.
+// Terlambat sekaligus pulang cepat memakai poin terkecil dari keduanya
+func (formula AttendanceGradeFormula) Evaluate(data AttendanceGrade) AttendanceGrade {
+	total := float64(data.HeldMeeting)
+	point := data.Presence*formula.PresencePoint +
+		data.Late*formula.LatePoint +
+		data.EarlyOut*formula.EarlyOutPoint +
+		data.LateEarlyOut*math.Min(formula.LatePoint, formula.EarlyOutPoint)
+	if formula.ExcludeExcused {
+		total -= float64(data.Sick + data.LeaveAttendance)
+	} else {
+		point += float64(data.Sick)*formula.SickPoint + float64(data.LeaveAttendance)*formula.LeavePoint
+	}
+
+	data.Score = 0
+	if total > 0 {
+		data.Score = math.Round(math.Min(point/total, 100)*100) / 100
+	}
+	data.GradeComponent = math.Round(data.Score*formula.Weight) / 100
+	return data
+}
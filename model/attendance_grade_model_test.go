@@ -0,0 +1,35 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestAttendanceGradeFormulaEvaluate(t *testing.T) {
+	formula := DefaultAttendanceGradeFormula(1)
+	formulaWithExcused := AttendanceGradeFormula{PresencePoint: 100, LatePoint: 60, EarlyOutPoint: 40, SickPoint: 80, LeavePoint: 50, Weight: 30}
+
+	tests := []struct {
+		name              string
+		formula           AttendanceGradeFormula
+		data              AttendanceGrade
+		expectedScore     float64
+		expectedComponent float64
+	}{
+		{"hadir penuh", formula, AttendanceGrade{HeldMeeting: 10, Presence: 10}, 100, 10},
+		{"terlambat dan pulang cepat", formula, AttendanceGrade{HeldMeeting: 10, Presence: 8, Late: 1, EarlyOut: 1}, 90, 9},
+		// bobot kehadiran setengah dari kebijakan presensi
+		{"bobot setengah", formula, AttendanceGrade{HeldMeeting: 10, Presence: 7.5}, 75, 7.5},
+		{"sakit tidak dihitung pertemuan", formula, AttendanceGrade{HeldMeeting: 10, Presence: 8, Sick: 2}, 100, 10},
+		// terlambat sekaligus pulang cepat memakai poin terkecil
+		{"terlambat sekaligus pulang cepat", formulaWithExcused, AttendanceGrade{HeldMeeting: 10, Presence: 8, LateEarlyOut: 2}, 88, 26.4},
+		{"sakit dan izin dihitung pertemuan", formulaWithExcused, AttendanceGrade{HeldMeeting: 10, Presence: 7, Sick: 2, LeaveAttendance: 1}, 91, 27.3},
+		{"belum ada pertemuan", formula, AttendanceGrade{}, 0, 0},
+		{"sakit seluruh pertemuan", formula, AttendanceGrade{HeldMeeting: 2, Sick: 2}, 0, 0},
+	}
+	for _, test := range tests {
+		result := test.formula.Evaluate(test.data)
+		if result.Score != test.expectedScore || result.GradeComponent != test.expectedComponent {
+			t.Errorf("%s: expected %v %v, got %v %v", test.name, test.expectedScore, test.expectedComponent, result.Score, result.GradeComponent)
+		}
+	}
+}
@@ -2,26 +2,49 @@ package model
 
 type Attendance struct {
 	GormCustom
-	UserID         int             `json:"user_id" query:"user_id" form:"user_id"`
-	ScheduleID     uint            `json:"schedule_id" query:"schedule_id" form:"schedule_id"`
-	User           User            `json:"user" gorm:"foreignKey:UserID" query:"user" form:"user"`
-	Schedule       Schedule        `json:"schedule" gorm:"foreignKey:ScheduleID" query:"schedule" form:"schedule"`
-	Date           string          `json:"date" gorm:"type:date;not null" query:"date" form:"date"`
-	ClockIn        int64           `json:"clock_in" query:"clock_in" form:"clock_in"`
-	ClockOut       int64           `json:"clock_out" query:"clock_out" form:"clock_out"`
-	Status         string          `json:"status" gorm:"type:enum('-','late','come_home_early','late_and_home_early');default:'-'" query:"status" form:"status"`
-	StatusPresence string          `json:"status_presence" gorm:"type:enum('presence','not_presence','sick','leave_attendance');default:'not_presence'" query:"status_presence" form:"status_presence"`
-	LateIn         string          `json:"late_in" gorm:"type:varchar(8); default:'00:00:00'" query:"late_in" form:"late_in"`
-	EarlyOut       string          `json:"early_out" gorm:"type:varchar(8); default:'00:00:00'" query:"early_out" form:"early_out"`
-	LatitudeIn     float64         `json:"latitude_in" query:"latitude_in" form:"latitude_in"`
-	LongitudeIn    float64         `json:"longitude_in" query:"longitude_in" form:"longitude_in"`
-	TimeZoneIn     int             `json:"time_zone_in" query:"time_zone_in" form:"time_zone_in"`
-	LocationIn     string          `json:"location_in" gorm:"type:varchar(255)" query:"location_in" form:"location_in"`
-	LatitudeOut    float64         `json:"latitude_out" query:"latitude_out" form:"latitude_out"`
-	LongitudeOut   float64         `json:"longitude_out" query:"longitude_out" form:"longitude_out"`
-	TimeZoneOut    int             `json:"time_zone_out" query:"time_zone_out" form:"time_zone_out"`
-	LocationOut    string          `json:"location_out" gorm:"type:varchar(255)" query:"location_out" form:"location_out"`
-	AttendanceLog  []AttendanceLog `json:"attendance_log" gorm:"foreignKey:AttendanceID" query:"attendance_log" form:"attendance_log"`
+	UserID          int             `json:"user_id" query:"user_id" form:"user_id"`
+	ScheduleID      uint            `json:"schedule_id" query:"schedule_id" form:"schedule_id"`
+	SessionID       uint            `json:"session_id" gorm:"index" query:"session_id" form:"session_id"`
+	DailyScheduleID uint            `json:"daily_schedule_id" gorm:"index" query:"daily_schedule_id" form:"daily_schedule_id"` // blok jadwal harian, 0 untuk pertemuan pindahan / kelas pengganti
+	User            User            `json:"user" gorm:"foreignKey:UserID" query:"user" form:"user"`
+	Schedule        Schedule        `json:"schedule" gorm:"foreignKey:ScheduleID" query:"schedule" form:"schedule"`
+	Date            string          `json:"date" gorm:"type:date;not null" query:"date" form:"date"`
+	ClockIn         int64           `json:"clock_in" query:"clock_in" form:"clock_in"`
+	ClockOut        int64           `json:"clock_out" query:"clock_out" form:"clock_out"`
+	Status          string          `json:"status" gorm:"type:enum('-','late','come_home_early','late_and_home_early');default:'-'" query:"status" form:"status"`
+	StatusPresence  string          `json:"status_presence" gorm:"type:enum('presence','not_presence','sick','leave_attendance');default:'not_presence'" query:"status_presence" form:"status_presence"`
+	LateIn          string          `json:"late_in" gorm:"type:varchar(8); default:'00:00:00'" query:"late_in" form:"late_in"`
+	EarlyOut        string          `json:"early_out" gorm:"type:varchar(8); default:'00:00:00'" query:"early_out" form:"early_out"`
+	LatitudeIn      float64         `json:"latitude_in" query:"latitude_in" form:"latitude_in"`
+	LongitudeIn     float64         `json:"longitude_in" query:"longitude_in" form:"longitude_in"`
+	TimeZoneIn      int             `json:"time_zone_in" query:"time_zone_in" form:"time_zone_in"`
+	LocationIn      string          `json:"location_in" gorm:"type:varchar(255)" query:"location_in" form:"location_in"`
+	LatitudeOut     float64         `json:"latitude_out" query:"latitude_out" form:"latitude_out"`
+	LongitudeOut    float64         `json:"longitude_out" query:"longitude_out" form:"longitude_out"`
+	TimeZoneOut     int             `json:"time_zone_out" query:"time_zone_out" form:"time_zone_out"`
+	LocationOut     string          `json:"location_out" gorm:"type:varchar(255)" query:"location_out" form:"location_out"`
+	AttendanceLog   []AttendanceLog `json:"attendance_log" gorm:"foreignKey:AttendanceID" query:"attendance_log" form:"attendance_log"`
+	PresenceScore   float64         `json:"presence_score" gorm:"not null;default:0" query:"presence_score" form:"presence_score"` // bobot kehadiran hasil kebijakan presensi, 1 hadir penuh
+	PolicyLabel     string          `json:"policy_label" gorm:"type:varchar(255)" query:"policy_label" form:"policy_label"`        // label aturan kebijakan yang terkena
+	StartDate       string          `json:"-" gorm:"-" query:"start_date" form:"start_date"`                                       // filter
+	EndDate         string          `json:"-" gorm:"-" query:"end_date" form:"end_date"`                                           // filter
+}
+
+// AttendanceExport satu baris ekspor presensi, jam masuk / pulang dalam millis dengan zona waktu saat dicatat
+type AttendanceExport struct {
+	NIM            string
+	FirstName      string
+	LastName       string
+	SubjectName    string
+	ScheduleName   string
+	ScheduleCode   string
+	Date           string
+	ClockIn        int64
+	TimeZoneIn     int
+	ClockOut       int64
+	TimeZoneOut    int
+	Status         string
+	StatusPresence string
 }
 
 type QuickUpdateAttendance struct {
@@ -72,3 +95,11 @@ func (data Attendance) GenerateStatus() (status string) {
 		return "-"
 	}
 }
+
+// GetPresenceScore bobot kehadiran tanpa kebijakan presensi
+func (data Attendance) GetPresenceScore() float64 {
+	if data.StatusPresence == "presence" {
+		return 1
+	}
+	return 0
+}
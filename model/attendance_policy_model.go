@@ -0,0 +1,177 @@
+package model
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	PolicyRuleLate            = "late"
+	PolicyRuleEarlyOut        = "early_out"
+	PolicyRuleMinDuration     = "min_duration"
+	PolicyRuleMissingClockOut = "missing_clock_out"
+)
+
+const (
+	PolicyResultPresence     = "presence"
+	PolicyResultHalfPresence = "half_presence"
+	PolicyResultNotPresence  = "not_presence"
+)
+
+// AttendancePolicy kumpulan aturan penilaian presensi, dipasang per jadwal atau per fakultas
+type AttendancePolicy struct {
+	GormCustom
+	Name    string                 `json:"name" gorm:"type:varchar(100)" query:"name" form:"name"`
+	Summary string                 `json:"summary" gorm:"type:text" query:"summary" form:"summary"`
+	Rules   []AttendancePolicyRule `json:"rules" gorm:"foreignKey:AttendancePolicyID" query:"rules" form:"rules"`
+	OwnerID int                    `json:"owner_id" gorm:"not null" query:"owner_id" form:"owner_id"`
+}
+
+// AttendancePolicyRule satu aturan, Minutes adalah ambang menit (tidak dipakai untuk missing_clock_out).
+// missing_clock_out hanya dinilai setelah batas absen keluar blok lewat.
+// Aturan bertingkat dengan tipe yang sama memakai ambang terberat yang terlewati
+type AttendancePolicyRule struct {
+	GormCustom
+	AttendancePolicyID uint   `json:"attendance_policy_id" gorm:"index" query:"attendance_policy_id" form:"attendance_policy_id"`
+	Type               string `json:"type" gorm:"type:enum('late','early_out','min_duration','missing_clock_out');default:'late'" query:"type" form:"type"`
+	Minutes            int    `json:"minutes" query:"minutes" form:"minutes"`
+	Result             string `json:"result" gorm:"type:enum('presence','half_presence','not_presence');default:'presence'" query:"result" form:"result"`
+	Label              string `json:"label" gorm:"type:varchar(100)" query:"label" form:"label"`
+}
+
+type AttendancePolicyAssign struct {
+	AttendancePolicyID int `json:"attendance_policy_id" query:"attendance_policy_id" form:"attendance_policy_id"` // 0 untuk melepas kebijakan
+	ScheduleID         int `json:"schedule_id" query:"schedule_id" form:"schedule_id"`
+	FacultyID          int `json:"faculty_id" query:"faculty_id" form:"faculty_id"`
+}
+
+type AttendancePolicyRecompute struct {
+	TotalSchedule   int   `json:"total_schedule"`
+	TotalAttendance int64 `json:"total_attendance"`
+}
+
+// HasRule kebijakan memiliki aturan dengan tipe tersebut
+func (policy AttendancePolicy) HasRule(ruleType string) bool {
+	for _, rule := range policy.Rules {
+		if rule.Type == ruleType {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule AttendancePolicyRule) Validate() error {
+	switch rule.Type {
+	case PolicyRuleLate, PolicyRuleEarlyOut, PolicyRuleMinDuration, PolicyRuleMissingClockOut:
+	default:
+		return errors.New("type: tipe aturan tidak valid")
+	}
+	switch rule.Result {
+	case PolicyResultPresence, PolicyResultHalfPresence, PolicyResultNotPresence:
+	default:
+		return errors.New("result: hasil aturan tidak valid")
+	}
+	if rule.Minutes < 0 {
+		return errors.New("minutes: tidak boleh bernilai negatif")
+	}
+	if rule.Type == PolicyRuleMinDuration && rule.Minutes == 0 {
+		return errors.New("minutes: durasi minimal harus diisi")
+	}
+	return nil
+}
+
+// Score bobot kehadiran dari hasil aturan
+func (rule AttendancePolicyRule) Score() float64 {
+	switch rule.Result {
+	case PolicyResultHalfPresence:
+		return 0.5
+	case PolicyResultNotPresence:
+		return 0
+	}
+	return 1
+}
+
+// isMatch aturan berlaku untuk presensi tersebut
+func (rule AttendancePolicyRule) isMatch(data Attendance, isClockOutClosed bool) bool {
+	switch rule.Type {
+	case PolicyRuleLate:
+		return data.ClockIn > 0 && durationMinutes(data.LateIn) > rule.Minutes
+	case PolicyRuleEarlyOut:
+		return data.ClockOut > 0 && durationMinutes(data.EarlyOut) > rule.Minutes
+	case PolicyRuleMinDuration:
+		return data.ClockIn > 0 && data.ClockOut > data.ClockIn && int((data.ClockOut-data.ClockIn)/60000) < rule.Minutes
+	case PolicyRuleMissingClockOut:
+		return isClockOutClosed && data.ClockIn > 0 && data.ClockOut == 0
+	}
+	return false
+}
+
+// overrides ambang aturan lebih berat dari aturan lain dengan tipe yang sama
+func (rule AttendancePolicyRule) overrides(other AttendancePolicyRule) bool {
+	if rule.Type == PolicyRuleMinDuration {
+		return rule.Minutes < other.Minutes
+	}
+	return rule.Minutes > other.Minutes
+}
+
+// Apply menilai presensi yang sudah melalui GenerateStatusPresence dan GenerateStatus.
+// Izin dan sakit tidak dinilai, presensi dengan bobot 0 menjadi not_presence.
+// isClockOutClosed false selama absen keluar masih dibuka sehingga missing_clock_out belum berlaku
+func (policy AttendancePolicy) Apply(data Attendance, isClockOutClosed bool) Attendance {
+	data.PolicyLabel = ""
+	if data.StatusPresence != "presence" {
+		data.PresenceScore = 0
+		return data
+	}
+	data.PresenceScore = 1
+
+	// untuk aturan bertingkat hanya ambang terberat yang terlewati yang dipakai
+	matched := map[string]AttendancePolicyRule{}
+	for _, rule := range policy.Rules {
+		if !rule.isMatch(data, isClockOutClosed) {
+			continue
+		}
+		if current, ok := matched[rule.Type]; ok && !rule.overrides(current) {
+			continue
+		}
+		matched[rule.Type] = rule
+	}
+
+	var labels []string
+	for _, ruleType := range []string{PolicyRuleLate, PolicyRuleEarlyOut, PolicyRuleMinDuration, PolicyRuleMissingClockOut} {
+		rule, ok := matched[ruleType]
+		if !ok {
+			continue
+		}
+		if rule.Score() < data.PresenceScore {
+			data.PresenceScore = rule.Score()
+		}
+		if rule.Label != "" {
+			labels = append(labels, rule.Label)
+		}
+	}
+	data.PolicyLabel = strings.Join(labels, ", ")
+
+	if data.PresenceScore == 0 {
+		data.StatusPresence = "not_presence"
+	}
+	return data
+}
+
+// durationMinutes mengubah durasi 00:00:00 menjadi menit
+func durationMinutes(duration string) int {
+	parts := strings.Split(duration, ":")
+	if len(parts) < 2 {
+		return 0
+	}
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0
+	}
+	return hour*60 + minute
+}
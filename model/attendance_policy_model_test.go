@@ -0,0 +1,71 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAttendancePolicyApply(t *testing.T) {
+	policy := AttendancePolicy{Rules: []AttendancePolicyRule{
+		{Type: PolicyRuleLate, Minutes: 15, Result: PolicyResultHalfPresence, Label: "terlambat"},
+		{Type: PolicyRuleLate, Minutes: 30, Result: PolicyResultNotPresence, Label: "terlambat berat"},
+		{Type: PolicyRuleMinDuration, Minutes: 60, Result: PolicyResultHalfPresence, Label: "durasi kurang"},
+		{Type: PolicyRuleMissingClockOut, Result: PolicyResultHalfPresence, Label: "tanpa absen keluar"},
+	}}
+	clockIn := int64(1709517600000) // 2024-03-04 09:00 WIB
+
+	tests := []struct {
+		name             string
+		data             Attendance
+		isClockOutClosed bool
+		expectedScore    float64
+		expectedStatus   string
+		expectedLabel    string
+	}{
+		{"tepat waktu", Attendance{StatusPresence: "presence", ClockIn: clockIn, ClockOut: clockIn + 90*60000, LateIn: "00:00:00"}, true, 1, "presence", ""},
+		{"terlambat di bawah ambang", Attendance{StatusPresence: "presence", ClockIn: clockIn, ClockOut: clockIn + 90*60000, LateIn: "00:10:00"}, true, 1, "presence", ""},
+		// aturan bertingkat hanya memakai ambang terberat yang terlewati
+		{"terlambat ambang pertama", Attendance{StatusPresence: "presence", ClockIn: clockIn, ClockOut: clockIn + 90*60000, LateIn: "00:20:00"}, true, 0.5, "presence", "terlambat"},
+		{"terlambat ambang kedua", Attendance{StatusPresence: "presence", ClockIn: clockIn, ClockOut: clockIn + 90*60000, LateIn: "00:45:00"}, true, 0, "not_presence", "terlambat berat"},
+		{"durasi kurang", Attendance{StatusPresence: "presence", ClockIn: clockIn, ClockOut: clockIn + 30*60000, LateIn: "00:00:00"}, true, 0.5, "presence", "durasi kurang"},
+		{"terlambat dan durasi kurang", Attendance{StatusPresence: "presence", ClockIn: clockIn, ClockOut: clockIn + 30*60000, LateIn: "00:20:00"}, true, 0.5, "presence", "terlambat, durasi kurang"},
+		// missing_clock_out belum berlaku selama absen keluar masih dibuka
+		{"belum absen keluar, masih dibuka", Attendance{StatusPresence: "presence", ClockIn: clockIn, LateIn: "00:00:00"}, false, 1, "presence", ""},
+		{"belum absen keluar, sudah ditutup", Attendance{StatusPresence: "presence", ClockIn: clockIn, LateIn: "00:00:00"}, true, 0.5, "presence", "tanpa absen keluar"},
+		{"sakit tidak dinilai", Attendance{StatusPresence: "sick", PolicyLabel: "lama"}, true, 0, "sick", ""},
+	}
+	for _, test := range tests {
+		result := policy.Apply(test.data, test.isClockOutClosed)
+		if result.PresenceScore != test.expectedScore || result.StatusPresence != test.expectedStatus || result.PolicyLabel != test.expectedLabel {
+			t.Errorf("%s: expected %v %s %q, got %v %s %q", test.name, test.expectedScore, test.expectedStatus, test.expectedLabel,
+				result.PresenceScore, result.StatusPresence, result.PolicyLabel)
+		}
+	}
+}
+
+func TestDailyScheduleIsClockOutClosed(t *testing.T) {
+	after := 30
+	tests := []struct {
+		name          string
+		dailySchedule DailySchedule
+		now           string
+		expected      bool
+	}{
+		{"sebelum jam selesai", DailySchedule{StartTime: "08:00", EndTime: "10:00"}, "2024-03-04 09:59", false},
+		{"tepat jam selesai", DailySchedule{StartTime: "08:00", EndTime: "10:00"}, "2024-03-04 10:00", true},
+		{"dalam clock_out_after", DailySchedule{StartTime: "08:00", EndTime: "10:00", ClockWindow: ClockWindow{ClockOutAfter: &after}}, "2024-03-04 10:15", false},
+		{"lewat clock_out_after", DailySchedule{StartTime: "08:00", EndTime: "10:00", ClockWindow: ClockWindow{ClockOutAfter: &after}}, "2024-03-04 10:30", true},
+		// blok malam ditutup keesokan harinya
+		{"blok malam sebelum tengah malam", DailySchedule{StartTime: "22:00", EndTime: "02:00"}, "2024-03-04 23:00", false},
+		{"blok malam setelah tengah malam", DailySchedule{StartTime: "22:00", EndTime: "02:00"}, "2024-03-05 01:00", false},
+		{"blok malam selesai", DailySchedule{StartTime: "22:00", EndTime: "02:00"}, "2024-03-05 02:00", true},
+		{"tanpa jam selesai, hari yang sama", DailySchedule{StartTime: "08:00"}, "2024-03-04 23:00", false},
+		{"tanpa jam selesai, hari berikutnya", DailySchedule{StartTime: "08:00"}, "2024-03-05 00:00", true},
+	}
+	for _, test := range tests {
+		now, _ := time.ParseInLocation("2006-01-02 15:04", test.now, time.Local)
+		if result := test.dailySchedule.IsClockOutClosed("2024-03-04", now); result != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, result)
+		}
+	}
+}
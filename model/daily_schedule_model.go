@@ -1,7 +1,9 @@
 package model
 
 import (
+	"attendance-api/common/util/calculation"
 	"attendance-api/common/util/converter"
+	"fmt"
 	"time"
 )
 
@@ -13,6 +15,39 @@ type DailySchedule struct {
 	StartTime  string `json:"start_time" gorm:"type:varchar(5)" query:"start_time" form:"start_time"`
 	EndTime    string `json:"end_time" gorm:"type:varchar(5)" query:"end_time" form:"end_time"`
 	OwnerID    int    `json:"owner_id" gorm:"not null" query:"owner_id" form:"owner_id"`
+	ClockWindow
+	Exception *ScheduleException `json:"-" gorm:"-"` // pertemuan pindahan / kelas pengganti asal blok tanpa id
+}
+
+// ClockWindow batas waktu absen dalam menit terhadap jam mulai dan jam selesai, kosong berarti tidak dibatasi.
+// Jadwal harian yang tidak mengisi memakai nilai bawaan dari jadwal
+type ClockWindow struct {
+	ClockInBefore  *int `json:"clock_in_before" query:"clock_in_before" form:"clock_in_before"`    // absen masuk dibuka sekian menit sebelum jam mulai
+	ClockInAfter   *int `json:"clock_in_after" query:"clock_in_after" form:"clock_in_after"`       // absen masuk ditutup sekian menit setelah jam mulai
+	ClockOutBefore *int `json:"clock_out_before" query:"clock_out_before" form:"clock_out_before"` // absen keluar dibuka sekian menit sebelum jam selesai
+	ClockOutAfter  *int `json:"clock_out_after" query:"clock_out_after" form:"clock_out_after"`    // absen keluar ditutup sekian menit setelah jam selesai
+}
+
+// IsOvernight jam selesai lebih awal dari jam mulai berarti blok berakhir keesokan harinya
+func (dailySchedule DailySchedule) IsOvernight() bool {
+	return dailySchedule.EndTime != "" && dailySchedule.EndTime < dailySchedule.StartTime
+}
+
+// IsClockOutClosed batas absen keluar blok pada tanggal presensi sudah lewat (jam selesai ditambah clock_out_after,
+// blok malam keesokan harinya, zona waktu server). Blok tanpa jam selesai dianggap tutup setelah tanggal tersebut
+func (dailySchedule DailySchedule) IsClockOutClosed(date string, now time.Time) bool {
+	date = converter.GetOnlyDateString(date)
+	closedAt, err := time.ParseInLocation("2006-01-02 15:04", date+" "+dailySchedule.EndTime, time.Local)
+	if err != nil {
+		return date < now.Format("2006-01-02")
+	}
+	if dailySchedule.IsOvernight() {
+		closedAt = closedAt.AddDate(0, 0, 1)
+	}
+	if dailySchedule.ClockOutAfter != nil {
+		closedAt = closedAt.Add(time.Duration(*dailySchedule.ClockOutAfter) * time.Minute)
+	}
+	return !now.Before(closedAt)
 }
 
 func (dailySchedule DailySchedule) IsToday() (isToday bool) {
@@ -24,3 +59,102 @@ func (dailySchedule DailySchedule) IsToday() (isToday bool) {
 		return false
 	}
 }
+
+// WithDefault mengisi batas waktu yang kosong dengan nilai bawaan
+func (data ClockWindow) WithDefault(defaultWindow ClockWindow) ClockWindow {
+	if data.ClockInBefore == nil {
+		data.ClockInBefore = defaultWindow.ClockInBefore
+	}
+	if data.ClockInAfter == nil {
+		data.ClockInAfter = defaultWindow.ClockInAfter
+	}
+	if data.ClockOutBefore == nil {
+		data.ClockOutBefore = defaultWindow.ClockOutBefore
+	}
+	if data.ClockOutAfter == nil {
+		data.ClockOutAfter = defaultWindow.ClockOutAfter
+	}
+	return data
+}
+
+// Validate batas waktu tidak boleh bernilai negatif
+func (data ClockWindow) Validate() error {
+	for name, value := range map[string]*int{
+		"clock_in_before":  data.ClockInBefore,
+		"clock_in_after":   data.ClockInAfter,
+		"clock_out_before": data.ClockOutBefore,
+		"clock_out_after":  data.ClockOutAfter,
+	} {
+		if value != nil && *value < 0 {
+			return fmt.Errorf("%s: tidak boleh bernilai negatif", name)
+		}
+	}
+	return nil
+}
+
+// ClockInError menolak absen masuk di luar batas waktu jadwal harian
+func (dailySchedule DailySchedule) ClockInError(date string, clockIn int64, timeZone int) error {
+	return clockWindowError("absen masuk", date, dailySchedule.StartTime, false, dailySchedule.ClockInBefore, dailySchedule.ClockInAfter, clockIn, timeZone)
+}
+
+// ClockOutError menolak absen keluar di luar batas waktu jadwal harian, jam selesai blok lintas tengah malam jatuh pada hari berikutnya
+func (dailySchedule DailySchedule) ClockOutError(date string, clockOut int64, timeZone int) error {
+	return clockWindowError("absen keluar", date, dailySchedule.EndTime, dailySchedule.IsOvernight(), dailySchedule.ClockOutBefore, dailySchedule.ClockOutAfter, clockOut, timeZone)
+}
+
+func clockWindowError(label string, date string, scheduleTime string, isNextDay bool, before *int, after *int, checkIn int64, timeZone int) error {
+	if before == nil && after == nil {
+		return nil
+	}
+	timeSchedule, err := time.Parse("15:04", scheduleTime)
+	if err != nil {
+		return nil
+	}
+	diff, err := calculation.DiffFromSchedule(date, scheduleTime, isNextDay, checkIn, timeZone)
+	if err != nil {
+		return nil
+	}
+
+	if before != nil && diff < -time.Duration(*before)*time.Minute {
+		openTime := timeSchedule.Add(-time.Duration(*before) * time.Minute)
+		return fmt.Errorf("%s belum dibuka, dibuka pukul %s", label, openTime.Format("15:04"))
+	}
+	if after != nil && diff > time.Duration(*after)*time.Minute {
+		closeTime := timeSchedule.Add(time.Duration(*after) * time.Minute)
+		return fmt.Errorf("%s sudah ditutup pukul %s", label, closeTime.Format("15:04"))
+	}
+	return nil
+}
+
+// MatchDailySchedule memilih blok jadwal harian (urut jam mulai) yang sesuai dengan waktu absen.
+// Absen masuk memakai blok yang sedang berjalan atau blok berikutnya, absen keluar memakai blok terakhir yang sudah dimulai
+func MatchDailySchedule(dailySchedules []DailySchedule, checkIn int64, timeZone int, logType string) DailySchedule {
+	if len(dailySchedules) == 0 {
+		return DailySchedule{}
+	}
+	timeCheck := converter.MillisToTimeString(checkIn, timeZone)
+	if logType == "clock_out" {
+		for i := len(dailySchedules) - 1; i >= 0; i-- {
+			if dailySchedules[i].StartTime <= timeCheck {
+				return dailySchedules[i]
+			}
+		}
+		return dailySchedules[0]
+	}
+	for _, dailySchedule := range dailySchedules {
+		if timeCheck <= dailySchedule.EndTime || dailySchedule.IsOvernight() {
+			return dailySchedule
+		}
+	}
+	return dailySchedules[len(dailySchedules)-1]
+}
+
+// FindDailySchedule mencari blok jadwal harian berdasarkan id
+func FindDailySchedule(dailySchedules []DailySchedule, id uint) (DailySchedule, bool) {
+	for _, dailySchedule := range dailySchedules {
+		if dailySchedule.ID == id {
+			return dailySchedule, true
+		}
+	}
+	return DailySchedule{}, false
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func millis(dateTime string) int64 {
+	t, _ := time.ParseInLocation("2006-01-02 15:04", dateTime, time.FixedZone("", 7*3600))
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func TestDailyScheduleClockWindowError(t *testing.T) {
+	before, after := 15, 30
+	window := ClockWindow{ClockInBefore: &before, ClockInAfter: &after, ClockOutBefore: &before, ClockOutAfter: &after}
+	day := DailySchedule{StartTime: "08:00", EndTime: "10:00", ClockWindow: window}
+	night := DailySchedule{StartTime: "22:00", EndTime: "02:00", ClockWindow: window}
+
+	tests := []struct {
+		name     string
+		logType  string
+		daily    DailySchedule
+		checkIn  string
+		expected string
+	}{
+		{"masuk sebelum dibuka", "clock_in", day, "2024-03-04 07:40", "absen masuk belum dibuka, dibuka pukul 07:45"},
+		{"masuk saat dibuka", "clock_in", day, "2024-03-04 07:50", ""},
+		{"masuk tepat batas tutup", "clock_in", day, "2024-03-04 08:30", ""},
+		{"masuk setelah ditutup", "clock_in", day, "2024-03-04 08:31", "absen masuk sudah ditutup pukul 08:30"},
+		{"masuk hari berikutnya", "clock_in", day, "2024-03-05 08:00", "absen masuk sudah ditutup pukul 08:30"},
+		{"masuk tanpa batas", "clock_in", DailySchedule{StartTime: "08:00", EndTime: "10:00"}, "2024-03-04 12:00", ""},
+		{"keluar sebelum dibuka", "clock_out", day, "2024-03-04 09:00", "absen keluar belum dibuka, dibuka pukul 09:45"},
+		{"keluar saat dibuka", "clock_out", day, "2024-03-04 10:10", ""},
+		// jam selesai blok malam jatuh pada hari berikutnya
+		{"blok malam masuk", "clock_in", night, "2024-03-04 21:50", ""},
+		{"blok malam keluar sebelum tengah malam", "clock_out", night, "2024-03-04 23:00", "absen keluar belum dibuka, dibuka pukul 01:45"},
+		{"blok malam keluar setelah tengah malam", "clock_out", night, "2024-03-05 01:50", ""},
+		{"blok malam keluar setelah ditutup", "clock_out", night, "2024-03-05 02:31", "absen keluar sudah ditutup pukul 02:30"},
+	}
+	for _, test := range tests {
+		var err error
+		if test.logType == "clock_out" {
+			err = test.daily.ClockOutError("2024-03-04", millis(test.checkIn), 7)
+		} else {
+			err = test.daily.ClockInError("2024-03-04", millis(test.checkIn), 7)
+		}
+		result := ""
+		if err != nil {
+			result = err.Error()
+		}
+		if result != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestMatchDailySchedule(t *testing.T) {
+	dailySchedules := []DailySchedule{
+		{GormCustom: GormCustom{ID: 1}, StartTime: "08:00", EndTime: "10:00"},
+		{GormCustom: GormCustom{ID: 2}, StartTime: "13:00", EndTime: "15:00"},
+		{GormCustom: GormCustom{ID: 3}, StartTime: "22:00", EndTime: "02:00"},
+	}
+
+	tests := []struct {
+		name           string
+		dailySchedules []DailySchedule
+		checkIn        string
+		logType        string
+		expected       uint
+	}{
+		{"masuk sebelum blok pertama", dailySchedules, "2024-03-04 07:50", "clock_in", 1},
+		{"masuk di antara blok", dailySchedules, "2024-03-04 10:30", "clock_in", 2},
+		{"masuk saat blok kedua", dailySchedules, "2024-03-04 14:00", "clock_in", 2},
+		{"masuk setelah blok kedua", dailySchedules, "2024-03-04 16:00", "clock_in", 3},
+		{"masuk blok malam", dailySchedules, "2024-03-04 23:30", "clock_in", 3},
+		{"keluar blok pertama", dailySchedules, "2024-03-04 09:50", "clock_out", 1},
+		{"keluar di antara blok", dailySchedules, "2024-03-04 12:00", "clock_out", 1},
+		{"keluar blok kedua", dailySchedules, "2024-03-04 15:10", "clock_out", 2},
+		{"keluar blok malam sebelum tengah malam", dailySchedules, "2024-03-04 23:59", "clock_out", 3},
+		// absen keluar blok malam setelah tengah malam dicocokkan dengan blok hari sebelumnya
+		{"keluar blok malam setelah tengah malam", dailySchedules[2:], "2024-03-05 01:30", "clock_out", 3},
+		{"tanpa blok", nil, "2024-03-04 08:00", "clock_in", 0},
+	}
+	for _, test := range tests {
+		if result := MatchDailySchedule(test.dailySchedules, millis(test.checkIn), 7, test.logType); result.ID != test.expected {
+			t.Errorf("%s: expected block %d, got %d", test.name, test.expected, result.ID)
+		}
+	}
+}
@@ -6,4 +6,6 @@ type Faculty struct {
 	Code    string `json:"code" gorm:"unique;type:varchar(25)" query:"code" form:"code"`
 	Summary string `json:"summary" gorm:"type:text" query:"summary" form:"summary"`
 	OwnerID int    `json:"owner_id" gorm:"not null" query:"owner_id" form:"owner_id"`
+	// AttendancePolicyID kebijakan presensi bawaan untuk jadwal dosen fakultas ini
+	AttendancePolicyID uint `json:"attendance_policy_id" gorm:"index" query:"attendance_policy_id" form:"attendance_policy_id"`
 }
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"attendance-api/common/util/geofence"
 	"time"
 )
 
@@ -31,29 +32,30 @@ type AttendanceLogForm struct {
 }
 
 type AttendanceForm struct {
-	ID             uint      `json:"id" gorm:"primary_key"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedBy      int       `json:"updated_by"`
-	DeletedBy      int       `json:"deleted_by"`
-	UserID         int       `json:"user_id"`
-	ScheduleID     uint      `json:"schedule_id"`
-	Date           time.Time `json:"date" gorm:"type:date;not null"`
-	ClockIn        int64     `json:"clock_in"`
-	ClockOut       int64     `json:"clock_out"`
-	Status         string    `json:"status" gorm:"type:enum('-','late','come_home_early','late_and_home_early');default:'-'"`
-	StatusPresence string    `json:"status_presence" gorm:"type:enum('presence','not_presence','sick','leave_attendance');default:'not_presence'"`
-	LateIn         string    `json:"late_in" gorm:"type:varchar(8); default:'00:00:00'"`
-	EarlyOut       string    `json:"early_out" gorm:"type:varchar(8); default:'00:00:00'"`
-	LatitudeIn     float64   `json:"latitude_in"`
-	LongitudeIn    float64   `json:"longitude_in"`
-	TimeZoneIn     int       `json:"time_zone_in"`
-	LocationIn     string    `json:"location_in" gorm:"type:varchar(255)"`
-	LatitudeOut    float64   `json:"latitude_out"`
-	LongitudeOut   float64   `json:"longitude_out"`
-	TimeZoneOut    int       `json:"time_zone_out"`
-	LocationOut    string    `json:"location_out" gorm:"type:varchar(255)"`
+	ID              uint      `json:"id" gorm:"primary_key"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedBy       int       `json:"updated_by"`
+	DeletedBy       int       `json:"deleted_by"`
+	UserID          int       `json:"user_id"`
+	ScheduleID      uint      `json:"schedule_id"`
+	DailyScheduleID uint      `json:"daily_schedule_id"`
+	Date            time.Time `json:"date" gorm:"type:date;not null"`
+	ClockIn         int64     `json:"clock_in"`
+	ClockOut        int64     `json:"clock_out"`
+	Status          string    `json:"status" gorm:"type:enum('-','late','come_home_early','late_and_home_early');default:'-'"`
+	StatusPresence  string    `json:"status_presence" gorm:"type:enum('presence','not_presence','sick','leave_attendance');default:'not_presence'"`
+	LateIn          string    `json:"late_in" gorm:"type:varchar(8); default:'00:00:00'"`
+	EarlyOut        string    `json:"early_out" gorm:"type:varchar(8); default:'00:00:00'"`
+	LatitudeIn      float64   `json:"latitude_in"`
+	LongitudeIn     float64   `json:"longitude_in"`
+	TimeZoneIn      int       `json:"time_zone_in"`
+	LocationIn      string    `json:"location_in" gorm:"type:varchar(255)"`
+	LatitudeOut     float64   `json:"latitude_out"`
+	LongitudeOut    float64   `json:"longitude_out"`
+	TimeZoneOut     int       `json:"time_zone_out"`
+	LocationOut     string    `json:"location_out" gorm:"type:varchar(255)"`
 }
 
 type CheckInDataForm struct {
@@ -83,6 +85,192 @@ type DailyScheduleForm struct {
 	StartTime  string    `json:"start_time" gorm:"type:varchar(5)"`
 	EndTime    string    `json:"end_time" gorm:"type:varchar(5)"`
 	OwnerID    int       `json:"owner_id" gorm:"not null"`
+	ClockWindow
+}
+
+type GeofenceZoneForm struct {
+	ID         uint             `json:"id" gorm:"primary_key"`
+	ScheduleID uint             `json:"schedule_id"`
+	RoomID     uint             `json:"room_id"`
+	Name       string           `json:"name" gorm:"type:varchar(100)"`
+	Type       string           `json:"type" gorm:"type:enum('circle','polygon');default:'circle'"`
+	Latitude   float64          `json:"latitude"`
+	Longitude  float64          `json:"longitude"`
+	Radius     int              `json:"radius"` //in metter
+	Points     []geofence.Point `json:"points"`
+}
+
+type ScheduleExceptionForm struct {
+	ID              uint      `json:"id" gorm:"primary_key"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedBy       int       `json:"updated_by"`
+	DeletedBy       int       `json:"deleted_by"`
+	ScheduleID      uint      `json:"schedule_id"`
+	DailyScheduleID uint      `json:"daily_schedule_id"`
+	Type            string    `json:"type" gorm:"type:enum('cancel','reschedule','makeup');default:'cancel'"`
+	Date            string    `json:"date" gorm:"type:date"`
+	NewDate         string    `json:"new_date" gorm:"type:date"`
+	StartTime       string    `json:"start_time" gorm:"type:varchar(5)"`
+	EndTime         string    `json:"end_time" gorm:"type:varchar(5)"`
+	RoomID          *uint     `json:"room_id"`
+	Reason          string    `json:"reason" gorm:"type:text"`
+	OwnerID         int       `json:"owner_id" gorm:"not null"`
+}
+
+type SessionForm struct {
+	ID                  uint      `json:"id" gorm:"primary_key"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+	CreatedBy           int       `json:"created_by"`
+	UpdatedBy           int       `json:"updated_by"`
+	DeletedBy           int       `json:"deleted_by"`
+	ScheduleID          uint      `json:"schedule_id"`
+	DailyScheduleID     uint      `json:"daily_schedule_id"`
+	ScheduleExceptionID uint      `json:"schedule_exception_id"`
+	Number              int       `json:"number"`
+	Date                string    `json:"date" gorm:"type:date"`
+	StartTime           string    `json:"start_time" gorm:"type:varchar(5)"`
+	EndTime             string    `json:"end_time" gorm:"type:varchar(5)"`
+	RoomID              *uint     `json:"room_id"`
+	Status              string    `json:"status" gorm:"type:enum('planned','held','cancelled');default:'planned'"`
+	Topic               string    `json:"topic" gorm:"type:varchar(255)"`
+	Notes               string    `json:"notes" gorm:"type:text"`
+	SubstituteID        int       `json:"substitute_id"`
+	OwnerID             int       `json:"owner_id" gorm:"not null"`
+	Orphaned            bool      `json:"orphaned"`
+}
+
+type LectureJournalForm struct {
+	ID               uint       `json:"id" gorm:"primary_key"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	CreatedBy        int        `json:"created_by"`
+	UpdatedBy        int        `json:"updated_by"`
+	DeletedBy        int        `json:"deleted_by"`
+	ScheduleID       uint       `json:"schedule_id"`
+	SessionID        uint       `json:"session_id"`
+	Date             string     `json:"date" gorm:"type:date"`
+	Topic            string     `json:"topic" gorm:"type:varchar(255)"`
+	Method           string     `json:"method" gorm:"type:varchar(100)"`
+	Notes            string     `json:"notes" gorm:"type:text"`
+	TotalStudent     int        `json:"total_student"`
+	PresenceCount    int        `json:"presence_count"`
+	NotPresenceCount int        `json:"not_presence_count"`
+	SickCount        int        `json:"sick_count"`
+	LeaveCount       int        `json:"leave_count"`
+	Status           string     `json:"status" gorm:"type:enum('draft','submitted');default:'draft'"`
+	SubmittedAt      *time.Time `json:"submitted_at"`
+	OwnerID          int        `json:"owner_id" gorm:"not null"`
+}
+
+type LeaveRequestForm struct {
+	ID          uint      `json:"id" gorm:"primary_key"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	CreatedBy   int       `json:"created_by"`
+	UpdatedBy   int       `json:"updated_by"`
+	DeletedBy   int       `json:"deleted_by"`
+	UserID      int       `json:"user_id"`
+	Type        string    `json:"type" gorm:"type:enum('sick','leave');default:'leave'"`
+	StartDate   string    `json:"start_date" gorm:"type:date"`
+	EndDate     string    `json:"end_date" gorm:"type:date"`
+	Reason      string    `json:"reason" gorm:"type:text"`
+	Attachment  string    `json:"attachment" gorm:"type:varchar(255)"`
+	Status      string    `json:"status" gorm:"type:enum('pending','approved','rejected','partial','cancelled');default:'pending'"`
+	ScheduleIDs []uint    `json:"schedule_ids"`
+}
+
+type AttendanceCorrectionForm struct {
+	AttendanceID uint   `json:"attendance_id"`
+	ClockIn      int64  `json:"clock_in"`
+	ClockOut     int64  `json:"clock_out"`
+	TimeZone     int    `json:"time_zone"`
+	Reason       string `json:"reason" gorm:"type:text"`
+	Evidence     string `json:"evidence" gorm:"type:varchar(255)"`
+}
+
+type AttendancePolicyForm struct {
+	Name    string                     `json:"name" gorm:"type:varchar(100)"`
+	Summary string                     `json:"summary" gorm:"type:text"`
+	Rules   []AttendancePolicyRuleForm `json:"rules"`
+}
+
+type AttendancePolicyRuleForm struct {
+	Type    string `json:"type" gorm:"type:enum('late','early_out','min_duration','missing_clock_out');default:'late'"`
+	Minutes int    `json:"minutes"`
+	Result  string `json:"result" gorm:"type:enum('presence','half_presence','not_presence');default:'presence'"`
+	Label   string `json:"label" gorm:"type:varchar(100)"`
+}
+
+type AttendanceGradeFormulaForm struct {
+	PresencePoint  float64 `json:"presence_point"`
+	LatePoint      float64 `json:"late_point"`
+	EarlyOutPoint  float64 `json:"early_out_point"`
+	SickPoint      float64 `json:"sick_point"`
+	LeavePoint     float64 `json:"leave_point"`
+	ExcludeExcused bool    `json:"exclude_excused"`
+	Weight         float64 `json:"weight"`
+}
+
+type GuardianForm struct {
+	ID               uint   `json:"id"` // 0 untuk kontak baru
+	Name             string `json:"name"`
+	Relation         string `json:"relation"`
+	Email            string `json:"email"`
+	Phone            string `json:"phone"`
+	PreferredChannel string `json:"preferred_channel" gorm:"type:enum('email','sms','whatsapp');default:'email'"`
+}
+
+type NotificationPreferenceForm struct {
+	Channel   string `json:"channel"` // email, gateway atau in_app
+	IsEnabled bool   `json:"is_enabled"`
+}
+
+type WebhookForm struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Events   string `json:"events"` // dipisah koma, misal attendance.clock_in,user.activated
+	Secret   string `json:"secret"` // 16 - 64 karakter, dibuat otomatis jika kosong
+	IsActive bool   `json:"is_active"`
+}
+
+type RoomForm struct {
+	ID           uint               `json:"id" gorm:"primary_key"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+	CreatedBy    int                `json:"created_by"`
+	UpdatedBy    int                `json:"updated_by"`
+	DeletedBy    int                `json:"deleted_by"`
+	Name         string             `json:"name" gorm:"type:varchar(100)"`
+	Code         string             `json:"code" gorm:"unique;type:varchar(25)"`
+	Building     string             `json:"building" gorm:"type:varchar(100)"`
+	Floor        int                `json:"floor"`
+	Capacity     int                `json:"capacity"`
+	Latitude     float64            `json:"latitude"`
+	Longitude    float64            `json:"longitude"`
+	Radius       int                `json:"radius"` //in metter
+	GeofenceZone []GeofenceZoneForm `json:"geofence_zone" gorm:"foreignKey:RoomID"`
+	Summary      string             `json:"summary" gorm:"type:text"`
+	OwnerID      int                `json:"owner_id" gorm:"not null"`
+}
+
+type AcademicCalendarForm struct {
+	ID        uint      `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedBy int       `json:"created_by"`
+	UpdatedBy int       `json:"updated_by"`
+	DeletedBy int       `json:"deleted_by"`
+	Name      string    `json:"name" gorm:"type:varchar(255)"`
+	Type      string    `json:"type" gorm:"type:enum('holiday','exam','break');default:'holiday'"`
+	StartDate string    `json:"start_date" gorm:"type:date"`
+	EndDate   string    `json:"end_date" gorm:"type:date"`
+	FacultyID uint      `json:"faculty_id"`
+	UID       string    `json:"uid" gorm:"type:varchar(255)"`
+	Summary   string    `json:"summary" gorm:"type:text"`
+	OwnerID   int       `json:"owner_id" gorm:"not null"`
 }
 
 type FacultyForm struct {
@@ -141,16 +329,17 @@ type PasswordResetTokenForm struct {
 }
 
 type SubjectForm struct {
-	ID        uint      `json:"id" gorm:"primary_key"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	CreatedBy int       `json:"created_by"`
-	UpdatedBy int       `json:"updated_by"`
-	DeletedBy int       `json:"deleted_by"`
-	Name      string    `json:"name" gorm:"type:varchar(100)"`
-	Code      string    `json:"code" gorm:"unique;type:varchar(25)"`
-	Summary   string    `json:"summary" gorm:"type:text"`
-	OwnerID   int       `json:"owner_id" gorm:"not null"`
+	ID            uint      `json:"id" gorm:"primary_key"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	CreatedBy     int       `json:"created_by"`
+	UpdatedBy     int       `json:"updated_by"`
+	DeletedBy     int       `json:"deleted_by"`
+	Name          string    `json:"name" gorm:"type:varchar(100)"`
+	Code          string    `json:"code" gorm:"unique;type:varchar(25)"`
+	Summary       string    `json:"summary" gorm:"type:text"`
+	OwnerID       int       `json:"owner_id" gorm:"not null"`
+	MinAttendance int       `json:"min_attendance"`
 }
 
 type ScheduleForm struct {
@@ -163,6 +352,9 @@ type ScheduleForm struct {
 	Name          string              `json:"name" gorm:"type:varchar(100)"`
 	Code          string              `json:"code" gorm:"unique;type:varchar(100)"`
 	QRCode        string              `json:"qr_code" gorm:"unique;type:varchar(100)"`
+	QRMode        string              `json:"qr_mode" gorm:"type:enum('static','rotating');default:'static'"`
+	QRInterval    int                 `json:"qr_interval"` // in second
+	QRSkew        *int                `json:"qr_skew"`
 	StartDate     string              `json:"start_date" gorm:"type:date"`
 	EndDate       string              `json:"end_date" gorm:"type:date"`
 	SubjectID     uint                `json:"subject_id"`
@@ -172,9 +364,14 @@ type ScheduleForm struct {
 	Latitude      float64             `json:"latitude"`
 	Longitude     float64             `json:"longitude"`
 	Radius        int                 `json:"radius"` //in metter
+	GeofenceZone  []GeofenceZoneForm  `json:"geofence_zone" gorm:"foreignKey:ScheduleID"`
+	RoomID        uint                `json:"room_id"`
+	Room          RoomForm            `json:"room"`
 	UserInRule    int                 `json:"user_in_rule" gorm:"-"`
 	OwnerID       int                 `json:"owner_id" gorm:"not null"`
 	Owner         UserForm            `json:"owner"`
+	ClockWindow   ClockWindow         `json:"clock_window"`
+	MinAttendance int                 `json:"min_attendance"`
 }
 
 type UserForm struct {
@@ -242,6 +439,7 @@ type StudentForm struct {
 	StudyProgram   StudyProgramForm `json:"study_program"`
 	Address        string           `json:"address" gorm:"type:varchar(255)"`
 	Gender         string           `json:"gender" gorm:"type:enum('laki-laki','perempuan');default:'laki-laki'"`
+	Guardians      []GuardianForm   `json:"guardians"`
 }
 
 type TeacherForm struct {
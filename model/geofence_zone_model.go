@@ -0,0 +1,102 @@
+package model
+
+import (
+	"attendance-api/common/util/geofence"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	GeofenceCircle  = "circle"
+	GeofencePolygon = "polygon"
+)
+
+// tag
+type GeofenceZone struct {
+	GormCustom
+	ScheduleID uint           `json:"schedule_id" gorm:"index" query:"schedule_id" form:"schedule_id"`
+	RoomID     uint           `json:"room_id" gorm:"index" query:"room_id" form:"room_id"`
+	Name       string         `json:"name" gorm:"type:varchar(100)" query:"name" form:"name"`
+	Type       string         `json:"type" gorm:"type:enum('circle','polygon');default:'circle'" query:"type" form:"type"`
+	Latitude   float64        `json:"latitude" query:"latitude" form:"latitude"`
+	Longitude  float64        `json:"longitude" query:"longitude" form:"longitude"`
+	Radius     int            `json:"radius" query:"radius" form:"radius"` //in metter
+	Points     GeofencePoints `json:"points" gorm:"type:text" query:"points" form:"points"`
+	OwnerID    int            `json:"owner_id" gorm:"not null" query:"owner_id" form:"owner_id"`
+}
+
+type GeofencePoints []geofence.Point
+
+func (points GeofencePoints) Value() (driver.Value, error) {
+	if points == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(points)
+	return string(data), err
+}
+
+func (points *GeofencePoints) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*points = GeofencePoints{}
+		return nil
+	default:
+		return errors.New("format titik polygon tidak valid")
+	}
+	if len(data) == 0 {
+		*points = GeofencePoints{}
+		return nil
+	}
+	return json.Unmarshal(data, points)
+}
+
+func (zone GeofenceZone) Validate() error {
+	switch zone.Type {
+	case GeofenceCircle, "":
+		if zone.Latitude == 0 || zone.Longitude == 0 || zone.Radius <= 0 {
+			return fmt.Errorf("zona %s: %v", zone.Name, "zona lingkaran harus memiliki latitude, longitude dan radius")
+		}
+	case GeofencePolygon:
+		if len(zone.Points) < 3 {
+			return fmt.Errorf("zona %s: %v", zone.Name, "zona polygon minimal memiliki 3 titik")
+		}
+	default:
+		return fmt.Errorf("zona %s: %v", zone.Name, "tipe zona harus circle atau polygon")
+	}
+	return nil
+}
+
+// Distance mengembalikan jarak titik ke batas zona dalam meter, 0 jika berada di dalam zona
+func (zone GeofenceZone) Distance(latitude float64, longitude float64) float64 {
+	point := geofence.Point{Latitude: latitude, Longitude: longitude}
+	if zone.Type == GeofencePolygon {
+		return geofence.DistanceToPolygon(point, zone.Points)
+	}
+
+	dist := geofence.Distance(geofence.Point{Latitude: zone.Latitude, Longitude: zone.Longitude}, point) - float64(zone.Radius)
+	if dist < 0 {
+		return 0
+	}
+	return dist
+}
+
+// CheckGeofenceZoneID memastikan zona yang dikirim dengan id sudah terdaftar pada current (zona milik jadwal / ruangan yang diubah)
+func CheckGeofenceZoneID(current []GeofenceZone, zones []GeofenceZone) error {
+	registered := map[uint]bool{}
+	for _, zone := range current {
+		registered[zone.ID] = true
+	}
+	for _, zone := range zones {
+		if zone.ID > 0 && !registered[zone.ID] {
+			return fmt.Errorf("zona %s: %v", zone.Name, "zona tidak terdaftar pada data ini")
+		}
+	}
+	return nil
+}
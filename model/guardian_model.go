@@ -0,0 +1,80 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
+const (
+	GuardianChannelEmail    = "email"
+	GuardianChannelSMS      = "sms"
+	GuardianChannelWhatsApp = "whatsapp"
+)
+
+// Guardian kontak orang tua / wali mahasiswa penerima ringkasan ketidakhadiran harian
+type Guardian struct {
+	GormCustom
+	StudentID        uint   `json:"student_id" gorm:"index" query:"student_id" form:"student_id"`
+	Name             string `json:"name" gorm:"type:varchar(100)" query:"name" form:"name"`
+	Relation         string `json:"relation" gorm:"type:varchar(50)" query:"relation" form:"relation"`
+	Email            string `json:"email" gorm:"type:varchar(100)" query:"email" form:"email"`
+	Phone            string `json:"phone" gorm:"type:varchar(20)" query:"phone" form:"phone"`
+	PreferredChannel string `json:"preferred_channel" gorm:"type:enum('email','sms','whatsapp');default:'email'" query:"preferred_channel" form:"preferred_channel"`
+	OptOut           bool   `json:"opt_out" gorm:"default:false" query:"opt_out" form:"opt_out"` // tidak menerima ringkasan harian
+	OptOutToken      string `json:"-" gorm:"type:varchar(64);index"`
+}
+
+// GuardianDigestRecord satu presensi tidak hadir / terlambat untuk ringkasan harian wali
+type GuardianDigestRecord struct {
+	GuardianID       uint   `json:"guardian_id"`
+	GuardianName     string `json:"guardian_name"`
+	GuardianEmail    string `json:"guardian_email"`
+	GuardianPhone    string `json:"guardian_phone"`
+	PreferredChannel string `json:"preferred_channel"`
+	OptOutToken      string `json:"-"`
+	StudentName      string `json:"student_name"`
+	NIM              string `json:"nim"`
+	ScheduleName     string `json:"schedule_name"`
+	ScheduleCode     string `json:"schedule_code"`
+	Date             string `json:"date"`
+	StatusPresence   string `json:"status_presence"`
+	Status           string `json:"status"`
+	LateIn           string `json:"late_in"`
+}
+
+func (data Guardian) Validate() error {
+	if err := validation.Validate(data.Name, validation.Required, validation.Length(1, 100)); err != nil {
+		return fmt.Errorf("nama wali: %v", err)
+	}
+	if err := validation.Validate(data.Relation, validation.Length(0, 50)); err != nil {
+		return fmt.Errorf("hubungan: %v", err)
+	}
+	if err := validation.Validate(data.Email, is.Email, validation.Length(0, 100)); err != nil {
+		return fmt.Errorf("email wali: %v", err)
+	}
+	if err := validation.Validate(data.Phone, validation.Length(0, 20)); err != nil {
+		return fmt.Errorf("no telp wali: %v", err)
+	}
+
+	switch data.PreferredChannel {
+	case GuardianChannelEmail:
+		if data.Email == "" {
+			return errors.New("email wali: harus diisi untuk kanal email")
+		}
+	case GuardianChannelSMS, GuardianChannelWhatsApp:
+		if data.Phone == "" {
+			return errors.New("no telp wali: harus diisi untuk kanal sms / whatsapp")
+		}
+	default:
+		return errors.New("preferred_channel: kanal tidak valid")
+	}
+	return nil
+}
+
+// IsLate presensi terlambat, sisanya tidak hadir
+func (data GuardianDigestRecord) IsLate() bool {
+	return data.StatusPresence == "presence"
+}
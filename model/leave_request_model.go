@@ -0,0 +1,102 @@
+package model
+
+import "time"
+
+const (
+	LeaveSick  = "sick"
+	LeaveLeave = "leave"
+
+	LeavePending   = "pending"
+	LeaveApproved  = "approved"
+	LeaveRejected  = "rejected"
+	LeavePartial   = "partial"
+	LeaveCancelled = "cancelled"
+)
+
+// LeaveRequest pengajuan izin atau sakit mahasiswa, persetujuan dilakukan per jadwal oleh dosen pemilik jadwal
+type LeaveRequest struct {
+	GormCustom
+	UserID      int                `json:"user_id" gorm:"index;not null" query:"user_id" form:"user_id"`
+	User        User               `json:"user" gorm:"foreignKey:UserID" query:"user" form:"user"`
+	Type        string             `json:"type" gorm:"type:enum('sick','leave');default:'leave'" query:"type" form:"type"`
+	StartDate   string             `json:"start_date" gorm:"type:date" query:"start_date" form:"start_date"`
+	EndDate     string             `json:"end_date" gorm:"type:date" query:"end_date" form:"end_date"`
+	Reason      string             `json:"reason" gorm:"type:text" query:"reason" form:"reason"`
+	Attachment  string             `json:"attachment" gorm:"type:varchar(255)" query:"attachment" form:"attachment"`
+	Status      string             `json:"status" gorm:"type:enum('pending','approved','rejected','partial','cancelled');default:'pending'" query:"status" form:"status"`
+	Items       []LeaveRequestItem `json:"items" gorm:"foreignKey:LeaveRequestID" query:"items" form:"items"`
+	ScheduleIDs []uint             `json:"schedule_ids,omitempty" gorm:"-" query:"schedule_ids" form:"schedule_ids"` // kosong berarti semua jadwal yang diikuti
+	ScheduleID  uint               `json:"-" gorm:"-" query:"schedule_id" form:"schedule_id"`                        // filter
+	OwnerID     int                `json:"-" gorm:"-" query:"owner_id" form:"owner_id"`                              // filter dosen penyetuju
+}
+
+// LeaveRequestItem satu jadwal yang tercakup dalam pengajuan beserta keputusannya
+type LeaveRequestItem struct {
+	GormCustom
+	LeaveRequestID uint       `json:"leave_request_id" gorm:"index;not null" query:"leave_request_id" form:"leave_request_id"`
+	ScheduleID     uint       `json:"schedule_id" gorm:"index;not null" query:"schedule_id" form:"schedule_id"`
+	Schedule       Schedule   `json:"schedule" gorm:"foreignKey:ScheduleID" query:"schedule" form:"schedule"`
+	OwnerID        int        `json:"owner_id" gorm:"index;not null" query:"owner_id" form:"owner_id"`
+	Status         string     `json:"status" gorm:"type:enum('pending','approved','rejected','cancelled');default:'pending'" query:"status" form:"status"`
+	Note           string     `json:"note" gorm:"type:text" query:"note" form:"note"`
+	DecidedBy      int        `json:"decided_by" query:"decided_by" form:"decided_by"`
+	DecidedAt      *time.Time `json:"decided_at" query:"decided_at" form:"decided_at"`
+}
+
+// LeaveRequestLog riwayat pengajuan dan setiap keputusan atas pengajuan
+type LeaveRequestLog struct {
+	GormCustom
+	LeaveRequestID uint   `json:"leave_request_id" gorm:"index;not null" query:"leave_request_id" form:"leave_request_id"`
+	ScheduleID     uint   `json:"schedule_id" query:"schedule_id" form:"schedule_id"`
+	Action         string `json:"action" gorm:"type:varchar(20)" query:"action" form:"action"`
+	Status         string `json:"status" gorm:"type:varchar(20)" query:"status" form:"status"`
+	Note           string `json:"note" gorm:"type:text" query:"note" form:"note"`
+	ActorID        int    `json:"actor_id" query:"actor_id" form:"actor_id"`
+}
+
+type LeaveRequestDecision struct {
+	Status     string `json:"status" query:"status" form:"status"` // approved atau rejected
+	Note       string `json:"note" query:"note" form:"note"`
+	ScheduleID uint   `json:"schedule_id" query:"schedule_id" form:"schedule_id"` // kosong berarti semua jadwal yang bisa diputuskan
+}
+
+func (data LeaveRequest) IsValidType() bool {
+	return data.Type == LeaveSick || data.Type == LeaveLeave
+}
+
+// GetStatusPresence status presensi yang diberikan saat pengajuan disetujui
+func (data LeaveRequest) GetStatusPresence() string {
+	if data.Type == LeaveSick {
+		return "sick"
+	}
+	return "leave_attendance"
+}
+
+func (data LeaveRequestDecision) IsValidStatus() bool {
+	return data.Status == LeaveApproved || data.Status == LeaveRejected
+}
+
+// GenerateLeaveStatus status pengajuan disimpulkan dari keputusan setiap jadwal
+func GenerateLeaveStatus(items []LeaveRequestItem) string {
+	approved, rejected := 0, 0
+	for _, item := range items {
+		switch item.Status {
+		case LeavePending:
+			return LeavePending
+		case LeaveApproved:
+			approved++
+		case LeaveRejected:
+			rejected++
+		}
+	}
+	if approved > 0 && rejected > 0 {
+		return LeavePartial
+	}
+	if approved > 0 {
+		return LeaveApproved
+	}
+	if rejected > 0 {
+		return LeaveRejected
+	}
+	return LeaveCancelled
+}
@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+const (
+	JournalDraft     = "draft"
+	JournalSubmitted = "submitted"
+)
+
+// LectureJournal berita acara perkuliahan untuk satu tanggal pertemuan jadwal
+type LectureJournal struct {
+	GormCustom
+	ScheduleID       uint       `json:"schedule_id" gorm:"index;not null" query:"schedule_id" form:"schedule_id"`
+	Schedule         Schedule   `json:"schedule" gorm:"foreignKey:ScheduleID" query:"schedule" form:"schedule"`
+	SessionID        uint       `json:"session_id" gorm:"index" query:"session_id" form:"session_id"`
+	Date             string     `json:"date" gorm:"type:date;index" query:"date" form:"date"`
+	Topic            string     `json:"topic" gorm:"type:varchar(255)" query:"topic" form:"topic"`
+	Method           string     `json:"method" gorm:"type:varchar(100)" query:"method" form:"method"`
+	Notes            string     `json:"notes" gorm:"type:text" query:"notes" form:"notes"`
+	TotalStudent     int        `json:"total_student" query:"total_student" form:"total_student"`
+	PresenceCount    int        `json:"presence_count" query:"presence_count" form:"presence_count"`
+	NotPresenceCount int        `json:"not_presence_count" query:"not_presence_count" form:"not_presence_count"`
+	SickCount        int        `json:"sick_count" query:"sick_count" form:"sick_count"`
+	LeaveCount       int        `json:"leave_count" query:"leave_count" form:"leave_count"`
+	Status           string     `json:"status" gorm:"type:enum('draft','submitted');default:'draft'" query:"status" form:"status"`
+	SubmittedAt      *time.Time `json:"submitted_at" query:"submitted_at" form:"submitted_at"`
+	OwnerID          int        `json:"owner_id" gorm:"not null" query:"owner_id" form:"owner_id"`
+	SubjectID        uint       `json:"-" gorm:"-" query:"subject_id" form:"subject_id"` // filter
+	StartDate        string     `json:"-" gorm:"-" query:"start_date" form:"start_date"` // filter
+	EndDate          string     `json:"-" gorm:"-" query:"end_date" form:"end_date"`     // filter
+}
+
+// LectureJournalCount rekap presensi mahasiswa pada tanggal jurnal
+type LectureJournalCount struct {
+	TotalStudent     int `json:"total_student"`
+	PresenceCount    int `json:"presence_count"`
+	NotPresenceCount int `json:"not_presence_count"`
+	SickCount        int `json:"sick_count"`
+	LeaveCount       int `json:"leave_count"`
+}
+
+func (data LectureJournal) IsLocked() bool {
+	return data.Status == JournalSubmitted
+}
+
+func (data *LectureJournal) ApplyCount(count LectureJournalCount) {
+	data.TotalStudent = count.TotalStudent
+	data.PresenceCount = count.PresenceCount
+	data.NotPresenceCount = count.NotPresenceCount
+	data.SickCount = count.SickCount
+	data.LeaveCount = count.LeaveCount
+}
@@ -0,0 +1,57 @@
+package model
+
+const (
+	NotificationChannelEmail   = "email"
+	NotificationChannelGateway = "gateway"
+	NotificationChannelInApp   = "in_app"
+)
+
+// NotificationChannels kanal yang dapat diatur pada preferensi pengguna
+var NotificationChannels = []string{NotificationChannelEmail, NotificationChannelGateway, NotificationChannelInApp}
+
+const (
+	NotificationEventActivation           = "activation"
+	NotificationEventForgotPassword       = "forgot_password"
+	NotificationEventAttendanceAlert      = "attendance_alert"
+	NotificationEventAttendanceAlertOwner = "attendance_alert_owner"
+	NotificationEventGuardianDigest       = "guardian_digest"
+	NotificationEventClockIn              = "clock_in"
+	NotificationEventClockOut             = "clock_out"
+	NotificationEventLeaveRequestDecided  = "leave_request_decided"
+	NotificationEventCorrectionReviewed   = "attendance_correction_reviewed"
+	NotificationEventScheduleException    = "schedule_exception"
+)
+
+// Notification pesan kanal in_app
+type Notification struct {
+	GormCustom
+	UserID  int    `json:"user_id" gorm:"index" query:"user_id" form:"user_id"`
+	Event   string `json:"event" gorm:"type:varchar(50)" query:"event" form:"event"`
+	Title   string `json:"title" gorm:"type:varchar(255)" query:"title" form:"title"`
+	Message string `json:"message" gorm:"type:text" query:"message" form:"message"`
+	IsRead  bool   `json:"is_read" query:"is_read" form:"is_read"`
+	// filter list, hanya pesan yang belum dibaca
+	UnreadOnly bool `json:"-" gorm:"-" query:"unread_only" form:"unread_only"`
+}
+
+// NotificationCount jumlah pesan belum dibaca (unread-count) atau yang baru ditandai sudah dibaca (read-all)
+type NotificationCount struct {
+	Total int64 `json:"total"`
+}
+
+// NotificationPreference kanal yang dipakai pengguna, kanal tanpa preferensi mengikuti kanal bawaan config
+type NotificationPreference struct {
+	GormCustom
+	UserID    int    `json:"user_id" gorm:"uniqueIndex:idx_notification_preference" query:"user_id" form:"user_id"`
+	Channel   string `json:"channel" gorm:"type:varchar(20);uniqueIndex:idx_notification_preference" query:"channel" form:"channel"`
+	IsEnabled bool   `json:"is_enabled" query:"is_enabled" form:"is_enabled"`
+}
+
+func IsNotificationChannel(channel string) bool {
+	for _, name := range NotificationChannels {
+		if name == channel {
+			return true
+		}
+	}
+	return false
+}
@@ -78,6 +78,229 @@ type FacultyResponseList struct {
 	Message string        `json:"message"`
 }
 
+type AcademicCalendarResponseData struct {
+	Code    int                  `json:"code"`
+	Data    AcademicCalendarForm `json:"data"`
+	Message string               `json:"message"`
+}
+
+type AcademicCalendarResponseList struct {
+	Code    int                    `json:"code"`
+	Data    []AcademicCalendarForm `json:"data"`
+	Meta    Meta                   `json:"meta"`
+	Message string                 `json:"message"`
+}
+
+type AcademicCalendarImportResponseData struct {
+	Code    int                    `json:"code"`
+	Data    AcademicCalendarImport `json:"data"`
+	Message string                 `json:"message"`
+}
+
+type ScheduleExceptionResponseData struct {
+	Code    int                   `json:"code"`
+	Data    ScheduleExceptionForm `json:"data"`
+	Message string                `json:"message"`
+}
+
+type ScheduleExceptionResponseList struct {
+	Code    int                     `json:"code"`
+	Data    []ScheduleExceptionForm `json:"data"`
+	Meta    Meta                    `json:"meta"`
+	Message string                  `json:"message"`
+}
+
+type SessionResponseData struct {
+	Code    int         `json:"code"`
+	Data    SessionForm `json:"data"`
+	Message string      `json:"message"`
+}
+
+type SessionResponseList struct {
+	Code    int           `json:"code"`
+	Data    []SessionForm `json:"data"`
+	Meta    Meta          `json:"meta"`
+	Message string        `json:"message"`
+}
+
+type SessionGenerateResponseData struct {
+	Code    int                   `json:"code"`
+	Data    SessionGenerateResult `json:"data"`
+	Message string                `json:"message"`
+}
+
+type LectureJournalResponseData struct {
+	Code    int                `json:"code"`
+	Data    LectureJournalForm `json:"data"`
+	Message string             `json:"message"`
+}
+
+type LectureJournalResponseList struct {
+	Code    int                  `json:"code"`
+	Data    []LectureJournalForm `json:"data"`
+	Meta    Meta                 `json:"meta"`
+	Message string               `json:"message"`
+}
+
+type LeaveRequestResponseData struct {
+	Code    int          `json:"code"`
+	Data    LeaveRequest `json:"data"`
+	Message string       `json:"message"`
+}
+
+type LeaveRequestResponseList struct {
+	Code    int            `json:"code"`
+	Data    []LeaveRequest `json:"data"`
+	Meta    Meta           `json:"meta"`
+	Message string         `json:"message"`
+}
+
+type LeaveRequestLogResponseList struct {
+	Code    int               `json:"code"`
+	Data    []LeaveRequestLog `json:"data"`
+	Message string            `json:"message"`
+}
+
+type AttachmentResponseData struct {
+	Code    int        `json:"code"`
+	Data    Attachment `json:"data"`
+	Message string     `json:"message"`
+}
+
+type AttachmentResponseList struct {
+	Code    int          `json:"code"`
+	Data    []Attachment `json:"data"`
+	Meta    Meta         `json:"meta"`
+	Message string       `json:"message"`
+}
+
+type AttendanceCorrectionResponseData struct {
+	Code    int                  `json:"code"`
+	Data    AttendanceCorrection `json:"data"`
+	Message string               `json:"message"`
+}
+
+type AttendanceCorrectionResponseList struct {
+	Code    int                    `json:"code"`
+	Data    []AttendanceCorrection `json:"data"`
+	Meta    Meta                   `json:"meta"`
+	Message string                 `json:"message"`
+}
+
+type AttendancePolicyResponseData struct {
+	Code    int              `json:"code"`
+	Data    AttendancePolicy `json:"data"`
+	Message string           `json:"message"`
+}
+
+type AttendancePolicyResponseList struct {
+	Code    int                `json:"code"`
+	Data    []AttendancePolicy `json:"data"`
+	Meta    Meta               `json:"meta"`
+	Message string             `json:"message"`
+}
+
+type AttendancePolicyRecomputeResponseData struct {
+	Code    int                       `json:"code"`
+	Data    AttendancePolicyRecompute `json:"data"`
+	Message string                    `json:"message"`
+}
+
+type AttendanceEligibilityResponseData struct {
+	Code    int                         `json:"code"`
+	Data    AttendanceEligibilityReport `json:"data"`
+	Message string                      `json:"message"`
+}
+
+type AttendanceGradeFormulaResponseData struct {
+	Code    int                    `json:"code"`
+	Data    AttendanceGradeFormula `json:"data"`
+	Message string                 `json:"message"`
+}
+
+type AttendanceGradeResponseData struct {
+	Code    int                   `json:"code"`
+	Data    AttendanceGradeReport `json:"data"`
+	Message string                `json:"message"`
+}
+
+type AttendanceAlertResponseList struct {
+	Code    int               `json:"code"`
+	Data    []AttendanceAlert `json:"data"`
+	Meta    Meta              `json:"meta"`
+	Message string            `json:"message"`
+}
+
+type GuardianResponseList struct {
+	Code    int        `json:"code"`
+	Data    []Guardian `json:"data"`
+	Message string     `json:"message"`
+}
+
+type NotificationPreferenceResponseList struct {
+	Code    int                      `json:"code"`
+	Data    []NotificationPreference `json:"data"`
+	Message string                   `json:"message"`
+}
+
+type NotificationResponseData struct {
+	Code    int          `json:"code"`
+	Data    Notification `json:"data"`
+	Message string       `json:"message"`
+}
+
+type NotificationResponseList struct {
+	Code    int            `json:"code"`
+	Data    []Notification `json:"data"`
+	Meta    Meta           `json:"meta"`
+	Message string         `json:"message"`
+}
+
+type NotificationCountResponseData struct {
+	Code    int               `json:"code"`
+	Data    NotificationCount `json:"data"`
+	Message string            `json:"message"`
+}
+
+type WebhookResponseData struct {
+	Code    int     `json:"code"`
+	Data    Webhook `json:"data"`
+	Message string  `json:"message"`
+}
+
+type WebhookResponseList struct {
+	Code    int       `json:"code"`
+	Data    []Webhook `json:"data"`
+	Meta    Meta      `json:"meta"`
+	Message string    `json:"message"`
+}
+
+type WebhookDeliveryResponseData struct {
+	Code    int             `json:"code"`
+	Data    WebhookDelivery `json:"data"`
+	Message string          `json:"message"`
+}
+
+type WebhookDeliveryResponseList struct {
+	Code    int               `json:"code"`
+	Data    []WebhookDelivery `json:"data"`
+	Meta    Meta              `json:"meta"`
+	Message string            `json:"message"`
+}
+
+type RoomResponseData struct {
+	Code    int      `json:"code"`
+	Data    RoomForm `json:"data"`
+	Message string   `json:"message"`
+}
+
+type RoomResponseList struct {
+	Code    int        `json:"code"`
+	Data    []RoomForm `json:"data"`
+	Meta    Meta       `json:"meta"`
+	Message string     `json:"message"`
+}
+
 type MajorResponseData struct {
 	Code    int       `json:"code"`
 	Data    MajorForm `json:"data"`
@@ -136,6 +359,24 @@ type ScheduleResponseData struct {
 	Message string       `json:"message"`
 }
 
+type ScheduleConflictResponseData struct {
+	Code    int                    `json:"code"`
+	Data    ScheduleConflictResult `json:"data"`
+	Message string                 `json:"message"`
+}
+
+type ScheduleQRCodeResponseData struct {
+	Code    int            `json:"code"`
+	Data    ScheduleQRCode `json:"data"`
+	Message string         `json:"message"`
+}
+
+type ScheduleStreamTicketResponseData struct {
+	Code    int                  `json:"code"`
+	Data    ScheduleStreamTicket `json:"data"`
+	Message string               `json:"message"`
+}
+
 type ScheduleResponseList struct {
 	Code    int            `json:"code"`
 	Data    []ScheduleForm `json:"data"`
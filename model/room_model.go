@@ -0,0 +1,41 @@
+package model
+
+// tag
+type Room struct {
+	GormCustom
+	Name         string         `json:"name" gorm:"type:varchar(100)" query:"name" form:"name"`
+	Code         string         `json:"code" gorm:"unique;type:varchar(25)" query:"code" form:"code"`
+	Building     string         `json:"building" gorm:"type:varchar(100)" query:"building" form:"building"`
+	Floor        int            `json:"floor" query:"floor" form:"floor"`
+	Capacity     int            `json:"capacity" query:"capacity" form:"capacity"`
+	Latitude     float64        `json:"latitude" query:"latitude" form:"latitude"`
+	Longitude    float64        `json:"longitude" query:"longitude" form:"longitude"`
+	Radius       int            `json:"radius" query:"radius" form:"radius"` //in metter
+	GeofenceZone []GeofenceZone `json:"geofence_zone" gorm:"foreignKey:RoomID" query:"geofence_zone" form:"geofence_zone"`
+	Summary      string         `json:"summary" gorm:"type:text" query:"summary" form:"summary"`
+	OwnerID      int            `json:"owner_id" gorm:"not null" query:"owner_id" form:"owner_id"`
+}
+
+// GetGeofenceZones mengembalikan zona ruangan, koordinat dan radius ruangan dianggap sebagai satu zona lingkaran
+func (data Room) GetGeofenceZones() (zones []GeofenceZone) {
+	if data.Radius != 0 && data.Latitude != 0 && data.Longitude != 0 {
+		zones = append(zones, GeofenceZone{
+			RoomID:    data.ID,
+			Name:      data.Name,
+			Type:      GeofenceCircle,
+			Latitude:  data.Latitude,
+			Longitude: data.Longitude,
+			Radius:    data.Radius,
+		})
+	}
+	return append(zones, data.GeofenceZone...)
+}
+
+func (data Room) GetListGeofenceZoneID() (zoneID []int) {
+	for _, zone := range data.GeofenceZone {
+		if zone.ID > 0 {
+			zoneID = append(zoneID, int(zone.ID))
+		}
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package model
+
+import "attendance-api/common/util/converter"
+
+const (
+	ConflictTeacher = "teacher"
+	ConflictRoom    = "room"
+	ConflictStudent = "student"
+)
+
+// ScheduleSlot satu blok waktu mingguan dari sebuah jadwal
+type ScheduleSlot struct {
+	Day       string `json:"day"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+type ScheduleConflict struct {
+	Type            string `json:"type"`
+	ScheduleID      uint   `json:"schedule_id"`
+	ScheduleName    string `json:"schedule_name"`
+	ScheduleCode    string `json:"schedule_code"`
+	DailyScheduleID uint   `json:"daily_schedule_id"`
+	Day             string `json:"day"`
+	StartTime       string `json:"start_time"`
+	EndTime         string `json:"end_time"`
+	StartDate       string `json:"start_date"`
+	EndDate         string `json:"end_date"`
+	OwnerID         int    `json:"owner_id"`
+	RoomID          uint   `json:"room_id,omitempty"`
+	UserID          int    `json:"user_id,omitempty"`
+}
+
+type ScheduleConflictResult struct {
+	HasConflict bool               `json:"has_conflict"`
+	Conflicts   []ScheduleConflict `json:"conflicts"`
+}
+
+// GetSlots mengubah daily schedule menjadi slot waktu lengkap dengan rentang tanggal jadwal
+func (data Schedule) GetSlots() (slots []ScheduleSlot) {
+	for _, daily := range data.DailySchedule {
+		slots = append(slots, data.GetSlot(daily))
+	}
+	return
+}
+
+func (data Schedule) GetSlot(daily DailySchedule) ScheduleSlot {
+	return ScheduleSlot{
+		Day:       daily.Name,
+		StartTime: daily.StartTime,
+		EndTime:   daily.EndTime,
+		StartDate: converter.GetOnlyDateString(data.StartDate),
+		EndDate:   converter.GetOnlyDateString(data.EndDate),
+	}
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"attendance-api/common/util/converter"
+	"fmt"
+)
+
+const (
+	ExceptionCancel     = "cancel"
+	ExceptionReschedule = "reschedule"
+	ExceptionMakeup     = "makeup"
+)
+
+// ScheduleException perubahan satu pertemuan pada tanggal tertentu.
+// Date adalah tanggal pertemuan yang dibatalkan / dipindah, NewDate adalah tanggal pertemuan berlangsung
+// (sama dengan Date untuk pembatalan dan kelas pengganti). DailyScheduleID membatasi pembatalan / pemindahan
+// ke satu blok jadwal harian, 0 berarti seluruh blok pada tanggal tersebut.
+type ScheduleException struct {
+	GormCustom
+	ScheduleID      uint   `json:"schedule_id" gorm:"index;not null" query:"schedule_id" form:"schedule_id"`
+	DailyScheduleID uint   `json:"daily_schedule_id" gorm:"index;not null;default:0" query:"daily_schedule_id" form:"daily_schedule_id"`
+	Type            string `json:"type" gorm:"type:enum('cancel','reschedule','makeup');default:'cancel'" query:"type" form:"type"`
+	Date            string `json:"date" gorm:"type:date;index" query:"date" form:"date"`
+	NewDate         string `json:"new_date" gorm:"type:date;index" query:"new_date" form:"new_date"`
+	StartTime       string `json:"start_time" gorm:"type:varchar(5)" query:"start_time" form:"start_time"`
+	EndTime         string `json:"end_time" gorm:"type:varchar(5)" query:"end_time" form:"end_time"`
+	RoomID          *uint  `json:"room_id" query:"room_id" form:"room_id"`
+	Room            *Room  `json:"room,omitempty" gorm:"foreignKey:RoomID"`
+	Reason          string `json:"reason" gorm:"type:text" query:"reason" form:"reason"`
+	OwnerID         int    `json:"owner_id" gorm:"not null" query:"owner_id" form:"owner_id"`
+}
+
+func (data ScheduleException) IsValidType() bool {
+	switch data.Type {
+	case ExceptionCancel, ExceptionReschedule, ExceptionMakeup:
+		return true
+	}
+	return false
+}
+
+// IsMeeting pengecualian yang menghasilkan pertemuan (pindah jadwal atau kelas pengganti)
+func (data ScheduleException) IsMeeting() bool {
+	return data.Type == ExceptionReschedule || data.Type == ExceptionMakeup
+}
+
+// IsRemoving pengecualian membatalkan atau memindah blok jadwal harian pada tanggal Date
+func (data ScheduleException) IsRemoving(dailyScheduleID uint) bool {
+	if data.Type != ExceptionCancel && data.Type != ExceptionReschedule {
+		return false
+	}
+	return data.DailyScheduleID == 0 || data.DailyScheduleID == dailyScheduleID
+}
+
+// GetMeetingDate tanggal pertemuan berlangsung
+func (data ScheduleException) GetMeetingDate() string {
+	if data.NewDate == "" {
+		return converter.GetOnlyDateString(data.Date)
+	}
+	return converter.GetOnlyDateString(data.NewDate)
+}
+
+// Describe perubahan pertemuan untuk pesan notifikasi
+func (data ScheduleException) Describe() string {
+	date := converter.GetOnlyDateString(data.Date)
+	switch data.Type {
+	case ExceptionReschedule:
+		return fmt.Sprintf("pertemuan tanggal %s dipindah ke tanggal %s pukul %s - %s", date, data.GetMeetingDate(), data.StartTime, data.EndTime)
+	case ExceptionMakeup:
+		return fmt.Sprintf("kelas pengganti tanggal %s pukul %s - %s", date, data.StartTime, data.EndTime)
+	}
+	return fmt.Sprintf("pertemuan tanggal %s dibatalkan", date)
+}
+
+// GetDailySchedule jam pertemuan pengganti dalam bentuk jadwal harian tanpa id
+func (data ScheduleException) GetDailySchedule() DailySchedule {
+	exception := data
+	return DailySchedule{
+		ScheduleID: data.ScheduleID,
+		Name:       converter.GetDayNameFromDateString(data.GetMeetingDate()),
+		StartTime:  data.StartTime,
+		EndTime:    data.EndTime,
+		OwnerID:    data.OwnerID,
+		Exception:  &exception,
+	}
+}
+
+// ApplyException memakai ruangan pertemuan pengganti untuk pengecekan geofence jika blok yang dipilih
+// berasal dari data pengecualian
+func (data *Schedule) ApplyException(dailySchedule DailySchedule) {
+	exception := dailySchedule.Exception
+	if exception != nil && exception.RoomID != nil && exception.Room != nil && exception.Room.ID > 0 {
+		data.RoomID = exception.RoomID
+		data.Room = exception.Room
+	}
+}
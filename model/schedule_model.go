@@ -2,6 +2,7 @@ package model
 
 import (
 	"attendance-api/common/util/converter"
+	"attendance-api/common/util/myqr"
 	"fmt"
 	"math"
 	"sync"
@@ -14,6 +15,10 @@ type Schedule struct {
 	Name          string          `json:"name" gorm:"type:varchar(100)" query:"name" form:"name"`
 	Code          string          `json:"code" gorm:"unique;type:varchar(100)" query:"code" form:"code"`
 	QRCode        string          `json:"qr_code" gorm:"unique;type:varchar(100)" query:"qr_code" form:"qr_code"`
+	QRMode        string          `json:"qr_mode" gorm:"type:enum('static','rotating');default:'static'" query:"qr_mode" form:"qr_mode"`
+	QRSecret      string          `json:"-" gorm:"type:varchar(64)"`
+	QRInterval    int             `json:"qr_interval" query:"qr_interval" form:"qr_interval"` // in second
+	QRSkew        *int            `json:"qr_skew" query:"qr_skew" form:"qr_skew"`             // jumlah window toleransi, kosong berarti bawaan
 	StartDate     string          `json:"start_date" gorm:"type:date" query:"start_date" form:"start_date"`
 	EndDate       string          `json:"end_date" gorm:"type:date" query:"end_date" form:"end_date"`
 	SubjectID     uint            `json:"subject_id" query:"subject_id" form:"subject_id"`
@@ -23,9 +28,18 @@ type Schedule struct {
 	Latitude      float64         `json:"latitude" query:"latitude" form:"latitude"`
 	Longitude     float64         `json:"longitude" query:"longitude" form:"longitude"`
 	Radius        int             `json:"radius" query:"radius" form:"radius"` //in metter
+	GeofenceZone  []GeofenceZone  `json:"geofence_zone" gorm:"foreignKey:ScheduleID" query:"geofence_zone" form:"geofence_zone"`
+	RoomID        *uint           `json:"room_id" query:"room_id" form:"room_id"`
+	Room          *Room           `json:"room,omitempty" gorm:"foreignKey:RoomID" query:"room" form:"room"`
 	UserInRule    int             `json:"user_in_rule" gorm:"-" query:"user_in_rule" form:"user_in_rule"`
 	OwnerID       uint            `json:"owner_id" gorm:"not null" query:"owner_id" form:"owner_id"`
 	Owner         User            `json:"owner" gorm:"foreignKey:OwnerID;references:ID" query:"owner" form:"owner"`
+	// ClockWindow bawaan untuk jadwal harian yang tidak mengisi batas waktu absen
+	ClockWindow ClockWindow `json:"clock_window" gorm:"embedded;embeddedPrefix:default_" query:"clock_window" form:"clock_window"`
+	// AttendancePolicyID kebijakan presensi jadwal, 0 berarti mengikuti kebijakan fakultas dosen pengampu
+	AttendancePolicyID uint `json:"attendance_policy_id" gorm:"index" query:"attendance_policy_id" form:"attendance_policy_id"`
+	// MinAttendance persentase minimal kehadiran untuk ujian, 0 berarti mengikuti mata kuliah
+	MinAttendance int `json:"min_attendance" query:"min_attendance" form:"min_attendance"`
 }
 
 func (data Schedule) IsTodaySchedule() (isTodaySchedule bool) {
@@ -63,34 +77,56 @@ func (data Schedule) IsTodaySchedule() (isTodaySchedule bool) {
 }
 
 func (data Schedule) InRange(latitudeCheck float64, longitudeCheck float64) (isPassed bool) {
-	if data.Radius != 0 && data.Latitude != 0 && data.Longitude != 0 {
-		radlat1 := float64(math.Pi * data.Latitude / 180)
-		radlat2 := float64(math.Pi * latitudeCheck / 180)
+	isPassed, _, _ = data.CheckGeofence(latitudeCheck, longitudeCheck)
+	return
+}
 
-		theta := float64(data.Longitude - longitudeCheck)
-		radtheta := float64(math.Pi * theta / 180)
+// GetGeofenceZones mengembalikan seluruh zona jadwal, field radius lama dianggap sebagai satu zona lingkaran.
+// Jika jadwal memiliki ruangan, zona ruangan menggantikan field radius lama
+func (data Schedule) GetGeofenceZones() (zones []GeofenceZone) {
+	if data.Room != nil && data.Room.ID > 0 {
+		zones = append(zones, data.Room.GetGeofenceZones()...)
+	} else if data.Radius != 0 && data.Latitude != 0 && data.Longitude != 0 {
+		zones = append(zones, GeofenceZone{
+			ScheduleID: data.ID,
+			Name:       data.Name,
+			Type:       GeofenceCircle,
+			Latitude:   data.Latitude,
+			Longitude:  data.Longitude,
+			Radius:     data.Radius,
+		})
+	}
+	return append(zones, data.GeofenceZone...)
+}
 
-		dist := math.Sin(radlat1)*math.Sin(radlat2) + math.Cos(radlat1)*math.Cos(radlat2)*math.Cos(radtheta)
-		if dist > 1 {
-			dist = 1
-		}
+// CheckGeofence lolos jika titik berada di salah satu zona, jika tidak mengembalikan zona terdekat dan jaraknya
+func (data Schedule) CheckGeofence(latitudeCheck float64, longitudeCheck float64) (isPassed bool, nearestZone GeofenceZone, distance float64) {
+	zones := data.GetGeofenceZones()
+	if len(zones) == 0 {
+		return true, nearestZone, 0
+	}
 
-		dist = math.Acos(dist)
-		dist = dist * 180 / math.Pi
-		dist = dist * 60 * 1.1515
-		// Distance in kilometer
-		dist = dist * 1.609344
-		// Distance in meter
-		dist = dist * 1000
-		if dist <= float64(data.Radius) {
-			return true
-		} else {
-			return false
+	distance = math.MaxFloat64
+	for _, zone := range zones {
+		dist := zone.Distance(latitudeCheck, longitudeCheck)
+		if dist == 0 {
+			return true, zone, 0
+		}
+		if dist < distance {
+			nearestZone = zone
+			distance = dist
 		}
-	} else {
-		return true
 	}
+	return false, nearestZone, distance
+}
 
+// GeofenceError membuat pesan kesalahan berisi zona terdekat dan jaraknya
+func (data Schedule) GeofenceError(prefix string, latitudeCheck float64, longitudeCheck float64) error {
+	isPassed, nearestZone, distance := data.CheckGeofence(latitudeCheck, longitudeCheck)
+	if isPassed {
+		return nil
+	}
+	return fmt.Errorf("%s, zona terdekat %s berjarak %.0f meter", prefix, nearestZone.Name, distance)
 }
 
 func (data Schedule) GetListDailyScheduleID() (dailyScheduleID []int) {
@@ -107,3 +143,94 @@ func (data Schedule) GetListDailyScheduleID() (dailyScheduleID []int) {
 	}
 	return
 }
+
+func (data Schedule) GetListGeofenceZoneID() (zoneID []int) {
+	for _, zone := range data.GeofenceZone {
+		if zone.ID > 0 {
+			zoneID = append(zoneID, int(zone.ID))
+		}
+	}
+	return
+}
+
+func (data Schedule) IsRotatingQR() bool {
+	return data.QRMode == myqr.ModeRotating
+}
+
+func (data Schedule) GetQRInterval() int {
+	if data.QRInterval > 0 {
+		return data.QRInterval
+	}
+	return myqr.DefaultRotatingInterval
+}
+
+// GetQRSkew toleransi window kode qr berputar, 0 berarti hanya window saat ini yang berlaku
+func (data Schedule) GetQRSkew() int {
+	if data.QRSkew != nil {
+		return *data.QRSkew
+	}
+	return myqr.DefaultRotatingSkew
+}
+
+// CurrentQRCode mengembalikan payload kode qr yang harus ditampilkan pada waktu t
+func (data Schedule) CurrentQRCode(t time.Time) (qrCode string, expiredAt time.Time) {
+	if data.IsRotatingQR() {
+		return myqr.GenerateRotating(data.QRCode, data.QRSecret, t, data.GetQRInterval()), myqr.WindowExpiredAt(t, data.GetQRInterval())
+	}
+	return data.QRCode, time.Time{}
+}
+
+// IsValidQRCode mengecek payload hasil scan terhadap mode kode qr jadwal
+func (data Schedule) IsValidQRCode(payload string, t time.Time) bool {
+	if !data.IsRotatingQR() {
+		return payload == data.QRCode
+	}
+	code, token := myqr.SplitPayload(payload)
+	if code != data.QRCode {
+		return false
+	}
+	return myqr.ValidateRotating(data.QRSecret, token, t, data.GetQRInterval(), data.GetQRSkew())
+}
+
+func (data Schedule) GetQRCodeData(t time.Time) ScheduleQRCode {
+	qrCode, expiredAt := data.CurrentQRCode(t)
+	result := ScheduleQRCode{
+		ScheduleID: data.ID,
+		QRMode:     data.QRMode,
+		QRCode:     qrCode,
+	}
+	if data.IsRotatingQR() {
+		result.Interval = data.GetQRInterval()
+		result.ValidUntil = expiredAt.UnixMilli()
+	}
+	return result
+}
+
+type ScheduleQRCode struct {
+	ScheduleID uint   `json:"schedule_id"`
+	QRMode     string `json:"qr_mode"`
+	QRCode     string `json:"qr_code"`
+	Interval   int    `json:"interval"`    // in second
+	ValidUntil int64  `json:"valid_until"` // in millis
+}
+
+type ScheduleClockInCount struct {
+	ScheduleID      uint   `json:"schedule_id"`
+	DailyScheduleID uint   `json:"daily_schedule_id"`
+	Date            string `json:"date"`
+	Count           int    `json:"count"`
+	Total           int    `json:"total"`
+}
+
+// ScheduleStreamTicket tiket untuk query ticket pada stream jadwal
+type ScheduleStreamTicket struct {
+	ScheduleID uint   `json:"schedule_id"`
+	Ticket     string `json:"ticket"`
+	Expired    int64  `json:"expired"` // in second
+}
+
+// ScheduleStream hanya untuk dokumentasi event pada stream jadwal
+type ScheduleStream struct {
+	QRCode       ScheduleQRCode       `json:"qr_code"`
+	ClockInCount ScheduleClockInCount `json:"clock_in_count"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"attendance-api/common/util/myqr"
+	"testing"
+)
+
+func TestScheduleGetQRSkew(t *testing.T) {
+	zero, two := 0, 2
+	tests := []struct {
+		name     string
+		qrSkew   *int
+		expected int
+	}{
+		{"kosong memakai bawaan", nil, myqr.DefaultRotatingSkew},
+		{"tanpa toleransi", &zero, 0},
+		{"diisi", &two, 2},
+	}
+	for _, test := range tests {
+		if result := (Schedule{QRSkew: test.qrSkew}).GetQRSkew(); result != test.expected {
+			t.Errorf("%s: expected %d, got %d", test.name, test.expected, result)
+		}
+	}
+}
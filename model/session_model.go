@@ -0,0 +1,183 @@
+package model
+
+import (
+	"attendance-api/common/util/converter"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	SessionPlanned   = "planned"
+	SessionHeld      = "held"
+	SessionCancelled = "cancelled"
+)
+
+// Session satu pertemuan konkret dari jadwal, Number adalah urutan pertemuan yang tidak dibatalkan
+type Session struct {
+	GormCustom
+	ScheduleID          uint   `json:"schedule_id" gorm:"index;not null" query:"schedule_id" form:"schedule_id"`
+	DailyScheduleID     uint   `json:"daily_schedule_id" gorm:"index" query:"daily_schedule_id" form:"daily_schedule_id"`
+	ScheduleExceptionID uint   `json:"schedule_exception_id" gorm:"index" query:"schedule_exception_id" form:"schedule_exception_id"`
+	Number              int    `json:"number" query:"number" form:"number"`
+	Date                string `json:"date" gorm:"type:date;index" query:"date" form:"date"`
+	StartTime           string `json:"start_time" gorm:"type:varchar(5)" query:"start_time" form:"start_time"`
+	EndTime             string `json:"end_time" gorm:"type:varchar(5)" query:"end_time" form:"end_time"`
+	RoomID              *uint  `json:"room_id" query:"room_id" form:"room_id"`
+	Status              string `json:"status" gorm:"type:enum('planned','held','cancelled');default:'planned'" query:"status" form:"status"`
+	Topic               string `json:"topic" gorm:"type:varchar(255)" query:"topic" form:"topic"`
+	Notes               string `json:"notes" gorm:"type:text" query:"notes" form:"notes"`
+	SubstituteID        int    `json:"substitute_id" query:"substitute_id" form:"substitute_id"`
+	OwnerID             int    `json:"owner_id" gorm:"not null" query:"owner_id" form:"owner_id"`
+	Orphaned            bool   `json:"orphaned" gorm:"not null;default:false" query:"orphaned" form:"orphaned"` // tidak lagi ada pada rencana pertemuan tetapi disimpan karena berisi data
+}
+
+type SessionGenerateResult struct {
+	ScheduleID uint `json:"schedule_id"`
+	Total      int  `json:"total"`
+	Created    int  `json:"created"`
+	Updated    int  `json:"updated"`
+	Deleted    int  `json:"deleted"`
+	Kept       int  `json:"kept"`
+}
+
+func (data Session) IsValidStatus() bool {
+	switch data.Status {
+	case SessionPlanned, SessionHeld, SessionCancelled:
+		return true
+	}
+	return false
+}
+
+// SessionKey identitas pertemuan yang tetap sama walaupun jadwal digenerate ulang
+func (data Session) SessionKey() string {
+	if data.DailyScheduleID == 0 && data.ScheduleExceptionID > 0 {
+		return fmt.Sprintf("exception-%d", data.ScheduleExceptionID)
+	}
+	return fmt.Sprintf("%s-%d", converter.GetOnlyDateString(data.Date), data.DailyScheduleID)
+}
+
+// RegenerateStatus status pertemuan saat jadwal digenerate ulang, pertemuan yang sudah berlangsung dan pertemuan
+// yang dibatalkan manual tetap dipertahankan kecuali data pengecualian membatalkannya
+func (data Session) RegenerateStatus(planStatus string) string {
+	if planStatus != SessionPlanned {
+		return planStatus
+	}
+	if data.Status == SessionHeld {
+		return SessionHeld
+	}
+	// pertemuan rutin yang dibatalkan pengecualian menyimpan id pengecualian, selain itu dibatalkan manual
+	if data.Status == SessionCancelled && (data.DailyScheduleID == 0 || data.ScheduleExceptionID == 0) {
+		return SessionCancelled
+	}
+	return planStatus
+}
+
+// HasContent pertemuan yang sudah berisi data tidak boleh dihapus saat generate ulang
+func (data Session) HasContent() bool {
+	return data.Status == SessionHeld || data.Topic != "" || data.Notes != "" || data.SubstituteID > 0
+}
+
+// GenerateSessionPlan menyusun daftar pertemuan dari jadwal mingguan, pengecualian jadwal dan kalender akademik
+func GenerateSessionPlan(schedule Schedule, exceptions []ScheduleException, calendars []AcademicCalendar) (sessions []Session) {
+	startDate, err := time.Parse("2006-01-02", converter.GetOnlyDateString(schedule.StartDate))
+	if err != nil {
+		return nil
+	}
+	endDate, err := time.Parse("2006-01-02", converter.GetOnlyDateString(schedule.EndDate))
+	if err != nil {
+		return nil
+	}
+
+	removed := map[string][]ScheduleException{}
+	for _, exception := range exceptions {
+		if exception.Type == ExceptionCancel || exception.Type == ExceptionReschedule {
+			date := converter.GetOnlyDateString(exception.Date)
+			removed[date] = append(removed[date], exception)
+		}
+	}
+
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		dateString := date.Format("2006-01-02")
+		if IsNonTeachingDate(calendars, dateString) {
+			continue
+		}
+		for _, daily := range schedule.DailySchedule {
+			if daily.Name != converter.GetDayName(date) {
+				continue
+			}
+			session := Session{
+				ScheduleID:      schedule.ID,
+				DailyScheduleID: daily.ID,
+				Date:            dateString,
+				StartTime:       daily.StartTime,
+				EndTime:         daily.EndTime,
+				RoomID:          schedule.RoomID,
+				Status:          SessionPlanned,
+				OwnerID:         int(schedule.OwnerID),
+			}
+			if exception, ok := findRemovingException(removed[dateString], daily.ID); ok {
+				if exception.Type == ExceptionReschedule {
+					// pertemuan pindahan dibuat dari data pengecualian
+					continue
+				}
+				session.Status = SessionCancelled
+				session.ScheduleExceptionID = exception.ID
+			}
+			sessions = append(sessions, session)
+		}
+	}
+
+	for _, exception := range exceptions {
+		if !exception.IsMeeting() {
+			continue
+		}
+		roomID := schedule.RoomID
+		if exception.RoomID != nil {
+			roomID = exception.RoomID
+		}
+		sessions = append(sessions, Session{
+			ScheduleID:          schedule.ID,
+			ScheduleExceptionID: exception.ID,
+			Date:                exception.GetMeetingDate(),
+			StartTime:           exception.StartTime,
+			EndTime:             exception.EndTime,
+			RoomID:              roomID,
+			Status:              SessionPlanned,
+			OwnerID:             int(schedule.OwnerID),
+		})
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		if sessions[i].Date != sessions[j].Date {
+			return sessions[i].Date < sessions[j].Date
+		}
+		return sessions[i].StartTime < sessions[j].StartTime
+	})
+
+	NumberSessions(sessions)
+	return sessions
+}
+
+// NumberSessions mengisi urutan pertemuan (sudah urut tanggal dan jam), pertemuan yang dibatalkan bernomor 0
+func NumberSessions(sessions []Session) {
+	number := 0
+	for i := range sessions {
+		if sessions[i].Status == SessionCancelled {
+			sessions[i].Number = 0
+			continue
+		}
+		number++
+		sessions[i].Number = number
+	}
+}
+
+// findRemovingException pengecualian yang membatalkan / memindah blok jadwal harian tersebut
+func findRemovingException(exceptions []ScheduleException, dailyScheduleID uint) (ScheduleException, bool) {
+	for _, exception := range exceptions {
+		if exception.IsRemoving(dailyScheduleID) {
+			return exception, true
+		}
+	}
+	return ScheduleException{}, false
+}
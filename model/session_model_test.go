@@ -0,0 +1,85 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestGenerateSessionPlan(t *testing.T) {
+	schedule := Schedule{
+		GormCustom: GormCustom{ID: 1},
+		StartDate:  "2024-03-04",
+		EndDate:    "2024-03-18",
+		DailySchedule: []DailySchedule{
+			{GormCustom: GormCustom{ID: 2}, Name: "monday", StartTime: "13:00", EndTime: "15:00"},
+			{GormCustom: GormCustom{ID: 1}, Name: "monday", StartTime: "08:00", EndTime: "10:00"},
+		},
+	}
+	exceptions := []ScheduleException{
+		// hanya blok siang yang dibatalkan, blok pagi tetap berlangsung
+		{GormCustom: GormCustom{ID: 10}, Type: ExceptionCancel, Date: "2024-03-04", DailyScheduleID: 2},
+		// seluruh blok dipindah ke hari rabu
+		{GormCustom: GormCustom{ID: 11}, Type: ExceptionReschedule, Date: "2024-03-11", NewDate: "2024-03-13", StartTime: "10:00", EndTime: "12:00"},
+	}
+	calendars := []AcademicCalendar{
+		{Type: CalendarHoliday, StartDate: "2024-03-18", EndDate: "2024-03-18"},
+	}
+
+	expected := []struct {
+		key    string
+		status string
+		number int
+	}{
+		{"2024-03-04-1", SessionPlanned, 1},
+		{"2024-03-04-2", SessionCancelled, 0},
+		{"exception-11", SessionPlanned, 2},
+	}
+	sessions := GenerateSessionPlan(schedule, exceptions, calendars)
+	if len(sessions) != len(expected) {
+		t.Fatalf("expected %d sessions, got %d: %+v", len(expected), len(sessions), sessions)
+	}
+	for i, session := range sessions {
+		if session.SessionKey() != expected[i].key || session.Status != expected[i].status || session.Number != expected[i].number {
+			t.Errorf("session %d: expected %s %s %d, got %s %s %d", i, expected[i].key, expected[i].status, expected[i].number,
+				session.SessionKey(), session.Status, session.Number)
+		}
+	}
+	if sessions[1].ScheduleExceptionID != 10 {
+		t.Errorf("cancelled session: expected schedule_exception_id 10, got %d", sessions[1].ScheduleExceptionID)
+	}
+}
+
+func TestSessionRegenerateStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    Session
+		planStatus string
+		expected   string
+	}{
+		{"rencana dibatalkan pengecualian", Session{DailyScheduleID: 1, Status: SessionHeld}, SessionCancelled, SessionCancelled},
+		{"sudah berlangsung", Session{DailyScheduleID: 1, Status: SessionHeld}, SessionPlanned, SessionHeld},
+		{"belum berlangsung", Session{DailyScheduleID: 1, Status: SessionPlanned}, SessionPlanned, SessionPlanned},
+		{"dibatalkan manual", Session{DailyScheduleID: 1, Status: SessionCancelled}, SessionPlanned, SessionCancelled},
+		// pengecualian pembatalan sudah dihapus sehingga pertemuan kembali direncanakan
+		{"pengecualian dihapus", Session{DailyScheduleID: 1, ScheduleExceptionID: 10, Status: SessionCancelled}, SessionPlanned, SessionPlanned},
+		{"kelas pengganti dibatalkan manual", Session{ScheduleExceptionID: 11, Status: SessionCancelled}, SessionPlanned, SessionCancelled},
+	}
+	for _, test := range tests {
+		if result := test.current.RegenerateStatus(test.planStatus); result != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestNumberSessions(t *testing.T) {
+	sessions := []Session{
+		{Status: SessionHeld, Number: 5},
+		{Status: SessionCancelled, Number: 2},
+		{Status: SessionPlanned},
+	}
+	NumberSessions(sessions)
+	for i, expected := range []int{1, 0, 2} {
+		if sessions[i].Number != expected {
+			t.Errorf("session %d: expected number %d, got %d", i, expected, sessions[i].Number)
+		}
+	}
+}
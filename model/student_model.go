@@ -19,6 +19,8 @@ type Student struct {
 	Avatar         string       `json:"avatar" gorm:"-" query:"avatar" form:"avatar"`
 	ScheduleID     int          `json:"schedule_id" gorm:"-" query:"schedule_id" form:"schedule_id"`
 	OwnerID        int          `json:"owner_id" gorm:"-" query:"owner_id" form:"owner_id"`
+	// Guardians kontak wali, nil saat update berarti kontak wali tidak diubah
+	Guardians []Guardian `json:"guardians" gorm:"foreignKey:StudentID" query:"guardians" form:"guardians"`
 }
 
 type UserStudent struct {
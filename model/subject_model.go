@@ -7,4 +7,6 @@ type Subject struct {
 	Code    string `json:"code" gorm:"unique;type:varchar(25)" query:"code" form:"code"`
 	Summary string `json:"summary" gorm:"type:text" query:"summary" form:"summary"`
 	OwnerID int    `json:"owner_id" gorm:"not null" query:"owner_id" form:"owner_id"`
+	// MinAttendance persentase minimal kehadiran untuk ujian, 0 berarti mengikuti bawaan config
+	MinAttendance int `json:"min_attendance" query:"min_attendance" form:"min_attendance"`
 }
@@ -100,6 +100,15 @@ type UserTokenPayload struct {
 	Expired  int64  `json:"expired"`
 }
 
+// StreamTicketPayload isi tiket stream jadwal, IsSuperAdmin diisi middleware saat tiket divalidasi
+type StreamTicketPayload struct {
+	UserID       uint   `json:"user_id"`
+	AuthUUID     string `json:"auth_uuid"`
+	ScheduleID   uint   `json:"schedule_id"`
+	Expired      int64  `json:"expired"`
+	IsSuperAdmin bool   `json:"-"`
+}
+
 type TokenData struct {
 	AccessToken         string `json:"access_token"`
 	ExpiredAccessToken  int64  `json:"expired_access_token"`
@@ -10,23 +10,28 @@ type UserSchedule struct {
 }
 
 type MySchedule struct {
-	ScheduleID   uint    `json:"schedule_id" query:"schedule_id"`
-	ScheduleName string  `json:"schedule_name" query:"schedule_name"`
-	ScheduleCode string  `json:"schedule_code" query:"schedule_code"`
-	QRCode       string  `json:"qr_code" query:"qr_code"`
-	OwnerID      int     `json:"owner_id" query:"owner_id"`
-	Teacher      string  `json:"teacher" query:"teacher"`
-	StartDate    string  `json:"start_date" query:"start_date"`
-	EndDate      string  `json:"end_date" query:"end_date"`
-	SubjectID    uint    `json:"subject_id" query:"subject_id"`
-	SubjectName  string  `json:"subject_name" query:"subject_name"`
-	SubjectCode  string  `json:"subject_code" query:"subject_code"`
-	StartTime    string  `json:"start_time" query:"start_time"`
-	EndTime      string  `json:"end_time" query:"end_time"`
-	LateDuration int     `json:"late_duration" query:"late_duration"`
-	Latitude     float64 `json:"latitude" query:"latitude"`
-	Longitude    float64 `json:"longitude" query:"longitude"`
-	Radius       int     `json:"radius" query:"radius"`
+	ScheduleID    uint    `json:"schedule_id" query:"schedule_id"`
+	ScheduleName  string  `json:"schedule_name" query:"schedule_name"`
+	ScheduleCode  string  `json:"schedule_code" query:"schedule_code"`
+	QRCode        string  `json:"qr_code" query:"qr_code"`
+	OwnerID       int     `json:"owner_id" query:"owner_id"`
+	Teacher       string  `json:"teacher" query:"teacher"`
+	StartDate     string  `json:"start_date" query:"start_date"`
+	EndDate       string  `json:"end_date" query:"end_date"`
+	SubjectID     uint    `json:"subject_id" query:"subject_id"`
+	SubjectName   string  `json:"subject_name" query:"subject_name"`
+	SubjectCode   string  `json:"subject_code" query:"subject_code"`
+	StartTime     string  `json:"start_time" query:"start_time"`
+	EndTime       string  `json:"end_time" query:"end_time"`
+	LateDuration  int     `json:"late_duration" query:"late_duration"`
+	Latitude      float64 `json:"latitude" query:"latitude"`
+	Longitude     float64 `json:"longitude" query:"longitude"`
+	Radius        int     `json:"radius" query:"radius"`
+	RoomID        uint    `json:"room_id" query:"room_id"`
+	ExceptionType string  `json:"exception_type" query:"exception_type"`
+	IsCancelled   bool    `json:"is_cancelled" query:"is_cancelled"`
+	NewDate       string  `json:"new_date" query:"new_date"`
+	Reason        string  `json:"reason" query:"reason"`
 }
 
 type ListMySchedule struct {
@@ -40,23 +45,25 @@ type MyScheduleFilter struct {
 }
 
 type TodaySchedule struct {
-	ScheduleID     uint   `json:"schedule_id"`
-	ScheduleName   string `json:"schedule_name"`
-	ScheduleCode   string `json:"schedule_code"`
-	QRCode         string `json:"qr_code" query:"qr_code"`
-	OwnerID        int    `json:"owner_id" query:"owner_id"`
-	Teacher        string `json:"teacher" query:"teacher"`
-	SubjectID      uint   `json:"subject_id"`
-	SubjectName    string `json:"subject_name"`
-	StartTime      string `json:"start_time"`
-	EndTime        string `json:"end_time"`
-	AttendanceID   uint   `json:"attendance_id"`
-	ClockInMillis  int64  `json:"clock_in_millis"`
-	ClockOutMillis int64  `json:"clock_out_millis"`
-	ClockIn        string `json:"clock_in"`
-	ClockOut       string `json:"clock_out"`
-	TimeZoneIn     int    `json:"time_zone_in"`
-	TimeZoneOut    int    `json:"time_zone_out"`
-	LocationIn     string `json:"location_in"`
-	LocationOut    string `json:"location_out"`
+	ScheduleID      uint   `json:"schedule_id"`
+	ScheduleName    string `json:"schedule_name"`
+	ScheduleCode    string `json:"schedule_code"`
+	QRCode          string `json:"qr_code" query:"qr_code"`
+	OwnerID         int    `json:"owner_id" query:"owner_id"`
+	Teacher         string `json:"teacher" query:"teacher"`
+	SubjectID       uint   `json:"subject_id"`
+	SubjectName     string `json:"subject_name"`
+	StartTime       string `json:"start_time"`
+	EndTime         string `json:"end_time"`
+	ExceptionType   string `json:"exception_type"`
+	DailyScheduleID uint   `json:"daily_schedule_id"`
+	AttendanceID    uint   `json:"attendance_id"`
+	ClockInMillis   int64  `json:"clock_in_millis"`
+	ClockOutMillis  int64  `json:"clock_out_millis"`
+	ClockIn         string `json:"clock_in"`
+	ClockOut        string `json:"clock_out"`
+	TimeZoneIn      int    `json:"time_zone_in"`
+	TimeZoneOut     int    `json:"time_zone_out"`
+	LocationIn      string `json:"location_in"`
+	LocationOut     string `json:"location_out"`
 }
@@ -0,0 +1,226 @@
+package model
+
+import (
+	"attendance-api/common/util/converter"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/spf13/viper"
+)
+
+const (
+	WebhookEventClockIn       = "attendance.clock_in"
+	WebhookEventClockOut      = "attendance.clock_out"
+	WebhookEventStatusChanged = "attendance.status_changed"
+	WebhookEventUserActivated = "user.activated"
+)
+
+// WebhookEvents tipe event yang dapat dilanggan webhook
+var WebhookEvents = []string{WebhookEventClockIn, WebhookEventClockOut, WebhookEventStatusChanged, WebhookEventUserActivated}
+
+const (
+	WebhookDeliveryPending = "pending"
+	WebhookDeliverySuccess = "success"
+	WebhookDeliveryFailed  = "failed"
+)
+
+// Webhook langganan sistem luar terhadap event presensi, Events dipisah koma
+type Webhook struct {
+	GormCustom
+	Name         string     `json:"name" gorm:"type:varchar(100)" query:"name" form:"name"`
+	URL          string     `json:"url" gorm:"type:varchar(255)" query:"url" form:"url"`
+	Events       string     `json:"events" gorm:"type:varchar(255)" query:"events" form:"events"`
+	Secret       string     `json:"secret" gorm:"type:varchar(64)"` // kunci HMAC-SHA256, dibuat otomatis jika kosong
+	IsActive     bool       `json:"is_active" gorm:"default:true" query:"is_active" form:"is_active"`
+	FailureCount int        `json:"failure_count" query:"failure_count" form:"failure_count"` // pengiriman gagal berturut-turut setelah semua percobaan ulang
+	DisabledAt   *time.Time `json:"disabled_at" query:"disabled_at" form:"disabled_at"`
+}
+
+// WebhookDelivery log satu pengiriman event ke webhook beserta percobaan ulangnya
+type WebhookDelivery struct {
+	GormCustom
+	WebhookID    uint       `json:"webhook_id" gorm:"index;not null" query:"webhook_id" form:"webhook_id"`
+	Webhook      Webhook    `json:"webhook" gorm:"foreignKey:WebhookID" query:"webhook" form:"webhook"`
+	Event        string     `json:"event" gorm:"type:varchar(50);index" query:"event" form:"event"`
+	Payload      string     `json:"payload" gorm:"type:text" query:"payload" form:"payload"`
+	Status       string     `json:"status" gorm:"type:enum('pending','success','failed');default:'pending';index" query:"status" form:"status"`
+	Attempt      int        `json:"attempt" query:"attempt" form:"attempt"`
+	ResponseCode int        `json:"response_code" query:"response_code" form:"response_code"`
+	ResponseBody string     `json:"response_body" gorm:"type:text" query:"response_body" form:"response_body"`
+	Error        string     `json:"error" gorm:"type:text" query:"error" form:"error"`
+	NextRetryAt  *time.Time `json:"next_retry_at" query:"next_retry_at" form:"next_retry_at"`
+	DeliveredAt  *time.Time `json:"delivered_at" query:"delivered_at" form:"delivered_at"`
+	RedeliveryOf uint       `json:"redelivery_of" query:"redelivery_of" form:"redelivery_of"` // id pengiriman asal untuk pengiriman ulang manual
+}
+
+// WebhookPayload isi request yang dikirim ke URL webhook
+type WebhookPayload struct {
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookAttendanceData data event attendance.*, tanpa relasi agar data pengguna tidak ikut terkirim
+type WebhookAttendanceData struct {
+	ID             uint    `json:"id"`
+	UserID         int     `json:"user_id"`
+	ScheduleID     uint    `json:"schedule_id"`
+	SessionID      uint    `json:"session_id"`
+	Date           string  `json:"date"`
+	ClockIn        int64   `json:"clock_in"`
+	ClockOut       int64   `json:"clock_out"`
+	Status         string  `json:"status"`
+	StatusPresence string  `json:"status_presence"`
+	LateIn         string  `json:"late_in"`
+	EarlyOut       string  `json:"early_out"`
+	PresenceScore  float64 `json:"presence_score"`
+}
+
+// WebhookUserData data event user.*
+type WebhookUserData struct {
+	ID        uint   `json:"id"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+}
+
+func NewWebhookAttendanceData(attendance Attendance) WebhookAttendanceData {
+	return WebhookAttendanceData{
+		ID:             attendance.ID,
+		UserID:         attendance.UserID,
+		ScheduleID:     attendance.ScheduleID,
+		SessionID:      attendance.SessionID,
+		Date:           converter.GetOnlyDateString(attendance.Date),
+		ClockIn:        attendance.ClockIn,
+		ClockOut:       attendance.ClockOut,
+		Status:         attendance.Status,
+		StatusPresence: attendance.StatusPresence,
+		LateIn:         attendance.LateIn,
+		EarlyOut:       attendance.EarlyOut,
+		PresenceScore:  attendance.PresenceScore,
+	}
+}
+
+func NewWebhookUserData(user User) WebhookUserData {
+	return WebhookUserData{
+		ID:        user.ID,
+		Username:  user.Username,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+	}
+}
+
+// WebhookRule kebijakan percobaan ulang pengiriman webhook
+type WebhookRule struct {
+	MaxAttempt   int // jumlah percobaan maksimal termasuk pengiriman pertama
+	BackoffBase  int // jeda percobaan ulang pertama dalam detik, berlipat dua setiap percobaan
+	BackoffMax   int // jeda maksimal dalam detik
+	DisableAfter int // webhook dinonaktifkan setelah pengiriman gagal berturut-turut sebanyak ini
+	Timeout      int // batas waktu request dalam detik
+}
+
+func DefaultWebhookRule() WebhookRule {
+	return WebhookRule{
+		MaxAttempt:   6,
+		BackoffBase:  30,
+		BackoffMax:   3600,
+		DisableAfter: 5,
+		Timeout:      10,
+	}
+}
+
+// NewWebhookRule kebijakan dari config, key yang kosong memakai nilai bawaan
+func NewWebhookRule(config *viper.Viper) WebhookRule {
+	rule := DefaultWebhookRule()
+	if config == nil {
+		return rule
+	}
+	if config.IsSet("max_attempt") {
+		rule.MaxAttempt = config.GetInt("max_attempt")
+	}
+	if config.IsSet("backoff_base") {
+		rule.BackoffBase = config.GetInt("backoff_base")
+	}
+	if config.IsSet("backoff_max") {
+		rule.BackoffMax = config.GetInt("backoff_max")
+	}
+	if config.IsSet("disable_after") {
+		rule.DisableAfter = config.GetInt("disable_after")
+	}
+	if config.IsSet("timeout") {
+		rule.Timeout = config.GetInt("timeout")
+	}
+	return rule
+}
+
+// Backoff jeda sebelum percobaan berikutnya setelah percobaan ke-attempt gagal
+func (rule WebhookRule) Backoff(attempt int) time.Duration {
+	delay := rule.BackoffBase
+	for i := 1; i < attempt && delay < rule.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > rule.BackoffMax {
+		delay = rule.BackoffMax
+	}
+	return time.Duration(delay) * time.Second
+}
+
+func (data Webhook) Validate() error {
+	if err := validation.Validate(data.Name, validation.Required, validation.Length(1, 100)); err != nil {
+		return fmt.Errorf("nama: %v", err)
+	}
+	if err := validation.Validate(data.URL, validation.Required, is.URL, validation.Length(1, 255)); err != nil {
+		return fmt.Errorf("url: %v", err)
+	}
+	if !strings.HasPrefix(data.URL, "http://") && !strings.HasPrefix(data.URL, "https://") {
+		return errors.New("url: harus diawali http:// atau https://")
+	}
+	if err := validation.Validate(data.Secret, validation.Length(16, 64)); err != nil {
+		return fmt.Errorf("secret: %v", err)
+	}
+
+	events := data.EventList()
+	if len(events) == 0 {
+		return fmt.Errorf("events: minimal satu event, pilihan %s", strings.Join(WebhookEvents, ", "))
+	}
+	for _, event := range events {
+		if !IsWebhookEvent(event) {
+			return fmt.Errorf("events: event %s tidak valid, pilihan %s", event, strings.Join(WebhookEvents, ", "))
+		}
+	}
+	return nil
+}
+
+// OriginID id pengiriman asal, dikirim pada header X-Webhook-Delivery sehingga pengiriman ulang dapat dikenali penerima
+func (data WebhookDelivery) OriginID() uint {
+	if data.RedeliveryOf > 0 {
+		return data.RedeliveryOf
+	}
+	return data.ID
+}
+
+// EventList daftar event yang dilanggan
+func (data Webhook) EventList() []string {
+	var events []string
+	for _, event := range strings.Split(data.Events, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func IsWebhookEvent(event string) bool {
+	for _, name := range WebhookEvents {
+		if name == event {
+			return true
+		}
+	}
+	return false
+}
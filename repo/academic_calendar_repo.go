@@ -0,0 +1,185 @@
+package repo
+
+import (
+	"attendance-api/model"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type AcademicCalendarRepo interface {
+	CreateAcademicCalendar(academicCalendar model.AcademicCalendar) (model.AcademicCalendar, error)
+	RetrieveAcademicCalendar(id int) (model.AcademicCalendar, error)
+	RetrieveAcademicCalendarByUID(uid string, facultyID int) (model.AcademicCalendar, error)
+	UpdateAcademicCalendar(id int, academicCalendar model.AcademicCalendar) (model.AcademicCalendar, error)
+	UpdateAcademicCalendarFaculty(id int, facultyID int) error
+	DeleteAcademicCalendar(id int) error
+	ListAcademicCalendar(academicCalendar model.AcademicCalendar, pagination model.Pagination) ([]model.AcademicCalendar, error)
+	ListAcademicCalendarMeta(academicCalendar model.AcademicCalendar, pagination model.Pagination) (model.Meta, error)
+	ListNonTeachingDayBySchedule(scheduleID int, startDate string, endDate string) ([]model.AcademicCalendar, error)
+	CheckIsNonTeachingDay(scheduleID int, date string) (isExist bool)
+}
+
+type academicCalendarRepo struct {
+	db *gorm.DB
+}
+
+func NewAcademicCalendarRepo(db *gorm.DB) AcademicCalendarRepo {
+	return &academicCalendarRepo{db: db}
+}
+
+func (r academicCalendarRepo) CreateAcademicCalendar(academicCalendar model.AcademicCalendar) (model.AcademicCalendar, error) {
+	if err := r.db.Table("academic_calendars").Create(&academicCalendar).Error; err != nil {
+		return model.AcademicCalendar{}, err
+	}
+
+	return academicCalendar, nil
+}
+
+func (r academicCalendarRepo) RetrieveAcademicCalendar(id int) (model.AcademicCalendar, error) {
+	var academicCalendar model.AcademicCalendar
+	if err := r.db.First(&academicCalendar, id).Error; err != nil {
+		return model.AcademicCalendar{}, err
+	}
+	return academicCalendar, nil
+}
+
+func (r academicCalendarRepo) RetrieveAcademicCalendarByUID(uid string, facultyID int) (model.AcademicCalendar, error) {
+	var academicCalendar model.AcademicCalendar
+	if err := r.db.Model(&model.AcademicCalendar{}).Where("uid = ? AND faculty_id = ?", uid, facultyID).First(&academicCalendar).Error; err != nil {
+		return model.AcademicCalendar{}, err
+	}
+	return academicCalendar, nil
+}
+
+func (r academicCalendarRepo) UpdateAcademicCalendar(id int, academicCalendar model.AcademicCalendar) (model.AcademicCalendar, error) {
+	if err := r.db.Model(&model.AcademicCalendar{}).Where("id = ?", id).Updates(&academicCalendar).Error; err != nil {
+		return model.AcademicCalendar{}, err
+	}
+	return academicCalendar, nil
+}
+
+func (r academicCalendarRepo) UpdateAcademicCalendarFaculty(id int, facultyID int) error {
+	if err := r.db.Model(&model.AcademicCalendar{}).Where("id = ?", id).Update("faculty_id", facultyID).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r academicCalendarRepo) DeleteAcademicCalendar(id int) error {
+	if err := r.db.Delete(&model.AcademicCalendar{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r academicCalendarRepo) ListAcademicCalendar(academicCalendar model.AcademicCalendar, pagination model.Pagination) ([]model.AcademicCalendar, error) {
+	var academicCalendars []model.AcademicCalendar
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	query := r.db.Table("academic_calendars").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterAcademicCalendar(query, academicCalendar)
+	query = SearchAcademicCalendar(query, pagination.Search)
+	query = query.Find(&academicCalendars)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+
+	return academicCalendars, nil
+}
+
+func (r academicCalendarRepo) ListAcademicCalendarMeta(academicCalendar model.AcademicCalendar, pagination model.Pagination) (model.Meta, error) {
+	var academicCalendars []model.AcademicCalendar
+	var totalRecord int
+	var totalPage int
+
+	queryTotal := r.db.Model(&model.AcademicCalendar{}).Select("count(*)")
+	queryTotal = FilterAcademicCalendar(queryTotal, academicCalendar)
+	queryTotal = SearchAcademicCalendar(queryTotal, pagination.Search)
+	queryTotal = queryTotal.Scan(&totalRecord)
+	if err := queryTotal.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	totalPage = int(totalRecord / pagination.Limit)
+	if totalRecord%pagination.Limit > 0 {
+		totalPage += 1
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	query := r.db.Table("academic_calendars").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterAcademicCalendar(query, academicCalendar)
+	query = SearchAcademicCalendar(query, pagination.Search)
+	query = query.Find(&academicCalendars)
+	if err := query.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	meta := model.Meta{
+		CurrentPage:   pagination.Page,
+		TotalPage:     totalPage,
+		TotalRecord:   totalRecord,
+		CurrentRecord: len(academicCalendars),
+	}
+	return meta, nil
+}
+
+// ListNonTeachingDayBySchedule mengambil kalender kampus dan kalender fakultas dosen pengampu yang beririsan dengan rentang tanggal
+func (r academicCalendarRepo) ListNonTeachingDayBySchedule(scheduleID int, startDate string, endDate string) ([]model.AcademicCalendar, error) {
+	var academicCalendars []model.AcademicCalendar
+	query := r.db.Table("academic_calendars ac").
+		Where("ac.start_date <= ? AND ac.end_date >= ?", endDate, startDate).
+		Where(QueryCalendarFaculty("ac", "?"), scheduleID).
+		Order("ac.start_date asc").
+		Find(&academicCalendars)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+	return academicCalendars, nil
+}
+
+func (r academicCalendarRepo) CheckIsNonTeachingDay(scheduleID int, date string) (isExist bool) {
+	query := r.db.Table("academic_calendars ac").Select("count(*) > 0").
+		Where("? BETWEEN ac.start_date AND ac.end_date", date).
+		Where(QueryCalendarFaculty("ac", "?"), scheduleID)
+	if err := query.Find(&isExist).Error; err != nil {
+		return false
+	}
+	return
+}
+
+// QueryCalendarFaculty kondisi kalender berlaku untuk jadwal: kalender kampus (faculty_id = 0) atau fakultas dosen pengampu jadwal
+func QueryCalendarFaculty(alias string, scheduleColumn string) string {
+	return fmt.Sprintf("(%[1]s.faculty_id = 0 OR %[1]s.faculty_id IN (SELECT t.faculty_id FROM teachers t JOIN schedules s ON s.owner_id = t.user_id WHERE s.id = %[2]s))", alias, scheduleColumn)
+}
+
+// QueryTeachingDay kondisi untuk mengecualikan data presensi yang jatuh pada hari libur, minggu ujian atau masa jeda
+func QueryTeachingDay(dateColumn string, scheduleColumn string) string {
+	return fmt.Sprintf("NOT EXISTS (SELECT 1 FROM academic_calendars acd WHERE DATE(%s) BETWEEN acd.start_date AND acd.end_date AND %s)", dateColumn, QueryCalendarFaculty("acd", scheduleColumn))
+}
+
+func FilterAcademicCalendar(query *gorm.DB, academicCalendar model.AcademicCalendar) *gorm.DB {
+	if academicCalendar.Name != "" {
+		query = query.Where("name LIKE ?", "%"+academicCalendar.Name+"%")
+	}
+	if academicCalendar.Type != "" {
+		query = query.Where("type = ?", academicCalendar.Type)
+	}
+	if academicCalendar.FacultyID > 0 {
+		query = query.Where("faculty_id = ?", academicCalendar.FacultyID)
+	}
+	if academicCalendar.StartDate != "" {
+		query = query.Where("end_date >= ?", academicCalendar.StartDate)
+	}
+	if academicCalendar.EndDate != "" {
+		query = query.Where("start_date <= ?", academicCalendar.EndDate)
+	}
+	return query
+}
+
+func SearchAcademicCalendar(query *gorm.DB, search string) *gorm.DB {
+	if search != "" {
+		query = query.Where("name LIKE ? OR summary LIKE ? ", "%"+search+"%", "%"+search+"%")
+	}
+	return query
+}
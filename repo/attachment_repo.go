@@ -0,0 +1,152 @@
+package repo
+
+import (
+	"attendance-api/model"
+
+	"gorm.io/gorm"
+)
+
+type AttachmentRepo interface {
+	CreateAttachment(attachment model.Attachment) (model.Attachment, error)
+	RetrieveAttachment(id int) (model.Attachment, error)
+	DeleteAttachment(id int) error
+	ListAttachment(attachment model.Attachment, pagination model.Pagination) ([]model.Attachment, error)
+	ListAttachmentMeta(attachment model.Attachment, pagination model.Pagination) (model.Meta, error)
+	CountAttachmentByKey(key string) (total int)
+	CheckRecordAccess(recordType string, recordID int, userID int) (haveAccess bool)
+}
+
+type attachmentRepo struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepo(db *gorm.DB) AttachmentRepo {
+	return &attachmentRepo{db: db}
+}
+
+func (r attachmentRepo) CreateAttachment(attachment model.Attachment) (model.Attachment, error) {
+	if err := r.db.Table("attachments").Create(&attachment).Error; err != nil {
+		return model.Attachment{}, err
+	}
+
+	return attachment, nil
+}
+
+func (r attachmentRepo) RetrieveAttachment(id int) (model.Attachment, error) {
+	var attachment model.Attachment
+	if err := r.db.First(&attachment, id).Error; err != nil {
+		return model.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+func (r attachmentRepo) DeleteAttachment(id int) error {
+	if err := r.db.Delete(&model.Attachment{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r attachmentRepo) ListAttachment(attachment model.Attachment, pagination model.Pagination) ([]model.Attachment, error) {
+	var attachments []model.Attachment
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	query := r.db.Table("attachments").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterAttachment(query, attachment)
+	query = SearchAttachment(query, pagination.Search)
+	query = query.Find(&attachments)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (r attachmentRepo) ListAttachmentMeta(attachment model.Attachment, pagination model.Pagination) (model.Meta, error) {
+	var attachments []model.Attachment
+	var totalRecord int
+	var totalPage int
+
+	queryTotal := r.db.Model(&model.Attachment{}).Select("count(*)")
+	queryTotal = FilterAttachment(queryTotal, attachment)
+	queryTotal = SearchAttachment(queryTotal, pagination.Search)
+	queryTotal = queryTotal.Scan(&totalRecord)
+	if err := queryTotal.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	totalPage = int(totalRecord / pagination.Limit)
+	if totalRecord%pagination.Limit > 0 {
+		totalPage += 1
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	query := r.db.Table("attachments").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterAttachment(query, attachment)
+	query = SearchAttachment(query, pagination.Search)
+	query = query.Find(&attachments)
+	if err := query.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	meta := model.Meta{
+		CurrentPage:   pagination.Page,
+		TotalPage:     totalPage,
+		TotalRecord:   totalRecord,
+		CurrentRecord: len(attachments),
+	}
+	return meta, nil
+}
+
+// CountAttachmentByKey file dengan hash yang sama dipakai bersama, file di storage baru dihapus jika sudah tidak dipakai
+func (r attachmentRepo) CountAttachmentByKey(key string) (total int) {
+	if err := r.db.Table("attachments").Select("count(*)").Where("`key` = ?", key).Find(&total).Error; err != nil {
+		return 0
+	}
+	return
+}
+
+// CheckRecordAccess pemilik data dan dosen pemilik jadwal terkait boleh mengakses lampiran data tersebut
+func (r attachmentRepo) CheckRecordAccess(recordType string, recordID int, userID int) (haveAccess bool) {
+	var query *gorm.DB
+	switch recordType {
+	case model.AttachmentUser:
+		return recordID == userID
+	case model.AttachmentAttendance:
+		query = r.db.Table("attendances").Select("count(*) > 0").
+			Joins("JOIN schedules ON schedules.id = attendances.schedule_id").
+			Where("attendances.id = ? AND (attendances.user_id = ? OR schedules.owner_id = ?)", recordID, userID, userID)
+	case model.AttachmentLeaveRequest:
+		query = r.db.Table("leave_requests").Select("count(*) > 0").
+			Where("leave_requests.id = ? AND (leave_requests.user_id = ? OR EXISTS (SELECT 1 FROM leave_request_items lra WHERE lra.leave_request_id = leave_requests.id AND lra.owner_id = ?))", recordID, userID, userID)
+	case model.AttachmentCorrection:
+		query = r.db.Table("attendance_corrections").Select("count(*) > 0").
+			Where("id = ? AND (user_id = ? OR owner_id = ?)", recordID, userID, userID)
+	default:
+		return false
+	}
+	if err := query.Find(&haveAccess).Error; err != nil {
+		return false
+	}
+	return
+}
+
+func FilterAttachment(query *gorm.DB, attachment model.Attachment) *gorm.DB {
+	if attachment.RecordType != "" {
+		query = query.Where("record_type = ?", attachment.RecordType)
+	}
+	if attachment.RecordID > 0 {
+		query = query.Where("record_id = ?", attachment.RecordID)
+	}
+	if attachment.OwnerID > 0 {
+		query = query.Where("owner_id = ?", attachment.OwnerID)
+	}
+	return query
+}
+
+func SearchAttachment(query *gorm.DB, search string) *gorm.DB {
+	if search != "" {
+		query = query.Where("name LIKE ?", "%"+search+"%")
+	}
+	return query
+}
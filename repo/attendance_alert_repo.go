@@ -0,0 +1,140 @@
+package repo
+
+import (
+	"attendance-api/model"
+
+	"gorm.io/gorm"
+)
+
+type AttendanceAlertRepo interface {
+	CreateAttendanceAlert(attendanceAlert model.AttendanceAlert) (model.AttendanceAlert, error)
+	UpdateAttendanceAlertNotified(id int, notifiedStudent bool, notifiedOwner bool) error
+	RecoverAttendanceAlert(userID int, scheduleID int, level string) error
+	ListAttendanceAlert(attendanceAlert model.AttendanceAlert, pagination model.Pagination) ([]model.AttendanceAlert, error)
+	ListAttendanceAlertMeta(attendanceAlert model.AttendanceAlert, pagination model.Pagination) (model.Meta, error)
+	ListActiveScheduleID() ([]int, error)
+	CheckIsActive(userID int, scheduleID int, level string) (isActive bool)
+}
+
+type attendanceAlertRepo struct {
+	db *gorm.DB
+}
+
+func NewAttendanceAlertRepo(db *gorm.DB) AttendanceAlertRepo {
+	return &attendanceAlertRepo{db: db}
+}
+
+func (r attendanceAlertRepo) CreateAttendanceAlert(attendanceAlert model.AttendanceAlert) (model.AttendanceAlert, error) {
+	if err := r.db.Omit("User", "Schedule").Create(&attendanceAlert).Error; err != nil {
+		return model.AttendanceAlert{}, err
+	}
+
+	return attendanceAlert, nil
+}
+
+func (r attendanceAlertRepo) UpdateAttendanceAlertNotified(id int, notifiedStudent bool, notifiedOwner bool) error {
+	return r.db.Model(&model.AttendanceAlert{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"notified_student": notifiedStudent,
+		"notified_owner":   notifiedOwner,
+	}).Error
+}
+
+// RecoverAttendanceAlert menutup peringatan aktif saat kehadiran kembali di atas ambang,
+// sehingga penurunan berikutnya dianggap pelanggaran ambang baru
+func (r attendanceAlertRepo) RecoverAttendanceAlert(userID int, scheduleID int, level string) error {
+	return r.db.Model(&model.AttendanceAlert{}).
+		Where("user_id = ? AND schedule_id = ? AND level = ? AND is_recovered = ?", userID, scheduleID, level, false).
+		Update("is_recovered", true).Error
+}
+
+func (r attendanceAlertRepo) ListAttendanceAlert(attendanceAlert model.AttendanceAlert, pagination model.Pagination) ([]model.AttendanceAlert, error) {
+	var attendanceAlerts []model.AttendanceAlert
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	query := r.db.Table("attendance_alerts").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = PreloadAttendanceAlert(query)
+	query = FilterAttendanceAlert(query, attendanceAlert)
+	query = query.Find(&attendanceAlerts)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+
+	return attendanceAlerts, nil
+}
+
+func (r attendanceAlertRepo) ListAttendanceAlertMeta(attendanceAlert model.AttendanceAlert, pagination model.Pagination) (model.Meta, error) {
+	var attendanceAlerts []model.AttendanceAlert
+	var totalRecord int
+	var totalPage int
+
+	queryTotal := r.db.Model(&model.AttendanceAlert{}).Select("count(*)")
+	queryTotal = FilterAttendanceAlert(queryTotal, attendanceAlert)
+	queryTotal = queryTotal.Scan(&totalRecord)
+	if err := queryTotal.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	totalPage = int(totalRecord / pagination.Limit)
+	if totalRecord%pagination.Limit > 0 {
+		totalPage += 1
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	query := r.db.Table("attendance_alerts").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterAttendanceAlert(query, attendanceAlert)
+	query = query.Find(&attendanceAlerts)
+	if err := query.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	meta := model.Meta{
+		CurrentPage:   pagination.Page,
+		TotalPage:     totalPage,
+		TotalRecord:   totalRecord,
+		CurrentRecord: len(attendanceAlerts),
+	}
+	return meta, nil
+}
+
+// ListActiveScheduleID jadwal yang masih berjalan hari ini
+func (r attendanceAlertRepo) ListActiveScheduleID() ([]int, error) {
+	var scheduleIDs []int
+	if err := r.db.Table("schedules").
+		Select("id").
+		Where("CURDATE() BETWEEN DATE(start_date) AND DATE(end_date)").
+		Scan(&scheduleIDs).Error; err != nil {
+		return nil, err
+	}
+	return scheduleIDs, nil
+}
+
+func (r attendanceAlertRepo) CheckIsActive(userID int, scheduleID int, level string) (isActive bool) {
+	if err := r.db.Table("attendance_alerts").Select("count(*) > 0").
+		Where("user_id = ? AND schedule_id = ? AND level = ? AND is_recovered = ?", userID, scheduleID, level, false).
+		Find(&isActive).Error; err != nil {
+		return false
+	}
+	return
+}
+
+func PreloadAttendanceAlert(query *gorm.DB) *gorm.DB {
+	query = query.Preload("User")
+	query = query.Preload("Schedule")
+	return query
+}
+
+func FilterAttendanceAlert(query *gorm.DB, attendanceAlert model.AttendanceAlert) *gorm.DB {
+	if attendanceAlert.UserID > 0 {
+		query = query.Where("user_id = ?", attendanceAlert.UserID)
+	}
+	if attendanceAlert.ScheduleID > 0 {
+		query = query.Where("schedule_id = ?", attendanceAlert.ScheduleID)
+	}
+	if attendanceAlert.Level != "" {
+		query = query.Where("level = ?", attendanceAlert.Level)
+	}
+	if attendanceAlert.OwnerID > 0 {
+		query = query.Where("owner_id = ?", attendanceAlert.OwnerID)
+	}
+	return query
+}
@@ -0,0 +1,268 @@
+package repo
+
+import (
+	"attendance-api/model"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AttendanceCorrectionRepo interface {
+	CreateAttendanceCorrection(attendanceCorrection model.AttendanceCorrection) (model.AttendanceCorrection, error)
+	RetrieveAttendanceCorrection(id int) (model.AttendanceCorrection, error)
+	RetrieveAttendanceCorrectionByUser(id int, userID int) (model.AttendanceCorrection, error)
+	RetrieveAttendanceCorrectionByOwner(id int, ownerID int) (model.AttendanceCorrection, error)
+	CancelAttendanceCorrection(id int, userID int) (model.AttendanceCorrection, error)
+	ReviewAttendanceCorrection(id int, actorID int, review model.AttendanceCorrectionReview, corrected model.Attendance) (model.AttendanceCorrection, error)
+	ListAttendanceCorrection(attendanceCorrection model.AttendanceCorrection, pagination model.Pagination) ([]model.AttendanceCorrection, error)
+	ListAttendanceCorrectionMeta(attendanceCorrection model.AttendanceCorrection, pagination model.Pagination) (model.Meta, error)
+	CheckIsExistPending(attendanceID int) (isExist bool)
+}
+
+type attendanceCorrectionRepo struct {
+	db *gorm.DB
+}
+
+func NewAttendanceCorrectionRepo(db *gorm.DB) AttendanceCorrectionRepo {
+	return &attendanceCorrectionRepo{db: db}
+}
+
+func (r attendanceCorrectionRepo) CreateAttendanceCorrection(attendanceCorrection model.AttendanceCorrection) (model.AttendanceCorrection, error) {
+	if err := r.db.Table("attendance_corrections").Omit("Attendance", "User").Create(&attendanceCorrection).Error; err != nil {
+		return model.AttendanceCorrection{}, err
+	}
+
+	return r.RetrieveAttendanceCorrection(int(attendanceCorrection.ID))
+}
+
+func (r attendanceCorrectionRepo) RetrieveAttendanceCorrection(id int) (model.AttendanceCorrection, error) {
+	var attendanceCorrection model.AttendanceCorrection
+	if err := PreloadAttendanceCorrection(r.db).First(&attendanceCorrection, id).Error; err != nil {
+		return model.AttendanceCorrection{}, err
+	}
+	return attendanceCorrection, nil
+}
+
+func (r attendanceCorrectionRepo) RetrieveAttendanceCorrectionByUser(id int, userID int) (model.AttendanceCorrection, error) {
+	var attendanceCorrection model.AttendanceCorrection
+	if err := PreloadAttendanceCorrection(r.db.Model(&model.AttendanceCorrection{})).Where("id = ? AND user_id = ?", id, userID).First(&attendanceCorrection).Error; err != nil {
+		return model.AttendanceCorrection{}, err
+	}
+	return attendanceCorrection, nil
+}
+
+func (r attendanceCorrectionRepo) RetrieveAttendanceCorrectionByOwner(id int, ownerID int) (model.AttendanceCorrection, error) {
+	var attendanceCorrection model.AttendanceCorrection
+	if err := PreloadAttendanceCorrection(r.db.Model(&model.AttendanceCorrection{})).Where("id = ? AND owner_id = ?", id, ownerID).First(&attendanceCorrection).Error; err != nil {
+		return model.AttendanceCorrection{}, err
+	}
+	return attendanceCorrection, nil
+}
+
+// CancelAttendanceCorrection pengajuan hanya bisa dibatalkan selama belum diperiksa
+func (r attendanceCorrectionRepo) CancelAttendanceCorrection(id int, userID int) (model.AttendanceCorrection, error) {
+	query := r.db.Model(&model.AttendanceCorrection{}).
+		Where("id = ? AND user_id = ? AND status = ?", id, userID, model.CorrectionPending).
+		Updates(map[string]interface{}{"status": model.CorrectionCancelled, "updated_by": userID})
+	if err := query.Error; err != nil {
+		return model.AttendanceCorrection{}, err
+	}
+	if query.RowsAffected == 0 {
+		return model.AttendanceCorrection{}, errors.New("pengajuan tidak ditemukan atau sudah diperiksa")
+	}
+
+	return r.RetrieveAttendanceCorrection(id)
+}
+
+// ReviewAttendanceCorrection memutuskan pengajuan yang masih menunggu. Saat disetujui nilai presensi saat itu
+// disimpan ke kolom original_*, presensi diperbarui dengan nilai corrected dan waktu koreksi dicatat pada attendance log
+func (r attendanceCorrectionRepo) ReviewAttendanceCorrection(id int, actorID int, review model.AttendanceCorrectionReview, corrected model.Attendance) (model.AttendanceCorrection, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var attendanceCorrection model.AttendanceCorrection
+		if err := tx.Where("id = ? AND status = ?", id, model.CorrectionPending).First(&attendanceCorrection).Error; err != nil {
+			return errors.New("pengajuan tidak ditemukan atau sudah diperiksa")
+		}
+
+		// status diubah dengan syarat masih pending sehingga baris terkunci sampai transaksi selesai,
+		// pemeriksaan lain yang berjalan bersamaan tidak lagi menemukan pengajuan pending
+		reviewedAt := time.Now()
+		query := tx.Model(&model.AttendanceCorrection{}).Where("id = ? AND status = ?", id, model.CorrectionPending).Updates(map[string]interface{}{
+			"status":      review.Status,
+			"review_note": review.Note,
+			"reviewed_by": actorID,
+			"reviewed_at": &reviewedAt,
+			"updated_by":  actorID,
+		})
+		if err := query.Error; err != nil {
+			return err
+		}
+		if query.RowsAffected == 0 {
+			return errors.New("pengajuan tidak ditemukan atau sudah diperiksa")
+		}
+
+		if review.Status == model.CorrectionApproved {
+			var attendance model.Attendance
+			if err := tx.First(&attendance, attendanceCorrection.AttendanceID).Error; err != nil {
+				return err
+			}
+			attendanceCorrection.SetOriginal(attendance)
+			if err := tx.Model(&model.AttendanceCorrection{}).Where("id = ?", id).Updates(map[string]interface{}{
+				"original_clock_in":        attendanceCorrection.OriginalClockIn,
+				"original_clock_out":       attendanceCorrection.OriginalClockOut,
+				"original_status":          attendanceCorrection.OriginalStatus,
+				"original_status_presence": attendanceCorrection.OriginalStatusPresence,
+				"original_late_in":         attendanceCorrection.OriginalLateIn,
+				"original_early_out":       attendanceCorrection.OriginalEarlyOut,
+			}).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Model(&model.Attendance{}).Where("id = ?", attendance.ID).Updates(map[string]interface{}{
+				"clock_in":        corrected.ClockIn,
+				"clock_out":       corrected.ClockOut,
+				"time_zone_in":    corrected.TimeZoneIn,
+				"time_zone_out":   corrected.TimeZoneOut,
+				"late_in":         corrected.LateIn,
+				"early_out":       corrected.EarlyOut,
+				"status_presence": corrected.StatusPresence,
+				"status":          corrected.Status,
+				"presence_score":  corrected.PresenceScore,
+				"policy_label":    corrected.PolicyLabel,
+				"updated_by":      actorID,
+			}).Error; err != nil {
+				return err
+			}
+
+			logs := []model.AttendanceLog{{
+				GormCustom:   model.GormCustom{CreatedBy: actorID, CreatedAt: reviewedAt, UpdatedAt: reviewedAt},
+				AttendanceID: attendance.ID,
+				LogType:      "clock_in",
+				CheckIn:      corrected.ClockIn,
+				Status:       "correction",
+				TimeZone:     corrected.TimeZoneIn,
+			}}
+			if corrected.ClockOut > 0 {
+				logs = append(logs, model.AttendanceLog{
+					GormCustom:   model.GormCustom{CreatedBy: actorID, CreatedAt: reviewedAt, UpdatedAt: reviewedAt},
+					AttendanceID: attendance.ID,
+					LogType:      "clock_out",
+					CheckIn:      corrected.ClockOut,
+					Status:       "correction",
+					TimeZone:     corrected.TimeZoneOut,
+				})
+			}
+			if err := tx.Create(&logs).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return model.AttendanceCorrection{}, err
+	}
+
+	return r.RetrieveAttendanceCorrection(id)
+}
+
+func (r attendanceCorrectionRepo) ListAttendanceCorrection(attendanceCorrection model.AttendanceCorrection, pagination model.Pagination) ([]model.AttendanceCorrection, error) {
+	var attendanceCorrections []model.AttendanceCorrection
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	query := PreloadAttendanceCorrection(r.db.Table("attendance_corrections")).Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterAttendanceCorrection(query, attendanceCorrection)
+	query = SearchAttendanceCorrection(query, pagination.Search)
+	query = query.Find(&attendanceCorrections)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+
+	return attendanceCorrections, nil
+}
+
+func (r attendanceCorrectionRepo) ListAttendanceCorrectionMeta(attendanceCorrection model.AttendanceCorrection, pagination model.Pagination) (model.Meta, error) {
+	var attendanceCorrections []model.AttendanceCorrection
+	var totalRecord int
+	var totalPage int
+
+	queryTotal := r.db.Model(&model.AttendanceCorrection{}).Select("count(*)")
+	queryTotal = FilterAttendanceCorrection(queryTotal, attendanceCorrection)
+	queryTotal = SearchAttendanceCorrection(queryTotal, pagination.Search)
+	queryTotal = queryTotal.Scan(&totalRecord)
+	if err := queryTotal.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	totalPage = int(totalRecord / pagination.Limit)
+	if totalRecord%pagination.Limit > 0 {
+		totalPage += 1
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	query := r.db.Table("attendance_corrections").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterAttendanceCorrection(query, attendanceCorrection)
+	query = SearchAttendanceCorrection(query, pagination.Search)
+	query = query.Find(&attendanceCorrections)
+	if err := query.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	meta := model.Meta{
+		CurrentPage:   pagination.Page,
+		TotalPage:     totalPage,
+		TotalRecord:   totalRecord,
+		CurrentRecord: len(attendanceCorrections),
+	}
+	return meta, nil
+}
+
+// CheckIsExistPending satu presensi hanya boleh memiliki satu pengajuan koreksi yang menunggu
+func (r attendanceCorrectionRepo) CheckIsExistPending(attendanceID int) (isExist bool) {
+	if err := r.db.Table("attendance_corrections").Select("count(*) > 0").Where("attendance_id = ? AND status = ?", attendanceID, model.CorrectionPending).Find(&isExist).Error; err != nil {
+		return false
+	}
+	return
+}
+
+func PreloadAttendanceCorrection(query *gorm.DB) *gorm.DB {
+	query = query.Preload("User", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "username", "first_name", "last_name", "email", "profile")
+	})
+	query = query.Preload("Attendance")
+	query = query.Preload("Attendance.Schedule")
+	query = query.Preload("Attendance.Schedule.Subject")
+	return query
+}
+
+func FilterAttendanceCorrection(query *gorm.DB, attendanceCorrection model.AttendanceCorrection) *gorm.DB {
+	if attendanceCorrection.AttendanceID > 0 {
+		query = query.Where("attendance_id = ?", attendanceCorrection.AttendanceID)
+	}
+	if attendanceCorrection.UserID > 0 {
+		query = query.Where("user_id = ?", attendanceCorrection.UserID)
+	}
+	if attendanceCorrection.ScheduleID > 0 {
+		query = query.Where("schedule_id = ?", attendanceCorrection.ScheduleID)
+	}
+	if attendanceCorrection.OwnerID > 0 {
+		query = query.Where("owner_id = ?", attendanceCorrection.OwnerID)
+	}
+	if attendanceCorrection.Status != "" {
+		query = query.Where("status = ?", attendanceCorrection.Status)
+	}
+	if attendanceCorrection.StartDate != "" {
+		query = query.Where("date >= ?", attendanceCorrection.StartDate)
+	}
+	if attendanceCorrection.EndDate != "" {
+		query = query.Where("date <= ?", attendanceCorrection.EndDate)
+	}
+	return query
+}
+
+func SearchAttendanceCorrection(query *gorm.DB, search string) *gorm.DB {
+	if search != "" {
+		query = query.Where("reason LIKE ?", "%"+search+"%")
+	}
+	return query
+}
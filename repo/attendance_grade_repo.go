@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"attendance-api/model"
+
+	"gorm.io/gorm"
+)
+
+type AttendanceGradeRepo interface {
+	RetrieveAttendanceGradeFormula(scheduleID int) (model.AttendanceGradeFormula, error)
+	SaveAttendanceGradeFormula(formula model.AttendanceGradeFormula) (model.AttendanceGradeFormula, error)
+	ListAttendanceGrade(scheduleID int) ([]model.AttendanceGrade, error)
+}
+
+type attendanceGradeRepo struct {
+	db *gorm.DB
+}
+
+func NewAttendanceGradeRepo(db *gorm.DB) AttendanceGradeRepo {
+	return &attendanceGradeRepo{db: db}
+}
+
+func (r attendanceGradeRepo) RetrieveAttendanceGradeFormula(scheduleID int) (model.AttendanceGradeFormula, error) {
+	var formula model.AttendanceGradeFormula
+	if err := r.db.Model(&model.AttendanceGradeFormula{}).Where("schedule_id = ?", scheduleID).First(&formula).Error; err != nil {
+		return model.AttendanceGradeFormula{}, err
+	}
+	return formula, nil
+}
+
+// SaveAttendanceGradeFormula membuat atau memperbarui rumus jadwal, seluruh kolom ditulis agar poin 0 ikut tersimpan
+func (r attendanceGradeRepo) SaveAttendanceGradeFormula(formula model.AttendanceGradeFormula) (model.AttendanceGradeFormula, error) {
+	if current, err := r.RetrieveAttendanceGradeFormula(int(formula.ScheduleID)); err == nil {
+		formula.ID = current.ID
+		formula.CreatedAt = current.CreatedAt
+		formula.CreatedBy = current.CreatedBy
+	}
+	if err := r.db.Save(&formula).Error; err != nil {
+		return model.AttendanceGradeFormula{}, err
+	}
+	return formula, nil
+}
+
+// ListAttendanceGrade jumlah kehadiran setiap mahasiswa per kategori, nilai dihitung oleh rumus pada pemanggil
+func (r attendanceGradeRepo) ListAttendanceGrade(scheduleID int) ([]model.AttendanceGrade, error) {
+	var results []model.AttendanceGrade
+	if err := r.db.Table("user_schedules us").
+		Select(`us.user_id, u.username, TRIM(CONCAT(u.first_name, ' ', u.last_name)) AS name,
+		COALESCE(SUM(CASE WHEN a.status_presence = 'presence' AND a.status = '-' THEN a.presence_score ELSE 0 END), 0) AS presence,
+		COALESCE(SUM(CASE WHEN a.status_presence = 'presence' AND a.status = 'late' THEN a.presence_score ELSE 0 END), 0) AS late,
+		COALESCE(SUM(CASE WHEN a.status_presence = 'presence' AND a.status = 'come_home_early' THEN a.presence_score ELSE 0 END), 0) AS early_out,
+		COALESCE(SUM(CASE WHEN a.status_presence = 'presence' AND a.status = 'late_and_home_early' THEN a.presence_score ELSE 0 END), 0) AS late_early_out,
+		COUNT(CASE WHEN a.status_presence = 'sick' THEN 1 END) AS sick,
+		COUNT(CASE WHEN a.status_presence = 'leave_attendance' THEN 1 END) AS leave_attendance`).
+		Joins("JOIN users u ON u.id = us.user_id").
+		Joins("LEFT JOIN attendances a ON a.schedule_id = us.schedule_id AND a.user_id = us.user_id AND a.date <= CURDATE() AND "+QueryTeachingDay("a.date", "a.schedule_id")).
+		Where("us.schedule_id = ?", scheduleID).
+		Group("us.user_id, u.username, u.first_name, u.last_name").
+		Order("u.first_name, u.last_name").
+		Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
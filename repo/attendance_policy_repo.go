@@ -0,0 +1,227 @@
+package repo
+
+import (
+	"attendance-api/model"
+
+	"gorm.io/gorm"
+)
+
+type AttendancePolicyRepo interface {
+	CreateAttendancePolicy(attendancePolicy model.AttendancePolicy) (model.AttendancePolicy, error)
+	RetrieveAttendancePolicy(id int) (model.AttendancePolicy, error)
+	RetrieveAttendancePolicyBySchedule(scheduleID int) (model.AttendancePolicy, error)
+	UpdateAttendancePolicy(id int, attendancePolicy model.AttendancePolicy) (model.AttendancePolicy, error)
+	DeleteAttendancePolicy(id int) error
+	ListAttendancePolicy(attendancePolicy model.AttendancePolicy, pagination model.Pagination) ([]model.AttendancePolicy, error)
+	ListAttendancePolicyMeta(attendancePolicy model.AttendancePolicy, pagination model.Pagination) (model.Meta, error)
+	ListAttendancePolicyScheduleID(id int) ([]int, error)
+	AssignAttendancePolicySchedule(scheduleID int, id int) error
+	AssignAttendancePolicyFaculty(facultyID int, id int) error
+	CheckIsExist(id int) (isExist bool)
+}
+
+type attendancePolicyRepo struct {
+	db *gorm.DB
+}
+
+func NewAttendancePolicyRepo(db *gorm.DB) AttendancePolicyRepo {
+	return &attendancePolicyRepo{db: db}
+}
+
+func (r attendancePolicyRepo) CreateAttendancePolicy(attendancePolicy model.AttendancePolicy) (model.AttendancePolicy, error) {
+	if err := r.db.Create(&attendancePolicy).Error; err != nil {
+		return model.AttendancePolicy{}, err
+	}
+
+	return attendancePolicy, nil
+}
+
+func (r attendancePolicyRepo) RetrieveAttendancePolicy(id int) (model.AttendancePolicy, error) {
+	var attendancePolicy model.AttendancePolicy
+	if err := PreloadAttendancePolicy(r.db.Model(&model.AttendancePolicy{})).First(&attendancePolicy, id).Error; err != nil {
+		return model.AttendancePolicy{}, err
+	}
+	return attendancePolicy, nil
+}
+
+// RetrieveAttendancePolicyBySchedule kebijakan milik jadwal, jika kosong kebijakan fakultas dosen pengampu jadwal.
+// Kebijakan tanpa aturan berarti penilaian bawaan
+func (r attendancePolicyRepo) RetrieveAttendancePolicyBySchedule(scheduleID int) (model.AttendancePolicy, error) {
+	var policyID int
+	if err := r.db.Table("schedules s").
+		Select(`CASE WHEN s.attendance_policy_id > 0 THEN s.attendance_policy_id ELSE COALESCE((SELECT f.attendance_policy_id FROM teachers t
+		JOIN faculties f ON f.id = t.faculty_id WHERE t.user_id = s.owner_id LIMIT 1), 0) END`).
+		Where("s.id = ?", scheduleID).
+		Scan(&policyID).Error; err != nil {
+		return model.AttendancePolicy{}, err
+	}
+	if policyID == 0 {
+		return model.AttendancePolicy{}, nil
+	}
+	return r.RetrieveAttendancePolicy(policyID)
+}
+
+// UpdateAttendancePolicy aturan lama diganti seluruhnya dengan aturan yang dikirim
+func (r attendancePolicyRepo) UpdateAttendancePolicy(id int, attendancePolicy model.AttendancePolicy) (model.AttendancePolicy, error) {
+	rules := attendancePolicy.Rules
+	attendancePolicy.Rules = nil
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.AttendancePolicy{}).Where("id = ?", id).Updates(&attendancePolicy).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("attendance_policy_id = ?", id).Delete(&model.AttendancePolicyRule{}).Error; err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			rule.ID = 0
+			rule.AttendancePolicyID = uint(id)
+			if err := tx.Create(&rule).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return model.AttendancePolicy{}, err
+	}
+	return r.RetrieveAttendancePolicy(id)
+}
+
+// DeleteAttendancePolicy jadwal dan fakultas yang memakai kebijakan dikembalikan ke aturan bawaan
+func (r attendancePolicyRepo) DeleteAttendancePolicy(id int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("schedules").Where("attendance_policy_id = ?", id).Update("attendance_policy_id", 0).Error; err != nil {
+			return err
+		}
+		if err := tx.Table("faculties").Where("attendance_policy_id = ?", id).Update("attendance_policy_id", 0).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("attendance_policy_id = ?", id).Delete(&model.AttendancePolicyRule{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.AttendancePolicy{}, id).Error
+	})
+}
+
+func (r attendancePolicyRepo) ListAttendancePolicy(attendancePolicy model.AttendancePolicy, pagination model.Pagination) ([]model.AttendancePolicy, error) {
+	var attendancePolicies []model.AttendancePolicy
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	query := r.db.Table("attendance_policies").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = PreloadAttendancePolicy(query)
+	query = FilterAttendancePolicy(query, attendancePolicy)
+	query = SearchAttendancePolicy(query, pagination.Search)
+	query = query.Find(&attendancePolicies)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+
+	return attendancePolicies, nil
+}
+
+func (r attendancePolicyRepo) ListAttendancePolicyMeta(attendancePolicy model.AttendancePolicy, pagination model.Pagination) (model.Meta, error) {
+	var attendancePolicies []model.AttendancePolicy
+	var totalRecord int
+	var totalPage int
+
+	queryTotal := r.db.Model(&model.AttendancePolicy{}).Select("count(*)")
+	queryTotal = FilterAttendancePolicy(queryTotal, attendancePolicy)
+	queryTotal = SearchAttendancePolicy(queryTotal, pagination.Search)
+	queryTotal = queryTotal.Scan(&totalRecord)
+	if err := queryTotal.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	totalPage = int(totalRecord / pagination.Limit)
+	if totalRecord%pagination.Limit > 0 {
+		totalPage += 1
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	query := r.db.Table("attendance_policies").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterAttendancePolicy(query, attendancePolicy)
+	query = SearchAttendancePolicy(query, pagination.Search)
+	query = query.Find(&attendancePolicies)
+	if err := query.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	meta := model.Meta{
+		CurrentPage:   pagination.Page,
+		TotalPage:     totalPage,
+		TotalRecord:   totalRecord,
+		CurrentRecord: len(attendancePolicies),
+	}
+	return meta, nil
+}
+
+// ListAttendancePolicyScheduleID jadwal yang dinilai dengan kebijakan ini, langsung maupun lewat fakultas
+func (r attendancePolicyRepo) ListAttendancePolicyScheduleID(id int) ([]int, error) {
+	var scheduleIDs []int
+	if err := r.db.Table("schedules s").
+		Select("s.id").
+		Where(`s.attendance_policy_id = ? OR (s.attendance_policy_id = 0 AND s.owner_id IN (SELECT t.user_id FROM teachers t
+		JOIN faculties f ON f.id = t.faculty_id WHERE f.attendance_policy_id = ?))`, id, id).
+		Scan(&scheduleIDs).Error; err != nil {
+		return nil, err
+	}
+	return scheduleIDs, nil
+}
+
+func (r attendancePolicyRepo) AssignAttendancePolicySchedule(scheduleID int, id int) error {
+	query := r.db.Table("schedules").Where("id = ?", scheduleID).Update("attendance_policy_id", id)
+	if err := query.Error; err != nil {
+		return err
+	}
+	if query.RowsAffected == 0 && !r.checkIsExistTable("schedules", scheduleID) {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r attendancePolicyRepo) AssignAttendancePolicyFaculty(facultyID int, id int) error {
+	query := r.db.Table("faculties").Where("id = ?", facultyID).Update("attendance_policy_id", id)
+	if err := query.Error; err != nil {
+		return err
+	}
+	if query.RowsAffected == 0 && !r.checkIsExistTable("faculties", facultyID) {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r attendancePolicyRepo) CheckIsExist(id int) (isExist bool) {
+	return r.checkIsExistTable("attendance_policies", id)
+}
+
+func (r attendancePolicyRepo) checkIsExistTable(table string, id int) (isExist bool) {
+	if err := r.db.Table(table).Select("count(*) > 0").Where("id = ?", id).Find(&isExist).Error; err != nil {
+		return false
+	}
+	return
+}
+
+func PreloadAttendancePolicy(query *gorm.DB) *gorm.DB {
+	query = query.Preload("Rules", func(db *gorm.DB) *gorm.DB {
+		return db.Order("type, minutes")
+	})
+	return query
+}
+
+func FilterAttendancePolicy(query *gorm.DB, attendancePolicy model.AttendancePolicy) *gorm.DB {
+	if attendancePolicy.Name != "" {
+		query = query.Where("name LIKE ?", "%"+attendancePolicy.Name+"%")
+	}
+	if attendancePolicy.OwnerID > 0 {
+		query = query.Where("owner_id = ?", attendancePolicy.OwnerID)
+	}
+	return query
+}
+
+func SearchAttendancePolicy(query *gorm.DB, search string) *gorm.DB {
+	if search != "" {
+		query = query.Where("name LIKE ? OR summary LIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+	return query
+}
@@ -4,6 +4,7 @@ import (
 	"attendance-api/model"
 	"errors"
 	"sync"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -12,7 +13,7 @@ type AttendanceRepo interface {
 	CreateAttendance(attendance model.Attendance) (model.Attendance, error)
 	RetrieveAttendance(id int) (model.Attendance, error)
 	RetrieveAttendanceByUserID(id int, userID int) (model.Attendance, error)
-	RetrieveAttendanceByDate(userID int, scheduleID int, date string) (model.Attendance, error)
+	RetrieveAttendanceByDate(userID int, scheduleID int, dailyScheduleID int, date string) (model.Attendance, error)
 	UpdateAttendance(id int, attendance model.Attendance) (model.Attendance, error)
 	UpdateAttendanceByUserID(id int, userID int, attendance model.Attendance) (model.Attendance, error)
 	UpdateStatusAttendance(id int, statusPresence string, userID int) (model.Attendance, error)
@@ -22,8 +23,17 @@ type AttendanceRepo interface {
 	ListAttendanceMeta(attendance model.Attendance, pagination model.Pagination) (model.Meta, error)
 	DropDownAttendance(attendance model.Attendance) ([]model.Attendance, error)
 	CheckIsExist(id int) (isExist bool, err error)
-	CheckIsExistByDate(userID int, scheduleID int, date string) bool
+	CheckIsExistByDate(userID int, scheduleID int, dailyScheduleID int, date string) bool
+	MigrateAttendanceDailySchedule() (total int64, err error)
+	MigrateAttendancePresenceScore() (total int64, err error)
+	RecomputeAttendancePolicy(scheduleID int, policy model.AttendancePolicy) (total int64, err error)
+	RecomputeOpenAttendancePolicy(scheduleID int, policy model.AttendancePolicy, startDate string) (total int64, err error)
+	ListOpenAttendanceScheduleID(startDate string) ([]int, error)
 	CountAttendanceByStatus(userID int, statusAttendance string, startDate string, endDate string) (result int)
+	CountClockInByDate(scheduleID int, dailyScheduleID int, date string) (result int)
+	CountHeldMeeting(scheduleID int) (result int)
+	ListAttendanceEligibility(scheduleID int, rule model.EligibilityRule) ([]model.AttendanceEligibility, error)
+	ExportAttendance(attendance model.Attendance, fn func(model.AttendanceExport) error) error
 }
 
 type attendanceRepo struct {
@@ -35,6 +45,18 @@ func NewAttendanceRepo(db *gorm.DB) AttendanceRepo {
 }
 
 func (r attendanceRepo) CreateAttendance(attendance model.Attendance) (result model.Attendance, err error) {
+	if attendance.SessionID == 0 {
+		// tautkan ke pertemuan pada tanggal dan blok tersebut jika sudah digenerate
+		query := r.db.Table("sessions").Select("id").
+			Where("schedule_id = ? AND daily_schedule_id = ? AND date = ? AND status != ?", attendance.ScheduleID, attendance.DailyScheduleID, attendance.Date, model.SessionCancelled)
+		query.Order("orphaned asc, start_time asc").Limit(1).Scan(&attendance.SessionID)
+	}
+	if attendance.ClockIn == 0 && attendance.StatusPresence == "not_presence" {
+		// pengajuan izin/sakit yang sudah disetujui berlaku untuk presensi yang dibuat kemudian
+		if statusPresence := NewLeaveRequestRepo(r.db).RetrieveApprovedStatusPresence(attendance.UserID, int(attendance.ScheduleID), attendance.Date); statusPresence != "" {
+			attendance.StatusPresence = statusPresence
+		}
+	}
 	if err := r.db.Table("attendances").Create(&attendance).Error; err != nil {
 		return model.Attendance{}, err
 	}
@@ -71,8 +93,9 @@ func (r attendanceRepo) RetrieveAttendanceByUserID(id int, userID int) (result m
 	return
 }
 
-func (r attendanceRepo) RetrieveAttendanceByDate(userID int, scheduleID int, date string) (result model.Attendance, err error) {
-	if err := PreloadAttendance(r.db.Table("attendances")).Where("user_id = ? AND schedule_id = ? AND DATE(date) = ?", userID, scheduleID, date).First(&result).Error; err != nil {
+// RetrieveAttendanceByDate presensi dicatat per blok jadwal harian, dailyScheduleID 0 untuk pertemuan pindahan / kelas pengganti
+func (r attendanceRepo) RetrieveAttendanceByDate(userID int, scheduleID int, dailyScheduleID int, date string) (result model.Attendance, err error) {
+	if err := PreloadAttendance(r.db.Table("attendances")).Where("user_id = ? AND schedule_id = ? AND daily_schedule_id = ? AND DATE(date) = ?", userID, scheduleID, dailyScheduleID, date).First(&result).Error; err != nil {
 		return model.Attendance{}, err
 	}
 	result.User.Role = result.User.GetRole()
@@ -85,6 +108,15 @@ func (r attendanceRepo) UpdateAttendance(id int, attendance model.Attendance) (r
 	if err := r.db.Table("attendances").Where("id = ?", id).Updates(&attendance).Error; err != nil {
 		return model.Attendance{}, err
 	}
+	// bobot kehadiran bisa bernilai 0 sehingga disimpan terpisah dari Updates struct
+	if attendance.StatusPresence != "" {
+		if err := r.db.Table("attendances").Where("id = ?", id).Updates(map[string]interface{}{
+			"presence_score": attendance.PresenceScore,
+			"policy_label":   attendance.PolicyLabel,
+		}).Error; err != nil {
+			return model.Attendance{}, err
+		}
+	}
 	if err := PreloadAttendance(r.db.Table("attendances")).Where("id = ?", id).First(&result).Error; err != nil {
 		return model.Attendance{}, err
 	}
@@ -100,6 +132,8 @@ func (r attendanceRepo) UpdateStatusAttendance(id int, statusPresence string, us
 		if err := r.db.Table("attendances").Where("id = ?", id).Updates(map[string]interface{}{
 			"status_presence": statusPresence,
 			"status":          "-",
+			"presence_score":  model.Attendance{StatusPresence: statusPresence}.GetPresenceScore(),
+			"policy_label":    "",
 			"updated_by":      userID,
 		}).Error; err != nil {
 			return model.Attendance{}, err
@@ -240,20 +274,183 @@ func (r attendanceRepo) CheckIsExist(id int) (isExist bool, err error) {
 	return
 }
 
-func (r attendanceRepo) CheckIsExistByDate(userID int, scheduleID int, date string) (isExist bool) {
-	if err := r.db.Table("attendances").Select("count(*) > 0").Where("user_id = ? AND schedule_id = ? AND DATE(date) = ?", userID, scheduleID, date).Find(&isExist).Error; err != nil {
+func (r attendanceRepo) CheckIsExistByDate(userID int, scheduleID int, dailyScheduleID int, date string) (isExist bool) {
+	if err := r.db.Table("attendances").Select("count(*) > 0").Where("user_id = ? AND schedule_id = ? AND daily_schedule_id = ? AND DATE(date) = ?", userID, scheduleID, dailyScheduleID, date).Find(&isExist).Error; err != nil {
 		return false
 	}
 	return
 }
 
+// MigrateAttendanceDailySchedule menautkan presensi lama ke blok jadwal harian jika pada hari tersebut hanya ada satu blok
+func (r attendanceRepo) MigrateAttendanceDailySchedule() (total int64, err error) {
+	query := r.db.Exec(`UPDATE attendances a 
+	JOIN daily_schedules ds ON ds.schedule_id = a.schedule_id AND ds.name = LOWER(DAYNAME(a.date)) 
+	SET a.daily_schedule_id = ds.id 
+	WHERE a.daily_schedule_id = 0 
+	AND (SELECT COUNT(*) FROM daily_schedules d2 WHERE d2.schedule_id = a.schedule_id AND d2.name = ds.name) = 1`)
+	if err := query.Error; err != nil {
+		return 0, err
+	}
+	return query.RowsAffected, nil
+}
+
+// MigrateAttendancePresenceScore presensi lama yang hadir diberi bobot kehadiran penuh, kolom yang masih NULL
+// (ditambahkan sebelum memiliki nilai bawaan) ikut diisi
+func (r attendanceRepo) MigrateAttendancePresenceScore() (total int64, err error) {
+	query := r.db.Table("attendances").Where("status_presence = ? AND (presence_score IS NULL OR presence_score = 0)", "presence").Update("presence_score", 1)
+	if err := query.Error; err != nil {
+		return 0, err
+	}
+	if err := r.db.Table("attendances").Where("presence_score IS NULL").Update("presence_score", 0).Error; err != nil {
+		return query.RowsAffected, err
+	}
+	return query.RowsAffected, nil
+}
+
+// RecomputeAttendancePolicy menilai ulang presensi hadir / tidak hadir pada jadwal dengan kebijakan terbaru
+func (r attendanceRepo) RecomputeAttendancePolicy(scheduleID int, policy model.AttendancePolicy) (total int64, err error) {
+	query := r.db.Table("attendances").Where("schedule_id = ? AND status_presence IN ?", scheduleID, []string{"presence", "not_presence"})
+	return r.recomputeAttendancePolicy(query, scheduleID, policy)
+}
+
+// RecomputeOpenAttendancePolicy menilai ulang presensi yang belum absen keluar sejak startDate, dipakai task
+// attendance_policy agar missing_clock_out berlaku setelah batas absen keluar blok lewat
+func (r attendanceRepo) RecomputeOpenAttendancePolicy(scheduleID int, policy model.AttendancePolicy, startDate string) (total int64, err error) {
+	query := r.db.Table("attendances").Where("schedule_id = ? AND status_presence = ? AND clock_in > 0 AND clock_out = 0 AND date >= ?", scheduleID, "presence", startDate)
+	return r.recomputeAttendancePolicy(query, scheduleID, policy)
+}
+
+// ListOpenAttendanceScheduleID jadwal yang memiliki presensi belum absen keluar sejak startDate
+func (r attendanceRepo) ListOpenAttendanceScheduleID(startDate string) ([]int, error) {
+	var scheduleIDs []int
+	if err := r.db.Table("attendances").Distinct("schedule_id").
+		Where("status_presence = ? AND clock_in > 0 AND clock_out = 0 AND date >= ?", "presence", startDate).
+		Pluck("schedule_id", &scheduleIDs).Error; err != nil {
+		return nil, err
+	}
+	return scheduleIDs, nil
+}
+
+func (r attendanceRepo) recomputeAttendancePolicy(query *gorm.DB, scheduleID int, policy model.AttendancePolicy) (total int64, err error) {
+	// batas absen keluar dihitung per blok jadwal harian, blok pindahan / kelas pengganti dianggap tutup setelah tanggalnya
+	var schedule model.Schedule
+	if err := r.db.Preload("DailySchedule").First(&schedule, scheduleID).Error; err != nil {
+		return 0, err
+	}
+	now := time.Now()
+
+	var attendances []model.Attendance
+	err = query.
+		FindInBatches(&attendances, 500, func(tx *gorm.DB, batch int) error {
+			for _, attendance := range attendances {
+				dailySchedule, _ := model.FindDailySchedule(schedule.DailySchedule, attendance.DailyScheduleID)
+				dailySchedule.ClockWindow = dailySchedule.ClockWindow.WithDefault(schedule.ClockWindow)
+
+				data := attendance
+				data.StatusPresence = ""
+				data.StatusPresence = data.GenerateStatusPresence()
+				data.Status = data.GenerateStatus()
+				data = policy.Apply(data, dailySchedule.IsClockOutClosed(attendance.Date, now))
+				if data.StatusPresence == attendance.StatusPresence && data.Status == attendance.Status &&
+					data.PresenceScore == attendance.PresenceScore && data.PolicyLabel == attendance.PolicyLabel {
+					continue
+				}
+				if err := r.db.Table("attendances").Where("id = ?", attendance.ID).Updates(map[string]interface{}{
+					"status_presence": data.StatusPresence,
+					"status":          data.Status,
+					"presence_score":  data.PresenceScore,
+					"policy_label":    data.PolicyLabel,
+				}).Error; err != nil {
+					return err
+				}
+				total++
+			}
+			return nil
+		}).Error
+	return
+}
+
 func (r attendanceRepo) CountAttendanceByStatus(userID int, statusAttendance string, startDate string, endDate string) (result int) {
-	if err := r.db.Table("attendances").Select("count(*)").Where("user_id = ? AND status_presence = ? AND DATE(date) BETWEEN ? AND ?", userID, statusAttendance, startDate, endDate).Find(&result).Error; err != nil {
+	if err := r.db.Table("attendances").Select("count(*)").Where("user_id = ? AND status_presence = ? AND DATE(date) BETWEEN ? AND ?", userID, statusAttendance, startDate, endDate).Where(QueryTeachingDay("attendances.date", "attendances.schedule_id")).Find(&result).Error; err != nil {
+		return 0
+	}
+	return
+}
+
+// CountClockInByDate jumlah mahasiswa yang sudah absen masuk pada blok jadwal harian di tanggal tersebut
+func (r attendanceRepo) CountClockInByDate(scheduleID int, dailyScheduleID int, date string) (result int) {
+	if err := r.db.Table("attendances").Select("COUNT(DISTINCT user_id)").Where("schedule_id = ? AND daily_schedule_id = ? AND DATE(date) = ? AND clock_in > 0", scheduleID, dailyScheduleID, date).Find(&result).Error; err != nil {
+		return 0
+	}
+	return
+}
+
+// CountHeldMeeting jumlah pertemuan yang sudah terlaksana, jadwal tanpa data pertemuan dihitung dari
+// tanggal dan blok jadwal harian yang memiliki absen masuk
+func (r attendanceRepo) CountHeldMeeting(scheduleID int) (result int) {
+	if err := r.db.Table("sessions").Select("count(*)").Where("schedule_id = ? AND status = ? AND date <= CURDATE()", scheduleID, model.SessionHeld).Find(&result).Error; err != nil {
+		return 0
+	}
+	if result > 0 {
+		return
+	}
+	if err := r.db.Table("attendances").Select("count(DISTINCT date, daily_schedule_id)").
+		Where("schedule_id = ? AND clock_in > 0 AND date <= CURDATE()", scheduleID).
+		Where(QueryTeachingDay("attendances.date", "attendances.schedule_id")).
+		Find(&result).Error; err != nil {
 		return 0
 	}
 	return
 }
 
+// ListAttendanceEligibility rekap kehadiran setiap mahasiswa pada jadwal, HeldMeeting dan kelayakan dihitung oleh pemanggil
+func (r attendanceRepo) ListAttendanceEligibility(scheduleID int, rule model.EligibilityRule) ([]model.AttendanceEligibility, error) {
+	var results []model.AttendanceEligibility
+	if err := r.db.Table("user_schedules us").
+		Select(`us.user_id, u.username, TRIM(CONCAT(u.first_name, ' ', u.last_name)) AS name,
+		COALESCE(SUM(CASE WHEN a.status_presence IN ? THEN IF(a.status_presence = 'presence', a.presence_score, 1) ELSE 0 END), 0) AS attended,
+		COUNT(CASE WHEN a.status_presence IN ? THEN 1 END) AS excused`, rule.AttendedStatus, rule.ExcusedStatus).
+		Joins("JOIN users u ON u.id = us.user_id").
+		Joins("LEFT JOIN attendances a ON a.schedule_id = us.schedule_id AND a.user_id = us.user_id AND a.date <= CURDATE() AND "+QueryTeachingDay("a.date", "a.schedule_id")).
+		Where("us.schedule_id = ?", scheduleID).
+		Group("us.user_id, u.username, u.first_name, u.last_name").
+		Order("u.first_name, u.last_name").
+		Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ExportAttendance membaca presensi hasil FilterAttendance baris per baris dan memanggil fn untuk setiap baris,
+// data tidak dimuat sekaligus ke memori sehingga aman untuk puluhan ribu baris
+func (r attendanceRepo) ExportAttendance(attendance model.Attendance, fn func(model.AttendanceExport) error) error {
+	filtered := FilterAttendance(r.db.Table("attendances").Select("attendances.*"), attendance)
+
+	rows, err := r.db.Table("(?) AS a", filtered).
+		Select("students.nim, users.first_name, users.last_name, subjects.name AS subject_name, schedules.name AS schedule_name, schedules.code AS schedule_code, a.date, a.clock_in, a.time_zone_in, a.clock_out, a.time_zone_out, a.status, a.status_presence").
+		Joins("JOIN users ON a.user_id = users.id").
+		Joins("LEFT JOIN students ON students.user_id = a.user_id").
+		Joins("JOIN schedules ON a.schedule_id = schedules.id").
+		Joins("LEFT JOIN subjects ON schedules.subject_id = subjects.id").
+		Order("a.date, schedules.code, students.nim, a.id").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data model.AttendanceExport
+		if err := r.db.ScanRows(rows, &data); err != nil {
+			return err
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 func FilterAttendance(query *gorm.DB, attendance model.Attendance) *gorm.DB {
 	if attendance.UserID > 0 {
 		query = query.Where("user_id = ?", attendance.UserID)
@@ -267,6 +464,12 @@ func FilterAttendance(query *gorm.DB, attendance model.Attendance) *gorm.DB {
 	if attendance.Status != "" {
 		query = query.Where("status = ?", attendance.Status)
 	}
+	if attendance.StartDate != "" {
+		query = query.Where("attendances.date >= ?", attendance.StartDate)
+	}
+	if attendance.EndDate != "" {
+		query = query.Where("attendances.date <= ?", attendance.EndDate)
+	}
 	if attendance.Schedule.OwnerID > 0 {
 		query = query.Joins("JOIN schedules ON attendances.schedule_id = schedules.id").Where("schedules.owner_id = ?", attendance.Schedule.OwnerID)
 	}
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"attendance-api/common/util/converter"
 	"attendance-api/model"
 	"fmt"
 
@@ -21,7 +22,8 @@ type DailyScheduleRepo interface {
 	ListDailySchedule(dailyschedule model.DailySchedule, pagination model.Pagination) ([]model.DailySchedule, error)
 	ListDailyScheduleMeta(dailyschedule model.DailySchedule, pagination model.Pagination) (model.Meta, error)
 	DropDownDailySchedule(dailyschedule model.DailySchedule) ([]model.DailySchedule, error)
-	CheckHaveDailySchedule(scheduleID int, day string) (isHaveDailySchedule bool, dailyScheduleID int, err error)
+	CheckHaveDailySchedule(scheduleID int, date string) (isHaveDailySchedule bool, dailyScheduleID int, err error)
+	ListDailyScheduleByDate(scheduleID int, date string) ([]model.DailySchedule, error)
 }
 
 type dailyScheduleRepo struct {
@@ -170,19 +172,46 @@ func (r dailyScheduleRepo) DropDownDailySchedule(dailyschedule model.DailySchedu
 	return dailyschedules, nil
 }
 
-func (r dailyScheduleRepo) CheckHaveDailySchedule(scheduleID int, day string) (isHaveDailySchedule bool, dailyScheduleID int, err error) {
+// CheckHaveDailySchedule ada pertemuan pada tanggal tersebut, dailyScheduleID adalah blok mingguan pertama yang
+// tidak dibatalkan / dipindah, 0 jika pertemuan hanya berasal dari pertemuan pindahan / kelas pengganti
+func (r dailyScheduleRepo) CheckHaveDailySchedule(scheduleID int, date string) (isHaveDailySchedule bool, dailyScheduleID int, err error) {
 	type DataDailySchedule struct {
 		IsHaveDailySchedule bool `json:"is_have_daily_schedule" query:"is_have_daily_schedule"`
 		DailyScheduleID     int  `json:"daily_schedule_id" query:"daily_schedule_id"`
 	}
 	var data DataDailySchedule
+
+	day := converter.GetDayNameFromDateString(date)
 	rawQuery := fmt.Sprintf(`SELECT COUNT(*) > 0 as is_have_daily_schedule, ds.id as daily_schedule_id 
-	FROM daily_schedules ds WHERE ds.schedule_id = %d AND name = '%s' GROUP BY ds.id LIMIT 1`, scheduleID, day)
+	FROM daily_schedules ds WHERE ds.schedule_id = ? AND name = ? AND NOT %s GROUP BY ds.id ORDER BY ds.start_time LIMIT 1`, QueryMeetingRemoved("ds.schedule_id", "ds.id", "?"))
 
-	if err := r.db.Raw(rawQuery).Scan(&data).Error; err != nil {
+	if err := r.db.Raw(rawQuery, scheduleID, day, date).Scan(&data).Error; err != nil {
 		return data.IsHaveDailySchedule, data.DailyScheduleID, err
 	}
-	return data.IsHaveDailySchedule, data.DailyScheduleID, nil
+	if data.IsHaveDailySchedule {
+		return true, data.DailyScheduleID, nil
+	}
+
+	// Pertemuan pindahan / kelas pengganti pada tanggal ini, jam diambil dari data pengecualian (daily_schedule_id = 0)
+	var isMeetingAdded bool
+	if err := r.db.Raw(fmt.Sprintf("SELECT %s", QueryMeetingAdded("?", "?")), scheduleID, date).Scan(&isMeetingAdded).Error; err != nil {
+		return false, 0, err
+	}
+	return isMeetingAdded, 0, nil
+}
+
+// ListDailyScheduleByDate blok jadwal harian pada hari dari tanggal tersebut yang tidak dibatalkan / dipindah, urut jam mulai
+func (r dailyScheduleRepo) ListDailyScheduleByDate(scheduleID int, date string) ([]model.DailySchedule, error) {
+	var dailyschedules []model.DailySchedule
+	query := r.db.Model(&model.DailySchedule{}).
+		Where("schedule_id = ? AND name = ?", scheduleID, converter.GetDayNameFromDateString(date)).
+		Where("NOT "+QueryMeetingRemoved("daily_schedules.schedule_id", "daily_schedules.id", "?"), date).
+		Order("start_time asc").
+		Find(&dailyschedules)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+	return dailyschedules, nil
 }
 
 func FilterDailySchedule(query *gorm.DB, dailyschedule model.DailySchedule) *gorm.DB {
@@ -107,6 +107,7 @@ func (r dashboardRepo) RetrieveDashboardAttendance(month, year int) (results []m
 		"SUM(CASE WHEN status = 'late' THEN 1 ELSE 0 END) as total_late, " +
 		"SUM(CASE WHEN status = 'come_home_early' THEN 1 ELSE 0 END) as total_come_home_early, " +
 		"SUM(CASE WHEN status = 'late_and_home_early' THEN 1 ELSE 0 END) as total_late_and_home_early")
+	query = query.Where(QueryTeachingDay("attendances.date", "attendances.schedule_id"))
 
 	if month > 0 && year > 0 {
 		query = query.Where("YEAR(STR_TO_DATE(date, '%Y-%m-%d')) = ? AND MONTH(STR_TO_DATE(date, '%Y-%m-%d')) = ?", year, month)
@@ -152,7 +153,7 @@ func (r dashboardRepo) RetrieveDashboardAttendanceSeries(month, year int) (resul
 			go func(j int, date string, status string) {
 				count := 0
 
-				query := r.db.Table("attendances").Select("count(*)").Where("status_presence = ? AND DATE(date) = ?", status, date)
+				query := r.db.Table("attendances").Select("count(*)").Where("status_presence = ? AND DATE(date) = ?", status, date).Where(QueryTeachingDay("attendances.date", "attendances.schedule_id"))
 				if errGet := query.Find(&count).Error; errGet != nil {
 					log.Printf("Error Get Data Status %v Pada Tanggal %v\n", status, date)
 					count = 0
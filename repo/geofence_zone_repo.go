@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"attendance-api/model"
+
+	"gorm.io/gorm"
+)
+
+type GeofenceZoneRepo interface {
+	CreateGeofenceZone(zone model.GeofenceZone) (model.GeofenceZone, error)
+	UpdateGeofenceZoneByScheduleID(id int, scheduleID int, zone model.GeofenceZone) (model.GeofenceZone, error)
+	UpdateGeofenceZoneByRoomID(id int, roomID int, zone model.GeofenceZone) (model.GeofenceZone, error)
+	DeleteGeofenceZoneByScheduleIDAndExceptListID(scheduleID int, exceptID []int) error
+	ListGeofenceZoneByScheduleID(scheduleID int) ([]model.GeofenceZone, error)
+	DeleteGeofenceZoneByRoomIDAndExceptListID(roomID int, exceptID []int) error
+	ListGeofenceZoneByRoomID(roomID int) ([]model.GeofenceZone, error)
+}
+
+type geofenceZoneRepo struct {
+	db *gorm.DB
+}
+
+func NewGeofenceZoneRepo(db *gorm.DB) GeofenceZoneRepo {
+	return &geofenceZoneRepo{db: db}
+}
+
+func (r geofenceZoneRepo) CreateGeofenceZone(zone model.GeofenceZone) (model.GeofenceZone, error) {
+	if err := r.db.Table("geofence_zones").Create(&zone).Error; err != nil {
+		return model.GeofenceZone{}, err
+	}
+	return zone, nil
+}
+
+func (r geofenceZoneRepo) UpdateGeofenceZoneByScheduleID(id int, scheduleID int, zone model.GeofenceZone) (model.GeofenceZone, error) {
+	if err := r.db.Model(&model.GeofenceZone{}).Where("id = ? AND schedule_id = ?", id, scheduleID).Select("*").Omit("id", "created_at", "created_by").Updates(&zone).Error; err != nil {
+		return model.GeofenceZone{}, err
+	}
+	return zone, nil
+}
+
+func (r geofenceZoneRepo) UpdateGeofenceZoneByRoomID(id int, roomID int, zone model.GeofenceZone) (model.GeofenceZone, error) {
+	if err := r.db.Model(&model.GeofenceZone{}).Where("id = ? AND room_id = ?", id, roomID).Select("*").Omit("id", "created_at", "created_by").Updates(&zone).Error; err != nil {
+		return model.GeofenceZone{}, err
+	}
+	return zone, nil
+}
+
+func (r geofenceZoneRepo) DeleteGeofenceZoneByScheduleIDAndExceptListID(scheduleID int, exceptID []int) error {
+	query := r.db.Where("schedule_id = ?", scheduleID)
+	if len(exceptID) > 0 {
+		query = query.Where("id NOT IN ?", exceptID)
+	}
+	if err := query.Delete(&model.GeofenceZone{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r geofenceZoneRepo) ListGeofenceZoneByScheduleID(scheduleID int) ([]model.GeofenceZone, error) {
+	var zones []model.GeofenceZone
+	if err := r.db.Model(&model.GeofenceZone{}).Where("schedule_id = ?", scheduleID).Find(&zones).Error; err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+func (r geofenceZoneRepo) DeleteGeofenceZoneByRoomIDAndExceptListID(roomID int, exceptID []int) error {
+	query := r.db.Where("room_id = ?", roomID)
+	if len(exceptID) > 0 {
+		query = query.Where("id NOT IN ?", exceptID)
+	}
+	if err := query.Delete(&model.GeofenceZone{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r geofenceZoneRepo) ListGeofenceZoneByRoomID(roomID int) ([]model.GeofenceZone, error) {
+	var zones []model.GeofenceZone
+	if err := r.db.Model(&model.GeofenceZone{}).Where("room_id = ?", roomID).Find(&zones).Error; err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
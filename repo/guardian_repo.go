@@ -0,0 +1,121 @@
+package repo
+
+import (
+	"attendance-api/common/util/myqr"
+	"attendance-api/model"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type GuardianRepo interface {
+	ListStudentGuardian(studentID int) ([]model.Guardian, error)
+	SaveStudentGuardian(studentID int, guardians []model.Guardian) ([]model.Guardian, error)
+	OptOutGuardian(token string) error
+	ListGuardianDigest(since string, until string) ([]model.GuardianDigestRecord, error)
+}
+
+type guardianRepo struct {
+	db *gorm.DB
+}
+
+func NewGuardianRepo(db *gorm.DB) GuardianRepo {
+	return &guardianRepo{db: db}
+}
+
+func (r guardianRepo) ListStudentGuardian(studentID int) ([]model.Guardian, error) {
+	var guardians []model.Guardian
+	if err := r.db.Where("student_id = ?", studentID).Order("id").Find(&guardians).Error; err != nil {
+		return nil, err
+	}
+	return guardians, nil
+}
+
+// SaveStudentGuardian menyamakan kontak wali mahasiswa dengan data yang dikirim, kontak dengan id lama
+// diperbaharui (token dan status berhenti berlangganan tetap, hanya bisa diubah wali lewat tautan), kontak tanpa id
+// dibuat dan kontak yang tidak dikirim dihapus
+func (r guardianRepo) SaveStudentGuardian(studentID int, guardians []model.Guardian) ([]model.Guardian, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var current []model.Guardian
+		if err := tx.Where("student_id = ?", studentID).Find(&current).Error; err != nil {
+			return err
+		}
+		existing := map[uint]model.Guardian{}
+		for _, guardian := range current {
+			existing[guardian.ID] = guardian
+		}
+
+		keepIDs := []uint{0}
+		for _, guardian := range guardians {
+			guardian.StudentID = uint(studentID)
+			if old, ok := existing[guardian.ID]; ok {
+				guardian.OptOut = old.OptOut
+				guardian.OptOutToken = old.OptOutToken
+				guardian.CreatedAt = old.CreatedAt
+				guardian.CreatedBy = old.CreatedBy
+			} else {
+				guardian.ID = 0
+				guardian.OptOut = false
+				guardian.OptOutToken = myqr.GenerateSecret(32)
+			}
+			if err := tx.Save(&guardian).Error; err != nil {
+				return err
+			}
+			keepIDs = append(keepIDs, guardian.ID)
+		}
+
+		return tx.Where("student_id = ? AND id NOT IN ?", studentID, keepIDs).Delete(&model.Guardian{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.ListStudentGuardian(studentID)
+}
+
+func (r guardianRepo) OptOutGuardian(token string) error {
+	query := r.db.Model(&model.Guardian{}).Where("opt_out_token = ?", token).Update("opt_out", true)
+	if err := query.Error; err != nil {
+		return err
+	}
+	if query.RowsAffected == 0 {
+		var isExist bool
+		r.db.Table("guardians").Select("count(*) > 0").Where("opt_out_token = ?", token).Find(&isExist)
+		if !isExist {
+			return gorm.ErrRecordNotFound
+		}
+	}
+	return nil
+}
+
+// ListGuardianDigest presensi tidak hadir dan terlambat dari blok yang selesai dalam rentang (since, until]
+// (format 2006-01-02 15:04:05) untuk setiap wali yang masih berlangganan, diurutkan per wali.
+// Blok malam yang melewati tengah malam selesai keesokan harinya sehingga masuk ringkasan berikutnya
+func (r guardianRepo) ListGuardianDigest(since string, until string) ([]model.GuardianDigestRecord, error) {
+	// jam selesai blok, pertemuan pindahan / kelas pengganti (blok 0) memakai jam dari data pengecualian
+	endAt := fmt.Sprintf(`COALESCE(
+		TIMESTAMP(a.date, ds.end_time) + INTERVAL IF(ds.end_time < ds.start_time, 1, 0) DAY,
+		(SELECT MAX(TIMESTAMP(se.new_date, se.end_time) + INTERVAL IF(se.end_time < se.start_time, 1, 0) DAY) FROM schedule_exceptions se
+			WHERE se.schedule_id = a.schedule_id AND se.new_date = a.date AND se.type IN ('%s','%s') AND se.end_time != ''),
+		TIMESTAMP(a.date, '23:59'))`, model.ExceptionReschedule, model.ExceptionMakeup)
+
+	var results []model.GuardianDigestRecord
+	if err := r.db.Table("attendances a").
+		Select(`g.id AS guardian_id, g.name AS guardian_name, g.email AS guardian_email, g.phone AS guardian_phone,
+		g.preferred_channel, g.opt_out_token, TRIM(CONCAT(u.first_name, ' ', u.last_name)) AS student_name, st.nim,
+		s.name AS schedule_name, s.code AS schedule_code, a.date, a.status_presence, a.status, a.late_in`).
+		Joins("JOIN students st ON st.user_id = a.user_id").
+		Joins("JOIN guardians g ON g.student_id = st.id AND g.opt_out = ?", false).
+		Joins("JOIN users u ON u.id = a.user_id").
+		Joins("JOIN schedules s ON s.id = a.schedule_id").
+		Joins("LEFT JOIN daily_schedules ds ON ds.id = a.daily_schedule_id").
+		Where("a.date BETWEEN DATE(?) - INTERVAL 1 DAY AND DATE(?)", since, until).
+		Where(endAt+" > ? AND "+endAt+" <= ?", since, until).
+		Where("(a.status_presence = ? OR (a.status_presence = ? AND a.status IN ?))",
+			"not_presence", "presence", []string{"late", "late_and_home_early"}).
+		Where(QueryTeachingDay("a.date", "a.schedule_id")).
+		Order("g.id, st.id, a.clock_in").
+		Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,318 @@
+package repo
+
+import (
+	"attendance-api/common/util/converter"
+	"attendance-api/model"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LeaveRequestRepo interface {
+	CreateLeaveRequest(leaveRequest model.LeaveRequest) (model.LeaveRequest, error)
+	RetrieveLeaveRequest(id int) (model.LeaveRequest, error)
+	RetrieveLeaveRequestByUser(id int, userID int) (model.LeaveRequest, error)
+	RetrieveLeaveRequestByOwner(id int, ownerID int) (model.LeaveRequest, error)
+	CancelLeaveRequest(id int, userID int) (model.LeaveRequest, error)
+	DecideLeaveRequest(id int, ownerID int, actorID int, decision model.LeaveRequestDecision) (model.LeaveRequest, error)
+	ListLeaveRequest(leaveRequest model.LeaveRequest, pagination model.Pagination) ([]model.LeaveRequest, error)
+	ListLeaveRequestMeta(leaveRequest model.LeaveRequest, pagination model.Pagination) (model.Meta, error)
+	ListLeaveRequestLog(leaveRequestID int) ([]model.LeaveRequestLog, error)
+	ListLeaveScheduleCandidate(userID int, startDate string, endDate string) ([]model.Schedule, error)
+	RetrieveApprovedStatusPresence(userID int, scheduleID int, date string) (statusPresence string)
+}
+
+type leaveRequestRepo struct {
+	db *gorm.DB
+}
+
+func NewLeaveRequestRepo(db *gorm.DB) LeaveRequestRepo {
+	return &leaveRequestRepo{db: db}
+}
+
+func (r leaveRequestRepo) CreateLeaveRequest(leaveRequest model.LeaveRequest) (model.LeaveRequest, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("leave_requests").Omit("User").Create(&leaveRequest).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.LeaveRequestLog{
+			GormCustom:     model.GormCustom{CreatedBy: leaveRequest.UserID, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+			LeaveRequestID: leaveRequest.ID,
+			Action:         "submit",
+			Status:         model.LeavePending,
+			Note:           leaveRequest.Reason,
+			ActorID:        leaveRequest.UserID,
+		}).Error
+	})
+	if err != nil {
+		return model.LeaveRequest{}, err
+	}
+
+	return r.RetrieveLeaveRequest(int(leaveRequest.ID))
+}
+
+func (r leaveRequestRepo) RetrieveLeaveRequest(id int) (model.LeaveRequest, error) {
+	var leaveRequest model.LeaveRequest
+	if err := PreloadLeaveRequest(r.db).First(&leaveRequest, id).Error; err != nil {
+		return model.LeaveRequest{}, err
+	}
+	return leaveRequest, nil
+}
+
+func (r leaveRequestRepo) RetrieveLeaveRequestByUser(id int, userID int) (model.LeaveRequest, error) {
+	var leaveRequest model.LeaveRequest
+	if err := PreloadLeaveRequest(r.db.Model(&model.LeaveRequest{})).Where("id = ? AND user_id = ?", id, userID).First(&leaveRequest).Error; err != nil {
+		return model.LeaveRequest{}, err
+	}
+	return leaveRequest, nil
+}
+
+// RetrieveLeaveRequestByOwner dosen hanya bisa melihat pengajuan yang mencakup jadwal miliknya
+func (r leaveRequestRepo) RetrieveLeaveRequestByOwner(id int, ownerID int) (model.LeaveRequest, error) {
+	var leaveRequest model.LeaveRequest
+	query := PreloadLeaveRequest(r.db.Model(&model.LeaveRequest{})).
+		Where("id = ? AND EXISTS (SELECT 1 FROM leave_request_items lri WHERE lri.leave_request_id = leave_requests.id AND lri.owner_id = ?)", id, ownerID).
+		First(&leaveRequest)
+	if err := query.Error; err != nil {
+		return model.LeaveRequest{}, err
+	}
+	return leaveRequest, nil
+}
+
+// CancelLeaveRequest pengajuan hanya bisa dibatalkan selama belum ada keputusan
+func (r leaveRequestRepo) CancelLeaveRequest(id int, userID int) (model.LeaveRequest, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var leaveRequest model.LeaveRequest
+		if err := tx.Preload("Items").Where("id = ? AND user_id = ?", id, userID).First(&leaveRequest).Error; err != nil {
+			return err
+		}
+		for _, item := range leaveRequest.Items {
+			if item.Status != model.LeavePending {
+				return errors.New("pengajuan yang sudah diputuskan tidak bisa dibatalkan")
+			}
+		}
+
+		if err := tx.Model(&model.LeaveRequestItem{}).Where("leave_request_id = ?", id).Update("status", model.LeaveCancelled).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.LeaveRequest{}).Where("id = ?", id).Update("status", model.LeaveCancelled).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.LeaveRequestLog{
+			GormCustom:     model.GormCustom{CreatedBy: userID, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+			LeaveRequestID: uint(id),
+			Action:         "cancel",
+			Status:         model.LeaveCancelled,
+			ActorID:        userID,
+		}).Error
+	})
+	if err != nil {
+		return model.LeaveRequest{}, err
+	}
+
+	return r.RetrieveLeaveRequest(id)
+}
+
+// DecideLeaveRequest memutuskan jadwal yang masih menunggu, ownerID 0 berarti semua jadwal (superadmin).
+// Persetujuan langsung mengubah status presensi yang sudah ada, presensi yang dibuat kemudian mengikuti lewat CreateAttendance.
+func (r leaveRequestRepo) DecideLeaveRequest(id int, ownerID int, actorID int, decision model.LeaveRequestDecision) (model.LeaveRequest, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var leaveRequest model.LeaveRequest
+		if err := tx.First(&leaveRequest, id).Error; err != nil {
+			return err
+		}
+
+		query := tx.Where("leave_request_id = ? AND status = ?", id, model.LeavePending)
+		if ownerID > 0 {
+			query = query.Where("owner_id = ?", ownerID)
+		}
+		if decision.ScheduleID > 0 {
+			query = query.Where("schedule_id = ?", decision.ScheduleID)
+		}
+		var items []model.LeaveRequestItem
+		if err := query.Find(&items).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return errors.New("tidak ada jadwal yang menunggu keputusan anda pada pengajuan ini")
+		}
+
+		decidedAt := time.Now()
+		for _, item := range items {
+			if err := tx.Model(&model.LeaveRequestItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+				"status":     decision.Status,
+				"note":       decision.Note,
+				"decided_by": actorID,
+				"decided_at": &decidedAt,
+				"updated_by": actorID,
+			}).Error; err != nil {
+				return err
+			}
+
+			if decision.Status == model.LeaveApproved {
+				// presensi yang sudah berisi absen masuk tidak ditimpa
+				if err := tx.Model(&model.Attendance{}).
+					Where("user_id = ? AND schedule_id = ? AND DATE(date) BETWEEN ? AND ?", leaveRequest.UserID, item.ScheduleID, converter.GetOnlyDateString(leaveRequest.StartDate), converter.GetOnlyDateString(leaveRequest.EndDate)).
+					Where("clock_in = 0 AND status_presence = ?", "not_presence").
+					Updates(map[string]interface{}{"status_presence": leaveRequest.GetStatusPresence(), "status": "-"}).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Create(&model.LeaveRequestLog{
+				GormCustom:     model.GormCustom{CreatedBy: actorID, CreatedAt: decidedAt, UpdatedAt: decidedAt},
+				LeaveRequestID: uint(id),
+				ScheduleID:     item.ScheduleID,
+				Action:         "decide",
+				Status:         decision.Status,
+				Note:           decision.Note,
+				ActorID:        actorID,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		var allItems []model.LeaveRequestItem
+		if err := tx.Where("leave_request_id = ?", id).Find(&allItems).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.LeaveRequest{}).Where("id = ?", id).Update("status", model.GenerateLeaveStatus(allItems)).Error
+	})
+	if err != nil {
+		return model.LeaveRequest{}, err
+	}
+
+	return r.RetrieveLeaveRequest(id)
+}
+
+func (r leaveRequestRepo) ListLeaveRequest(leaveRequest model.LeaveRequest, pagination model.Pagination) ([]model.LeaveRequest, error) {
+	var leaveRequests []model.LeaveRequest
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	query := PreloadLeaveRequest(r.db.Table("leave_requests")).Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterLeaveRequest(query, leaveRequest)
+	query = SearchLeaveRequest(query, pagination.Search)
+	query = query.Find(&leaveRequests)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+
+	return leaveRequests, nil
+}
+
+func (r leaveRequestRepo) ListLeaveRequestMeta(leaveRequest model.LeaveRequest, pagination model.Pagination) (model.Meta, error) {
+	var leaveRequests []model.LeaveRequest
+	var totalRecord int
+	var totalPage int
+
+	queryTotal := r.db.Model(&model.LeaveRequest{}).Select("count(*)")
+	queryTotal = FilterLeaveRequest(queryTotal, leaveRequest)
+	queryTotal = SearchLeaveRequest(queryTotal, pagination.Search)
+	queryTotal = queryTotal.Scan(&totalRecord)
+	if err := queryTotal.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	totalPage = int(totalRecord / pagination.Limit)
+	if totalRecord%pagination.Limit > 0 {
+		totalPage += 1
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	query := r.db.Table("leave_requests").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterLeaveRequest(query, leaveRequest)
+	query = SearchLeaveRequest(query, pagination.Search)
+	query = query.Find(&leaveRequests)
+	if err := query.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	meta := model.Meta{
+		CurrentPage:   pagination.Page,
+		TotalPage:     totalPage,
+		TotalRecord:   totalRecord,
+		CurrentRecord: len(leaveRequests),
+	}
+	return meta, nil
+}
+
+func (r leaveRequestRepo) ListLeaveRequestLog(leaveRequestID int) ([]model.LeaveRequestLog, error) {
+	var logs []model.LeaveRequestLog
+	if err := r.db.Where("leave_request_id = ?", leaveRequestID).Order("id asc").Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// ListLeaveScheduleCandidate jadwal yang diikuti mahasiswa dan periodenya beririsan dengan rentang pengajuan
+func (r leaveRequestRepo) ListLeaveScheduleCandidate(userID int, startDate string, endDate string) ([]model.Schedule, error) {
+	var schedules []model.Schedule
+	query := r.db.Table("schedules").Select("schedules.*").
+		Joins("JOIN user_schedules ON user_schedules.schedule_id = schedules.id").
+		Where("user_schedules.user_id = ? AND schedules.start_date <= ? AND schedules.end_date >= ?", userID, endDate, startDate).
+		Group("schedules.id").
+		Find(&schedules)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// RetrieveApprovedStatusPresence status presensi dari pengajuan yang disetujui pada tanggal tersebut, kosong jika tidak ada
+func (r leaveRequestRepo) RetrieveApprovedStatusPresence(userID int, scheduleID int, date string) (statusPresence string) {
+	var leaveRequest model.LeaveRequest
+	query := r.db.Table("leave_requests").Select("leave_requests.*").
+		Joins("JOIN leave_request_items ON leave_request_items.leave_request_id = leave_requests.id").
+		Where("leave_requests.user_id = ? AND leave_request_items.schedule_id = ? AND leave_request_items.status = ?", userID, scheduleID, model.LeaveApproved).
+		Where("? BETWEEN leave_requests.start_date AND leave_requests.end_date", date).
+		Order("leave_requests.id desc").
+		Limit(1).
+		Find(&leaveRequest)
+	if query.Error != nil || leaveRequest.ID == 0 {
+		return ""
+	}
+	return leaveRequest.GetStatusPresence()
+}
+
+func PreloadLeaveRequest(query *gorm.DB) *gorm.DB {
+	query = query.Preload("User", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "username", "first_name", "last_name", "email", "profile")
+	})
+	query = query.Preload("Items")
+	query = query.Preload("Items.Schedule")
+	query = query.Preload("Items.Schedule.Subject")
+	return query
+}
+
+func FilterLeaveRequest(query *gorm.DB, leaveRequest model.LeaveRequest) *gorm.DB {
+	if leaveRequest.UserID > 0 {
+		query = query.Where("leave_requests.user_id = ?", leaveRequest.UserID)
+	}
+	if leaveRequest.Type != "" {
+		query = query.Where("leave_requests.type = ?", leaveRequest.Type)
+	}
+	if leaveRequest.Status != "" {
+		query = query.Where("leave_requests.status = ?", leaveRequest.Status)
+	}
+	if leaveRequest.StartDate != "" {
+		query = query.Where("leave_requests.end_date >= ?", leaveRequest.StartDate)
+	}
+	if leaveRequest.EndDate != "" {
+		query = query.Where("leave_requests.start_date <= ?", leaveRequest.EndDate)
+	}
+	if leaveRequest.ScheduleID > 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM leave_request_items lrs WHERE lrs.leave_request_id = leave_requests.id AND lrs.schedule_id = ?)", leaveRequest.ScheduleID)
+	}
+	if leaveRequest.OwnerID > 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM leave_request_items lro WHERE lro.leave_request_id = leave_requests.id AND lro.owner_id = ?)", leaveRequest.OwnerID)
+	}
+	return query
+}
+
+func SearchLeaveRequest(query *gorm.DB, search string) *gorm.DB {
+	if search != "" {
+		query = query.Where("leave_requests.reason LIKE ?", "%"+search+"%")
+	}
+	return query
+}
@@ -0,0 +1,248 @@
+package repo
+
+import (
+	"attendance-api/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LectureJournalRepo interface {
+	CreateLectureJournal(lectureJournal model.LectureJournal) (model.LectureJournal, error)
+	RetrieveLectureJournal(id int) (model.LectureJournal, error)
+	RetrieveLectureJournalByOwner(id int, ownerID int) (model.LectureJournal, error)
+	UpdateLectureJournal(id int, lectureJournal model.LectureJournal) (model.LectureJournal, error)
+	UpdateLectureJournalByOwner(id int, ownerID int, lectureJournal model.LectureJournal) (model.LectureJournal, error)
+	UpdateLectureJournalCount(id int, count model.LectureJournalCount) error
+	UpdateLectureJournalStatus(id int, status string, submittedAt *time.Time) error
+	DeleteLectureJournal(id int) error
+	DeleteLectureJournalByOwner(id int, ownerID int) error
+	ListLectureJournal(lectureJournal model.LectureJournal, pagination model.Pagination) ([]model.LectureJournal, error)
+	ListLectureJournalMeta(lectureJournal model.LectureJournal, pagination model.Pagination) (model.Meta, error)
+	CheckIsExistByDate(scheduleID int, date string, exceptID int) (isExist bool)
+	CountLectureJournalAttendance(scheduleID int, date string) (count model.LectureJournalCount)
+}
+
+type lectureJournalRepo struct {
+	db *gorm.DB
+}
+
+func NewLectureJournalRepo(db *gorm.DB) LectureJournalRepo {
+	return &lectureJournalRepo{db: db}
+}
+
+func (r lectureJournalRepo) CreateLectureJournal(lectureJournal model.LectureJournal) (model.LectureJournal, error) {
+	if err := r.db.Table("lecture_journals").Omit("Schedule").Create(&lectureJournal).Error; err != nil {
+		return model.LectureJournal{}, err
+	}
+
+	return lectureJournal, nil
+}
+
+func (r lectureJournalRepo) RetrieveLectureJournal(id int) (model.LectureJournal, error) {
+	var lectureJournal model.LectureJournal
+	if err := PreloadLectureJournal(r.db).First(&lectureJournal, id).Error; err != nil {
+		return model.LectureJournal{}, err
+	}
+	return lectureJournal, nil
+}
+
+func (r lectureJournalRepo) RetrieveLectureJournalByOwner(id int, ownerID int) (model.LectureJournal, error) {
+	var lectureJournal model.LectureJournal
+	if err := PreloadLectureJournal(r.db.Model(&model.LectureJournal{})).Where("id = ? AND owner_id = ?", id, ownerID).First(&lectureJournal).Error; err != nil {
+		return model.LectureJournal{}, err
+	}
+	return lectureJournal, nil
+}
+
+func (r lectureJournalRepo) UpdateLectureJournal(id int, lectureJournal model.LectureJournal) (model.LectureJournal, error) {
+	if err := r.db.Model(&model.LectureJournal{}).Where("id = ?", id).Omit("Schedule").Updates(&lectureJournal).Error; err != nil {
+		return model.LectureJournal{}, err
+	}
+	return lectureJournal, nil
+}
+
+func (r lectureJournalRepo) UpdateLectureJournalByOwner(id int, ownerID int, lectureJournal model.LectureJournal) (model.LectureJournal, error) {
+	if err := r.db.Model(&model.LectureJournal{}).Where("id = ? AND owner_id = ?", id, ownerID).Omit("Schedule").Updates(&lectureJournal).Error; err != nil {
+		return model.LectureJournal{}, err
+	}
+	return lectureJournal, nil
+}
+
+// UpdateLectureJournalCount memakai map agar jumlah 0 tetap tersimpan
+func (r lectureJournalRepo) UpdateLectureJournalCount(id int, count model.LectureJournalCount) error {
+	if err := r.db.Model(&model.LectureJournal{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"total_student":      count.TotalStudent,
+		"presence_count":     count.PresenceCount,
+		"not_presence_count": count.NotPresenceCount,
+		"sick_count":         count.SickCount,
+		"leave_count":        count.LeaveCount,
+	}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r lectureJournalRepo) UpdateLectureJournalStatus(id int, status string, submittedAt *time.Time) error {
+	if err := r.db.Model(&model.LectureJournal{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       status,
+		"submitted_at": submittedAt,
+	}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r lectureJournalRepo) DeleteLectureJournal(id int) error {
+	if err := r.db.Delete(&model.LectureJournal{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r lectureJournalRepo) DeleteLectureJournalByOwner(id int, ownerID int) error {
+	if err := r.db.Where("id = ? AND owner_id = ?", id, ownerID).Delete(&model.LectureJournal{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r lectureJournalRepo) ListLectureJournal(lectureJournal model.LectureJournal, pagination model.Pagination) ([]model.LectureJournal, error) {
+	var lectureJournals []model.LectureJournal
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	query := PreloadLectureJournal(r.db.Table("lecture_journals")).Select("lecture_journals.*").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterLectureJournal(query, lectureJournal)
+	query = SearchLectureJournal(query, pagination.Search)
+	query = query.Find(&lectureJournals)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+
+	return lectureJournals, nil
+}
+
+func (r lectureJournalRepo) ListLectureJournalMeta(lectureJournal model.LectureJournal, pagination model.Pagination) (model.Meta, error) {
+	var lectureJournals []model.LectureJournal
+	var totalRecord int
+	var totalPage int
+
+	queryTotal := r.db.Model(&model.LectureJournal{}).Select("count(*)")
+	queryTotal = FilterLectureJournal(queryTotal, lectureJournal)
+	queryTotal = SearchLectureJournal(queryTotal, pagination.Search)
+	queryTotal = queryTotal.Scan(&totalRecord)
+	if err := queryTotal.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	totalPage = int(totalRecord / pagination.Limit)
+	if totalRecord%pagination.Limit > 0 {
+		totalPage += 1
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	query := r.db.Table("lecture_journals").Select("lecture_journals.*").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterLectureJournal(query, lectureJournal)
+	query = SearchLectureJournal(query, pagination.Search)
+	query = query.Find(&lectureJournals)
+	if err := query.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	meta := model.Meta{
+		CurrentPage:   pagination.Page,
+		TotalPage:     totalPage,
+		TotalRecord:   totalRecord,
+		CurrentRecord: len(lectureJournals),
+	}
+	return meta, nil
+}
+
+// CheckIsExistByDate satu jadwal hanya memiliki satu jurnal per tanggal pertemuan
+func (r lectureJournalRepo) CheckIsExistByDate(scheduleID int, date string, exceptID int) (isExist bool) {
+	query := r.db.Table("lecture_journals").Select("count(*) > 0").
+		Where("schedule_id = ? AND date = ? AND id != ?", scheduleID, date, exceptID)
+	if err := query.Find(&isExist).Error; err != nil {
+		return false
+	}
+	return
+}
+
+// CountLectureJournalAttendance mahasiswa peserta jadwal yang belum memiliki data presensi dihitung tidak hadir,
+// mahasiswa dihitung sekali per tanggal walaupun memiliki presensi di beberapa blok jadwal (hadir > sakit > izin)
+func (r lectureJournalRepo) CountLectureJournalAttendance(scheduleID int, date string) (count model.LectureJournalCount) {
+	r.db.Table("user_schedules").Select("count(*)").Where("schedule_id = ? AND user_id != ?", scheduleID, 0).Find(&count.TotalStudent)
+
+	var rows []struct {
+		StatusPresence string
+		Total          int
+	}
+	perUser := r.db.Table("attendances").
+		Select(`user_id, CASE
+			WHEN SUM(status_presence = 'presence') > 0 THEN 'presence'
+			WHEN SUM(status_presence = 'sick') > 0 THEN 'sick'
+			WHEN SUM(status_presence = 'leave_attendance') > 0 THEN 'leave_attendance'
+			ELSE '' END AS status_presence`).
+		Where("schedule_id = ? AND DATE(date) = ?", scheduleID, date).
+		Group("user_id")
+	query := r.db.Table("(?) AS a", perUser).Select("status_presence, count(*) AS total").
+		Group("status_presence").
+		Find(&rows)
+	if err := query.Error; err != nil {
+		return
+	}
+
+	for _, row := range rows {
+		switch row.StatusPresence {
+		case "presence":
+			count.PresenceCount = row.Total
+		case "sick":
+			count.SickCount = row.Total
+		case "leave_attendance":
+			count.LeaveCount = row.Total
+		}
+	}
+	count.NotPresenceCount = count.TotalStudent - count.PresenceCount - count.SickCount - count.LeaveCount
+	if count.NotPresenceCount < 0 {
+		count.NotPresenceCount = 0
+	}
+	return
+}
+
+func PreloadLectureJournal(query *gorm.DB) *gorm.DB {
+	query = query.Preload("Schedule")
+	query = query.Preload("Schedule.Subject")
+	return query
+}
+
+func FilterLectureJournal(query *gorm.DB, lectureJournal model.LectureJournal) *gorm.DB {
+	if lectureJournal.ScheduleID > 0 {
+		query = query.Where("lecture_journals.schedule_id = ?", lectureJournal.ScheduleID)
+	}
+	if lectureJournal.SubjectID > 0 {
+		query = query.Joins("JOIN schedules ON lecture_journals.schedule_id = schedules.id").Where("schedules.subject_id = ?", lectureJournal.SubjectID)
+	}
+	if lectureJournal.Status != "" {
+		query = query.Where("lecture_journals.status = ?", lectureJournal.Status)
+	}
+	if lectureJournal.Date != "" {
+		query = query.Where("lecture_journals.date = ?", lectureJournal.Date)
+	}
+	if lectureJournal.StartDate != "" {
+		query = query.Where("lecture_journals.date >= ?", lectureJournal.StartDate)
+	}
+	if lectureJournal.EndDate != "" {
+		query = query.Where("lecture_journals.date <= ?", lectureJournal.EndDate)
+	}
+	if lectureJournal.OwnerID > 0 {
+		query = query.Where("lecture_journals.owner_id = ?", lectureJournal.OwnerID)
+	}
+	return query
+}
+
+func SearchLectureJournal(query *gorm.DB, search string) *gorm.DB {
+	if search != "" {
+		query = query.Where("(lecture_journals.topic LIKE ? OR lecture_journals.method LIKE ? OR lecture_journals.notes LIKE ?)", "%"+search+"%", "%"+search+"%", "%"+search+"%")
+	}
+	return query
+}
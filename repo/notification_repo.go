@@ -0,0 +1,179 @@
+package repo
+
+import (
+	"attendance-api/model"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepo interface {
+	CreateNotification(notification model.Notification) (model.Notification, error)
+	ListNotification(notification model.Notification, pagination model.Pagination) ([]model.Notification, error)
+	ListNotificationMeta(notification model.Notification, pagination model.Pagination) (model.Meta, error)
+	CountUnreadNotification(userID int) (int64, error)
+	ReadNotification(id int, userID int) (model.Notification, error)
+	ReadAllNotification(userID int) (int64, error)
+	DeleteNotification(id int, userID int) error
+	ListNotificationPreference(userID int) ([]model.NotificationPreference, error)
+	SaveNotificationPreference(userID int, preferences []model.NotificationPreference) ([]model.NotificationPreference, error)
+}
+
+type notificationRepo struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepo(db *gorm.DB) NotificationRepo {
+	return &notificationRepo{db: db}
+}
+
+func (r notificationRepo) CreateNotification(notification model.Notification) (model.Notification, error) {
+	if err := r.db.Create(&notification).Error; err != nil {
+		return model.Notification{}, err
+	}
+
+	return notification, nil
+}
+
+func (r notificationRepo) ListNotification(notification model.Notification, pagination model.Pagination) ([]model.Notification, error) {
+	var notifications []model.Notification
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	query := r.db.Table("notifications").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterNotification(query, notification)
+	query = query.Find(&notifications)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (r notificationRepo) ListNotificationMeta(notification model.Notification, pagination model.Pagination) (model.Meta, error) {
+	var notifications []model.Notification
+	var totalRecord int
+	var totalPage int
+
+	queryTotal := r.db.Model(&model.Notification{}).Select("count(*)")
+	queryTotal = FilterNotification(queryTotal, notification)
+	queryTotal = queryTotal.Scan(&totalRecord)
+	if err := queryTotal.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	totalPage = int(totalRecord / pagination.Limit)
+	if totalRecord%pagination.Limit > 0 {
+		totalPage += 1
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	query := r.db.Table("notifications").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterNotification(query, notification)
+	query = query.Find(&notifications)
+	if err := query.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	meta := model.Meta{
+		CurrentPage:   pagination.Page,
+		TotalPage:     totalPage,
+		TotalRecord:   totalRecord,
+		CurrentRecord: len(notifications),
+	}
+	return meta, nil
+}
+
+func (r notificationRepo) CountUnreadNotification(userID int) (int64, error) {
+	var total int64
+	if err := r.db.Model(&model.Notification{}).Where("user_id = ? AND is_read = ?", userID, false).Count(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r notificationRepo) ReadNotification(id int, userID int) (model.Notification, error) {
+	var notification model.Notification
+	if err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		return model.Notification{}, errors.New("notifikasi tidak ditemukan")
+	}
+	if notification.IsRead {
+		return notification, nil
+	}
+
+	if err := r.db.Model(&notification).Updates(map[string]interface{}{"is_read": true, "updated_by": userID}).Error; err != nil {
+		return model.Notification{}, err
+	}
+	return notification, nil
+}
+
+// ReadAllNotification menandai seluruh pesan pengguna sebagai sudah dibaca, mengembalikan jumlah pesan yang berubah
+func (r notificationRepo) ReadAllNotification(userID int) (int64, error) {
+	query := r.db.Model(&model.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Updates(map[string]interface{}{"is_read": true, "updated_by": userID})
+	if err := query.Error; err != nil {
+		return 0, err
+	}
+	return query.RowsAffected, nil
+}
+
+func (r notificationRepo) DeleteNotification(id int, userID int) error {
+	query := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&model.Notification{})
+	if err := query.Error; err != nil {
+		return err
+	}
+	if query.RowsAffected == 0 {
+		return errors.New("notifikasi tidak ditemukan")
+	}
+	return nil
+}
+
+func (r notificationRepo) ListNotificationPreference(userID int) ([]model.NotificationPreference, error) {
+	var preferences []model.NotificationPreference
+	if err := r.db.Where("user_id = ?", userID).Order("channel").Find(&preferences).Error; err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
+
+// SaveNotificationPreference preferensi per kanal, kanal yang tidak dikirim tidak diubah
+func (r notificationRepo) SaveNotificationPreference(userID int, preferences []model.NotificationPreference) ([]model.NotificationPreference, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, preference := range preferences {
+			var current model.NotificationPreference
+			if err := tx.Where("user_id = ? AND channel = ?", userID, preference.Channel).Limit(1).Find(&current).Error; err != nil {
+				return err
+			}
+			preference.ID = current.ID
+			preference.UserID = userID
+			if current.ID > 0 {
+				preference.CreatedAt = current.CreatedAt
+				preference.CreatedBy = current.CreatedBy
+			}
+			if err := tx.Save(&preference).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.ListNotificationPreference(userID)
+}
+
+func FilterNotification(query *gorm.DB, notification model.Notification) *gorm.DB {
+	if notification.UserID > 0 {
+		query = query.Where("user_id = ?", notification.UserID)
+	}
+	if notification.Event != "" {
+		query = query.Where("event = ?", notification.Event)
+	}
+	if notification.Title != "" {
+		query = query.Where("title LIKE ?", "%"+notification.Title+"%")
+	}
+	if notification.UnreadOnly {
+		query = query.Where("is_read = ?", false)
+	}
+	return query
+}
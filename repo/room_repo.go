@@ -0,0 +1,268 @@
+package repo
+
+import (
+	"attendance-api/model"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type RoomRepo interface {
+	CreateRoom(room model.Room) (model.Room, error)
+	RetrieveRoom(id int) (model.Room, error)
+	RetrieveRoomByOwner(id int, ownerID int) (model.Room, error)
+	UpdateRoom(id int, room model.Room) (model.Room, error)
+	UpdateRoomByOwner(id int, ownerID int, room model.Room) (model.Room, error)
+	DeleteRoom(id int) error
+	DeleteRoomByOwner(id int, ownerID int) error
+	ListRoom(room model.Room, pagination model.Pagination) ([]model.Room, error)
+	ListRoomMeta(room model.Room, pagination model.Pagination) (model.Meta, error)
+	DropDownRoom(room model.Room) ([]model.Room, error)
+	CheckIsExist(id int) (isExist bool)
+	CheckIsExistByName(name string, exceptID int) (isExist bool)
+	CheckIsExistByCode(code string, exceptID int) (isExist bool)
+	CheckIsUsed(id int) (isUsed bool)
+	MigrateRoomFromSchedule() (total int, err error)
+}
+
+type roomRepo struct {
+	db *gorm.DB
+}
+
+func NewRoomRepo(db *gorm.DB) RoomRepo {
+	return &roomRepo{db: db}
+}
+
+func (r roomRepo) CreateRoom(room model.Room) (model.Room, error) {
+	if err := r.db.Table("rooms").Create(&room).Error; err != nil {
+		return model.Room{}, err
+	}
+
+	return room, nil
+}
+
+func (r roomRepo) RetrieveRoom(id int) (model.Room, error) {
+	var room model.Room
+	if err := PreloadRoom(r.db.Model(&model.Room{})).First(&room, id).Error; err != nil {
+		return model.Room{}, err
+	}
+	return room, nil
+}
+
+func (r roomRepo) RetrieveRoomByOwner(id int, ownerID int) (model.Room, error) {
+	var room model.Room
+	if err := PreloadRoom(r.db.Model(&model.Room{})).Where("id = ? AND owner_id = ?", id, ownerID).First(&room).Error; err != nil {
+		return model.Room{}, err
+	}
+	return room, nil
+}
+
+func (r roomRepo) UpdateRoom(id int, room model.Room) (model.Room, error) {
+	if err := r.db.Model(&model.Room{}).Where("id = ?", id).Updates(&room).Error; err != nil {
+		return model.Room{}, err
+	}
+	return room, nil
+}
+
+func (r roomRepo) UpdateRoomByOwner(id int, ownerID int, room model.Room) (model.Room, error) {
+	if err := r.db.Model(&model.Room{}).Where("id = ? AND owner_id = ?", id, ownerID).Updates(&room).Error; err != nil {
+		return model.Room{}, err
+	}
+	return room, nil
+}
+
+func (r roomRepo) DeleteRoom(id int) error {
+	if err := r.db.Delete(&model.Room{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r roomRepo) DeleteRoomByOwner(id int, ownerID int) error {
+	if err := r.db.Where("id = ? AND owner_id = ?", id, ownerID).Delete(&model.Room{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r roomRepo) ListRoom(room model.Room, pagination model.Pagination) ([]model.Room, error) {
+	var rooms []model.Room
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	query := r.db.Table("rooms").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = PreloadRoom(query)
+	query = FilterRoom(query, room)
+	query = SearchRoom(query, pagination.Search)
+	query = query.Find(&rooms)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+
+	return rooms, nil
+}
+
+func (r roomRepo) ListRoomMeta(room model.Room, pagination model.Pagination) (model.Meta, error) {
+	var rooms []model.Room
+	var totalRecord int
+	var totalPage int
+
+	queryTotal := r.db.Model(&model.Room{}).Select("count(*)")
+	queryTotal = FilterRoom(queryTotal, room)
+	queryTotal = SearchRoom(queryTotal, pagination.Search)
+	queryTotal = queryTotal.Scan(&totalRecord)
+	if err := queryTotal.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	totalPage = int(totalRecord / pagination.Limit)
+	if totalRecord%pagination.Limit > 0 {
+		totalPage += 1
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	query := r.db.Table("rooms").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterRoom(query, room)
+	query = SearchRoom(query, pagination.Search)
+	query = query.Find(&rooms)
+	if err := query.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	meta := model.Meta{
+		CurrentPage:   pagination.Page,
+		TotalPage:     totalPage,
+		TotalRecord:   totalRecord,
+		CurrentRecord: len(rooms),
+	}
+	return meta, nil
+}
+
+func (r roomRepo) DropDownRoom(room model.Room) ([]model.Room, error) {
+	var rooms []model.Room
+	query := r.db.Table("rooms").Order("id desc")
+	query = FilterRoom(query, room)
+	query = query.Find(&rooms)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+func (r roomRepo) CheckIsExist(id int) (isExist bool) {
+	if err := r.db.Table("rooms").Select("count(*) > 0").Where("id = ?", id).Find(&isExist).Error; err != nil {
+		return false
+	}
+	return
+}
+
+func (r roomRepo) CheckIsExistByName(name string, exceptID int) (isExist bool) {
+	if err := r.db.Table("rooms").Select("count(*) > 0").Where("LOWER(name) = ? AND id != ?", strings.ToLower(name), exceptID).Find(&isExist).Error; err != nil {
+		return false
+	}
+	return
+}
+
+func (r roomRepo) CheckIsExistByCode(code string, exceptID int) (isExist bool) {
+	if err := r.db.Table("rooms").Select("count(*) > 0").Where("code = ? AND id != ?", code, exceptID).Find(&isExist).Error; err != nil {
+		return false
+	}
+	return
+}
+
+func (r roomRepo) CheckIsUsed(id int) (isUsed bool) {
+	if err := r.db.Table("schedules").Select("count(*) > 0").Where("room_id = ?", id).Find(&isUsed).Error; err != nil {
+		return true
+	}
+	return
+}
+
+// MigrateRoomFromSchedule membuat ruangan dari koordinat jadwal yang berbeda-beda lalu menautkan jadwal ke ruangan tersebut
+func (r roomRepo) MigrateRoomFromSchedule() (total int, err error) {
+	var locations []struct {
+		Latitude  float64
+		Longitude float64
+		Radius    int
+		OwnerID   int
+	}
+	if err := r.db.Table("schedules").
+		Select("latitude, longitude, MAX(radius) AS radius, MIN(owner_id) AS owner_id").
+		Where("room_id IS NULL AND latitude != 0 AND longitude != 0").
+		Group("latitude, longitude").
+		Order("MIN(id)").
+		Scan(&locations).Error; err != nil {
+		return 0, err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var number int64
+		if err := tx.Model(&model.Room{}).Count(&number).Error; err != nil {
+			return err
+		}
+
+		for _, location := range locations {
+			// kode ruangan unik, nomor dinaikkan sampai kode belum dipakai (ruangan dihapus atau dibuat manual)
+			var code string
+			for {
+				number++
+				code = fmt.Sprintf("R-%03d", number)
+				var count int64
+				if err := tx.Model(&model.Room{}).Where("code = ?", code).Count(&count).Error; err != nil {
+					return err
+				}
+				if count == 0 {
+					break
+				}
+			}
+
+			room := model.Room{
+				Name:      fmt.Sprintf("Ruangan %d", number),
+				Code:      code,
+				Latitude:  location.Latitude,
+				Longitude: location.Longitude,
+				Radius:    location.Radius,
+				OwnerID:   location.OwnerID,
+			}
+			if err := tx.Create(&room).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Table("schedules").
+				Where("room_id IS NULL AND latitude = ? AND longitude = ?", location.Latitude, location.Longitude).
+				Update("room_id", room.ID).Error; err != nil {
+				return err
+			}
+			total++
+		}
+		return nil
+	})
+	return
+}
+
+func FilterRoom(query *gorm.DB, room model.Room) *gorm.DB {
+	if room.Name != "" {
+		query = query.Where("name LIKE ?", "%"+room.Name+"%")
+	}
+	if room.Code != "" {
+		query = query.Where("code LIKE ?", "%"+room.Code+"%")
+	}
+	if room.Building != "" {
+		query = query.Where("building LIKE ?", "%"+room.Building+"%")
+	}
+	if room.OwnerID > 0 {
+		query = query.Where("owner_id = ?", room.OwnerID)
+	}
+	return query
+}
+
+func SearchRoom(query *gorm.DB, search string) *gorm.DB {
+	if search != "" {
+		query = query.Where("name LIKE ? OR code LIKE ? OR building LIKE ? OR summary LIKE ? ", "%"+search+"%", "%"+search+"%", "%"+search+"%", "%"+search+"%")
+	}
+	return query
+}
+
+func PreloadRoom(query *gorm.DB) *gorm.DB {
+	query = query.Preload("GeofenceZone")
+	return query
+}
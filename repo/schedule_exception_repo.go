@@ -0,0 +1,230 @@
+package repo
+
+import (
+	"attendance-api/model"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type ScheduleExceptionRepo interface {
+	CreateScheduleException(scheduleException model.ScheduleException) (model.ScheduleException, error)
+	RetrieveScheduleException(id int) (model.ScheduleException, error)
+	RetrieveScheduleExceptionByOwner(id int, ownerID int) (model.ScheduleException, error)
+	ListMeetingException(scheduleID int, date string) ([]model.ScheduleException, error)
+	UpdateScheduleException(id int, scheduleException model.ScheduleException) (model.ScheduleException, error)
+	UpdateScheduleExceptionByOwner(id int, ownerID int, scheduleException model.ScheduleException) (model.ScheduleException, error)
+	DeleteScheduleException(id int) error
+	DeleteScheduleExceptionByOwner(id int, ownerID int) error
+	DeleteScheduleExceptionByScheduleID(scheduleID int) error
+	ListScheduleException(scheduleException model.ScheduleException, pagination model.Pagination) ([]model.ScheduleException, error)
+	ListScheduleExceptionMeta(scheduleException model.ScheduleException, pagination model.Pagination) (model.Meta, error)
+	CheckIsExistByDate(scheduleID int, date string, dailyScheduleID int, exceptID int) (isExist bool)
+	CheckIsExistByNewDate(scheduleID int, newDate string, exceptID int) (isExist bool)
+}
+
+type scheduleExceptionRepo struct {
+	db *gorm.DB
+}
+
+func NewScheduleExceptionRepo(db *gorm.DB) ScheduleExceptionRepo {
+	return &scheduleExceptionRepo{db: db}
+}
+
+func (r scheduleExceptionRepo) CreateScheduleException(scheduleException model.ScheduleException) (model.ScheduleException, error) {
+	if err := r.db.Table("schedule_exceptions").Create(&scheduleException).Error; err != nil {
+		return model.ScheduleException{}, err
+	}
+
+	return scheduleException, nil
+}
+
+func (r scheduleExceptionRepo) RetrieveScheduleException(id int) (model.ScheduleException, error) {
+	var scheduleException model.ScheduleException
+	if err := PreloadScheduleException(r.db).First(&scheduleException, id).Error; err != nil {
+		return model.ScheduleException{}, err
+	}
+	return scheduleException, nil
+}
+
+func (r scheduleExceptionRepo) RetrieveScheduleExceptionByOwner(id int, ownerID int) (model.ScheduleException, error) {
+	var scheduleException model.ScheduleException
+	if err := PreloadScheduleException(r.db.Model(&model.ScheduleException{})).Where("id = ? AND owner_id = ?", id, ownerID).First(&scheduleException).Error; err != nil {
+		return model.ScheduleException{}, err
+	}
+	return scheduleException, nil
+}
+
+// ListMeetingException semua pertemuan pindahan atau kelas pengganti yang berlangsung pada tanggal tersebut, urut jam mulai
+func (r scheduleExceptionRepo) ListMeetingException(scheduleID int, date string) ([]model.ScheduleException, error) {
+	var scheduleExceptions []model.ScheduleException
+	query := PreloadScheduleException(r.db.Model(&model.ScheduleException{})).
+		Where("schedule_id = ? AND new_date = ? AND type IN ?", scheduleID, date, []string{model.ExceptionReschedule, model.ExceptionMakeup}).
+		Order("start_time asc").
+		Find(&scheduleExceptions)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+	return scheduleExceptions, nil
+}
+
+func (r scheduleExceptionRepo) UpdateScheduleException(id int, scheduleException model.ScheduleException) (model.ScheduleException, error) {
+	if err := r.db.Model(&model.ScheduleException{}).Where("id = ?", id).Updates(&scheduleException).Error; err != nil {
+		return model.ScheduleException{}, err
+	}
+	// daily_schedule_id 0 (seluruh blok) tidak ikut tersimpan oleh Updates struct
+	if err := r.db.Model(&model.ScheduleException{}).Where("id = ?", id).Update("daily_schedule_id", scheduleException.DailyScheduleID).Error; err != nil {
+		return model.ScheduleException{}, err
+	}
+	return scheduleException, nil
+}
+
+func (r scheduleExceptionRepo) UpdateScheduleExceptionByOwner(id int, ownerID int, scheduleException model.ScheduleException) (model.ScheduleException, error) {
+	if err := r.db.Model(&model.ScheduleException{}).Where("id = ? AND owner_id = ?", id, ownerID).Updates(&scheduleException).Error; err != nil {
+		return model.ScheduleException{}, err
+	}
+	// daily_schedule_id 0 (seluruh blok) tidak ikut tersimpan oleh Updates struct
+	if err := r.db.Model(&model.ScheduleException{}).Where("id = ? AND owner_id = ?", id, ownerID).Update("daily_schedule_id", scheduleException.DailyScheduleID).Error; err != nil {
+		return model.ScheduleException{}, err
+	}
+	return scheduleException, nil
+}
+
+func (r scheduleExceptionRepo) DeleteScheduleException(id int) error {
+	if err := r.db.Delete(&model.ScheduleException{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r scheduleExceptionRepo) DeleteScheduleExceptionByOwner(id int, ownerID int) error {
+	if err := r.db.Where("id = ? AND owner_id = ?", id, ownerID).Delete(&model.ScheduleException{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r scheduleExceptionRepo) DeleteScheduleExceptionByScheduleID(scheduleID int) error {
+	if err := r.db.Where("schedule_id = ?", scheduleID).Delete(&model.ScheduleException{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r scheduleExceptionRepo) ListScheduleException(scheduleException model.ScheduleException, pagination model.Pagination) ([]model.ScheduleException, error) {
+	var scheduleExceptions []model.ScheduleException
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	query := PreloadScheduleException(r.db.Table("schedule_exceptions")).Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterScheduleException(query, scheduleException)
+	query = SearchScheduleException(query, pagination.Search)
+	query = query.Find(&scheduleExceptions)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+
+	return scheduleExceptions, nil
+}
+
+func (r scheduleExceptionRepo) ListScheduleExceptionMeta(scheduleException model.ScheduleException, pagination model.Pagination) (model.Meta, error) {
+	var scheduleExceptions []model.ScheduleException
+	var totalRecord int
+	var totalPage int
+
+	queryTotal := r.db.Model(&model.ScheduleException{}).Select("count(*)")
+	queryTotal = FilterScheduleException(queryTotal, scheduleException)
+	queryTotal = SearchScheduleException(queryTotal, pagination.Search)
+	queryTotal = queryTotal.Scan(&totalRecord)
+	if err := queryTotal.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	totalPage = int(totalRecord / pagination.Limit)
+	if totalRecord%pagination.Limit > 0 {
+		totalPage += 1
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	query := r.db.Table("schedule_exceptions").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterScheduleException(query, scheduleException)
+	query = SearchScheduleException(query, pagination.Search)
+	query = query.Find(&scheduleExceptions)
+	if err := query.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	meta := model.Meta{
+		CurrentPage:   pagination.Page,
+		TotalPage:     totalPage,
+		TotalRecord:   totalRecord,
+		CurrentRecord: len(scheduleExceptions),
+	}
+	return meta, nil
+}
+
+// CheckIsExistByDate satu pertemuan hanya boleh dibatalkan atau dipindah satu kali, dailyScheduleID 0 berarti
+// seluruh blok pada tanggal tersebut sehingga beririsan dengan pengecualian blok mana pun
+func (r scheduleExceptionRepo) CheckIsExistByDate(scheduleID int, date string, dailyScheduleID int, exceptID int) (isExist bool) {
+	query := r.db.Table("schedule_exceptions").Select("count(*) > 0").
+		Where("schedule_id = ? AND date = ? AND type IN ? AND id != ?", scheduleID, date, []string{model.ExceptionCancel, model.ExceptionReschedule}, exceptID)
+	if dailyScheduleID > 0 {
+		query = query.Where("daily_schedule_id IN (0, ?)", dailyScheduleID)
+	}
+	if err := query.Find(&isExist).Error; err != nil {
+		return false
+	}
+	return
+}
+
+// CheckIsExistByNewDate satu jadwal hanya boleh memiliki satu pertemuan pengganti pada tanggal yang sama
+func (r scheduleExceptionRepo) CheckIsExistByNewDate(scheduleID int, newDate string, exceptID int) (isExist bool) {
+	query := r.db.Table("schedule_exceptions").Select("count(*) > 0").
+		Where("schedule_id = ? AND new_date = ? AND type IN ? AND id != ?", scheduleID, newDate, []string{model.ExceptionReschedule, model.ExceptionMakeup}, exceptID)
+	if err := query.Find(&isExist).Error; err != nil {
+		return false
+	}
+	return
+}
+
+// QueryMeetingRemoved kondisi blok jadwal harian pada tanggal tersebut dibatalkan atau dipindah,
+// pengecualian tanpa blok (daily_schedule_id 0) berlaku untuk seluruh blok
+func QueryMeetingRemoved(scheduleColumn string, dailyScheduleColumn string, dateExpr string) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM schedule_exceptions sre WHERE sre.schedule_id = %s AND sre.daily_schedule_id IN (0, %s) AND sre.date = %s AND sre.type IN ('%s','%s'))", scheduleColumn, dailyScheduleColumn, dateExpr, model.ExceptionCancel, model.ExceptionReschedule)
+}
+
+// QueryMeetingAdded kondisi ada pertemuan pindahan atau kelas pengganti pada tanggal tersebut
+func QueryMeetingAdded(scheduleColumn string, dateExpr string) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM schedule_exceptions sae WHERE sae.schedule_id = %s AND sae.new_date = %s AND sae.type IN ('%s','%s'))", scheduleColumn, dateExpr, model.ExceptionReschedule, model.ExceptionMakeup)
+}
+
+func PreloadScheduleException(query *gorm.DB) *gorm.DB {
+	query = query.Preload("Room")
+	query = query.Preload("Room.GeofenceZone")
+	return query
+}
+
+func FilterScheduleException(query *gorm.DB, scheduleException model.ScheduleException) *gorm.DB {
+	if scheduleException.ScheduleID > 0 {
+		query = query.Where("schedule_id = ?", scheduleException.ScheduleID)
+	}
+	if scheduleException.DailyScheduleID > 0 {
+		query = query.Where("daily_schedule_id = ?", scheduleException.DailyScheduleID)
+	}
+	if scheduleException.Type != "" {
+		query = query.Where("type = ?", scheduleException.Type)
+	}
+	if scheduleException.Date != "" {
+		query = query.Where("(date = ? OR new_date = ?)", scheduleException.Date, scheduleException.Date)
+	}
+	if scheduleException.OwnerID > 0 {
+		query = query.Where("owner_id = ?", scheduleException.OwnerID)
+	}
+	return query
+}
+
+func SearchScheduleException(query *gorm.DB, search string) *gorm.DB {
+	if search != "" {
+		query = query.Where("reason LIKE ?", "%"+search+"%")
+	}
+	return query
+}
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"attendance-api/common/util/converter"
 	"attendance-api/model"
 	"sync"
 
@@ -14,8 +15,8 @@ type ScheduleRepo interface {
 	RetrieveScheduleByQRcode(QRcode string) (model.Schedule, error)
 	UpdateSchedule(id int, schedule model.Schedule) (model.Schedule, error)
 	UpdateScheduleByOwner(id int, ownerID int, schedule model.Schedule) (model.Schedule, error)
-	UpdateQRcode(id int, QRcode string) (model.Schedule, error)
-	UpdateQRcodeByOwner(id int, ownerID int, QRcode string) (model.Schedule, error)
+	UpdateQRcode(id int, QRcode string, QRSecret string) (model.Schedule, error)
+	UpdateQRcodeByOwner(id int, ownerID int, QRcode string, QRSecret string) (model.Schedule, error)
 	DeleteSchedule(id int) error
 	DeleteScheduleByOwner(id int, ownerID int) error
 	ListSchedule(schedule model.Schedule, pagination model.Pagination) ([]model.Schedule, error)
@@ -23,6 +24,10 @@ type ScheduleRepo interface {
 	DropDownSchedule(schedule model.Schedule) ([]model.Schedule, error)
 	CheckIsExist(id int) (isExist bool, err error)
 	CheckCodeIsExist(code string, exceptID int) bool
+	ListTeacherConflict(ownerID int, exceptScheduleID int, slot model.ScheduleSlot) ([]model.ScheduleConflict, error)
+	ListRoomConflict(roomID int, exceptScheduleID int, slot model.ScheduleSlot) ([]model.ScheduleConflict, error)
+	ListStudentConflict(userID int, exceptScheduleID int, slot model.ScheduleSlot) ([]model.ScheduleConflict, error)
+	ListEnrolledStudentConflict(scheduleID int, slot model.ScheduleSlot) ([]model.ScheduleConflict, error)
 }
 
 type scheduleRepo struct {
@@ -100,16 +105,16 @@ func (r scheduleRepo) UpdateScheduleByOwner(id int, ownerID int, schedule model.
 	return schedule, nil
 }
 
-func (r scheduleRepo) UpdateQRcode(id int, QRcode string) (schedule model.Schedule, err error) {
-	if err := r.db.Model(&model.Schedule{}).Where("id = ?", id).Update("qr_code", QRcode).Find(&schedule).Error; err != nil {
+func (r scheduleRepo) UpdateQRcode(id int, QRcode string, QRSecret string) (schedule model.Schedule, err error) {
+	if err := r.db.Model(&model.Schedule{}).Where("id = ?", id).Updates(map[string]interface{}{"qr_code": QRcode, "qr_secret": QRSecret}).Find(&schedule).Error; err != nil {
 		return model.Schedule{}, err
 	}
 	schedule.Owner = r.GetOwner(int(schedule.OwnerID))
 	return schedule, nil
 }
 
-func (r scheduleRepo) UpdateQRcodeByOwner(id int, ownerID int, QRcode string) (schedule model.Schedule, err error) {
-	if err := r.db.Model(&model.Schedule{}).Where("id = ? AND owner_id = ?", id, ownerID).Update("qr_code", QRcode).Find(&schedule).Error; err != nil {
+func (r scheduleRepo) UpdateQRcodeByOwner(id int, ownerID int, QRcode string, QRSecret string) (schedule model.Schedule, err error) {
+	if err := r.db.Model(&model.Schedule{}).Where("id = ? AND owner_id = ?", id, ownerID).Updates(map[string]interface{}{"qr_code": QRcode, "qr_secret": QRSecret}).Find(&schedule).Error; err != nil {
 		return model.Schedule{}, err
 	}
 	schedule.Owner = r.GetOwner(int(schedule.OwnerID))
@@ -272,9 +277,88 @@ func SearchSchedule(query *gorm.DB, search string) *gorm.DB {
 	return query
 }
 
+func (r scheduleRepo) ListTeacherConflict(ownerID int, exceptScheduleID int, slot model.ScheduleSlot) (results []model.ScheduleConflict, err error) {
+	query := QueryScheduleConflict(r.db, exceptScheduleID, slot).
+		Select(selectScheduleConflict+", ? AS type", model.ConflictTeacher).
+		Where("schedules.owner_id = ?", ownerID)
+	if err := query.Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r scheduleRepo) ListRoomConflict(roomID int, exceptScheduleID int, slot model.ScheduleSlot) (results []model.ScheduleConflict, err error) {
+	query := QueryScheduleConflict(r.db, exceptScheduleID, slot).
+		Select(selectScheduleConflict+", ? AS type", model.ConflictRoom).
+		Where("schedules.room_id = ?", roomID)
+	if err := query.Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r scheduleRepo) ListStudentConflict(userID int, exceptScheduleID int, slot model.ScheduleSlot) (results []model.ScheduleConflict, err error) {
+	query := QueryScheduleConflict(r.db, exceptScheduleID, slot).
+		Select(selectScheduleConflict+", ? AS type, user_schedules.user_id", model.ConflictStudent).
+		Joins("JOIN user_schedules ON user_schedules.schedule_id = schedules.id").
+		Where("user_schedules.user_id = ?", userID)
+	if err := query.Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ListEnrolledStudentConflict mencari jadwal lain yang bentrok dengan mahasiswa yang sudah terdaftar di jadwal scheduleID
+func (r scheduleRepo) ListEnrolledStudentConflict(scheduleID int, slot model.ScheduleSlot) (results []model.ScheduleConflict, err error) {
+	query := QueryScheduleConflict(r.db, scheduleID, slot).
+		Select(selectScheduleConflict+", ? AS type, user_schedules.user_id", model.ConflictStudent).
+		Joins("JOIN user_schedules ON user_schedules.schedule_id = schedules.id").
+		Where("user_schedules.user_id IN (?)", r.db.Table("user_schedules").Select("user_id").Where("schedule_id = ?", scheduleID))
+	if err := query.Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+const selectScheduleConflict = `schedules.id AS schedule_id, schedules.name AS schedule_name, schedules.code AS schedule_code,
+	DATE_FORMAT(schedules.start_date, '%Y-%m-%d') AS start_date, DATE_FORMAT(schedules.end_date, '%Y-%m-%d') AS end_date,
+	schedules.owner_id, schedules.room_id, daily_schedules.id AS daily_schedule_id, daily_schedules.name AS day,
+	daily_schedules.start_time, daily_schedules.end_time`
+
+// QueryScheduleConflict daily schedule pada hari yang sama, jam yang beririsan dan rentang tanggal yang beririsan.
+// Blok lintas tengah malam dipecah menjadi bagian sampai 24:00 dan bagian 00:00 - jam selesai pada hari berikutnya
+func QueryScheduleConflict(db *gorm.DB, exceptScheduleID int, slot model.ScheduleSlot) *gorm.DB {
+	endTime := slot.EndTime
+	if endTime < slot.StartTime {
+		endTime = "24:00"
+	}
+
+	// blok pada hari yang sama, lalu bagian setelah tengah malam dari blok lintas hari pada hari sebelumnya
+	conflict := "(daily_schedules.name = ? AND daily_schedules.start_time < ? AND (daily_schedules.end_time > ? OR daily_schedules.end_time < daily_schedules.start_time))" +
+		" OR (daily_schedules.name = ? AND daily_schedules.end_time < daily_schedules.start_time AND daily_schedules.end_time > ?)"
+	args := []interface{}{slot.Day, endTime, slot.StartTime, converter.ShiftDayName(slot.Day, -1), slot.StartTime}
+	if slot.EndTime < slot.StartTime {
+		// bagian setelah tengah malam dari slot dibandingkan dengan blok pada hari berikutnya
+		conflict += " OR (daily_schedules.name = ? AND daily_schedules.start_time < ?)"
+		args = append(args, converter.ShiftDayName(slot.Day, 1), slot.EndTime)
+	}
+
+	query := db.Table("daily_schedules").
+		Joins("JOIN schedules ON schedules.id = daily_schedules.schedule_id").
+		Where("schedules.id != ?", exceptScheduleID).
+		Where("("+conflict+")", args...)
+	if slot.StartDate != "" && slot.EndDate != "" {
+		query = query.Where("schedules.start_date <= ? AND schedules.end_date >= ?", slot.EndDate, slot.StartDate)
+	}
+	return query
+}
+
 func PreloadSchedule(query *gorm.DB) *gorm.DB {
 	query = query.Preload("Subject")
 	query = query.Preload("DailySchedule")
+	query = query.Preload("GeofenceZone")
+	query = query.Preload("Room")
+	query = query.Preload("Room.GeofenceZone")
 	// query = query.Preload("Owner")
 	return query
 }
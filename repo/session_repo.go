@@ -0,0 +1,276 @@
+package repo
+
+import (
+	"attendance-api/common/util/converter"
+	"attendance-api/model"
+
+	"gorm.io/gorm"
+)
+
+type SessionRepo interface {
+	RetrieveSession(id int) (model.Session, error)
+	RetrieveSessionByOwner(id int, ownerID int) (model.Session, error)
+	RetrieveSessionByDate(scheduleID int, date string) (model.Session, error)
+	RetrieveSessionByDailySchedule(scheduleID int, dailyScheduleID int, date string) (model.Session, error)
+	UpdateSession(id int, session model.Session) (model.Session, error)
+	UpdateSessionByOwner(id int, ownerID int, session model.Session) (model.Session, error)
+	UpdateSessionStatus(id int, status string) error
+	DeleteSessionByScheduleID(scheduleID int) error
+	ListSession(session model.Session, pagination model.Pagination) ([]model.Session, error)
+	ListSessionMeta(session model.Session, pagination model.Pagination) (model.Meta, error)
+	GenerateSession(scheduleID int) (model.SessionGenerateResult, error)
+}
+
+type sessionRepo struct {
+	db *gorm.DB
+}
+
+func NewSessionRepo(db *gorm.DB) SessionRepo {
+	return &sessionRepo{db: db}
+}
+
+func (r sessionRepo) RetrieveSession(id int) (model.Session, error) {
+	var session model.Session
+	if err := r.db.First(&session, id).Error; err != nil {
+		return model.Session{}, err
+	}
+	return session, nil
+}
+
+// RetrieveSessionByOwner dosen pengganti ikut dianggap pemilik pertemuan
+func (r sessionRepo) RetrieveSessionByOwner(id int, ownerID int) (model.Session, error) {
+	var session model.Session
+	if err := r.db.Model(&model.Session{}).Where("id = ? AND (owner_id = ? OR substitute_id = ?)", id, ownerID, ownerID).First(&session).Error; err != nil {
+		return model.Session{}, err
+	}
+	return session, nil
+}
+
+// RetrieveSessionByDate mengambil pertemuan pertama yang tidak dibatalkan pada tanggal tersebut
+func (r sessionRepo) RetrieveSessionByDate(scheduleID int, date string) (model.Session, error) {
+	var session model.Session
+	query := r.db.Model(&model.Session{}).
+		Where("schedule_id = ? AND date = ? AND status != ?", scheduleID, date, model.SessionCancelled).
+		Order("orphaned asc, start_time asc").
+		First(&session)
+	if err := query.Error; err != nil {
+		return model.Session{}, err
+	}
+	return session, nil
+}
+
+// RetrieveSessionByDailySchedule pertemuan dari blok jadwal harian pada tanggal tersebut, blok 0 adalah pertemuan pindahan / kelas pengganti
+func (r sessionRepo) RetrieveSessionByDailySchedule(scheduleID int, dailyScheduleID int, date string) (model.Session, error) {
+	var session model.Session
+	query := r.db.Model(&model.Session{}).
+		Where("schedule_id = ? AND daily_schedule_id = ? AND date = ? AND status != ?", scheduleID, dailyScheduleID, date, model.SessionCancelled).
+		Order("orphaned asc, start_time asc").
+		First(&session)
+	if err := query.Error; err != nil {
+		return model.Session{}, err
+	}
+	return session, nil
+}
+
+func (r sessionRepo) UpdateSession(id int, session model.Session) (model.Session, error) {
+	if err := r.db.Model(&model.Session{}).Where("id = ?", id).Updates(&session).Error; err != nil {
+		return model.Session{}, err
+	}
+	return session, nil
+}
+
+func (r sessionRepo) UpdateSessionByOwner(id int, ownerID int, session model.Session) (model.Session, error) {
+	if err := r.db.Model(&model.Session{}).Where("id = ? AND (owner_id = ? OR substitute_id = ?)", id, ownerID, ownerID).Updates(&session).Error; err != nil {
+		return model.Session{}, err
+	}
+	return session, nil
+}
+
+func (r sessionRepo) UpdateSessionStatus(id int, status string) error {
+	if err := r.db.Model(&model.Session{}).Where("id = ?", id).Update("status", status).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r sessionRepo) DeleteSessionByScheduleID(scheduleID int) error {
+	if err := r.db.Where("schedule_id = ?", scheduleID).Delete(&model.Session{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r sessionRepo) ListSession(session model.Session, pagination model.Pagination) ([]model.Session, error) {
+	var sessions []model.Session
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	query := r.db.Table("sessions").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterSession(query, session)
+	query = SearchSession(query, pagination.Search)
+	query = query.Find(&sessions)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (r sessionRepo) ListSessionMeta(session model.Session, pagination model.Pagination) (model.Meta, error) {
+	var sessions []model.Session
+	var totalRecord int
+	var totalPage int
+
+	queryTotal := r.db.Model(&model.Session{}).Select("count(*)")
+	queryTotal = FilterSession(queryTotal, session)
+	queryTotal = SearchSession(queryTotal, pagination.Search)
+	queryTotal = queryTotal.Scan(&totalRecord)
+	if err := queryTotal.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	totalPage = int(totalRecord / pagination.Limit)
+	if totalRecord%pagination.Limit > 0 {
+		totalPage += 1
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	query := r.db.Table("sessions").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterSession(query, session)
+	query = SearchSession(query, pagination.Search)
+	query = query.Find(&sessions)
+	if err := query.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	meta := model.Meta{
+		CurrentPage:   pagination.Page,
+		TotalPage:     totalPage,
+		TotalRecord:   totalRecord,
+		CurrentRecord: len(sessions),
+	}
+	return meta, nil
+}
+
+// GenerateSession menyusun ulang pertemuan jadwal tanpa menghilangkan data pertemuan yang sudah ada.
+// Pertemuan dicocokkan dengan SessionKey, pertemuan lama yang sudah berisi data atau presensi dibatalkan, bukan dihapus.
+func (r sessionRepo) GenerateSession(scheduleID int) (result model.SessionGenerateResult, err error) {
+	var schedule model.Schedule
+	if err := r.db.Preload("DailySchedule").First(&schedule, scheduleID).Error; err != nil {
+		return result, err
+	}
+	result.ScheduleID = schedule.ID
+
+	var exceptions []model.ScheduleException
+	if err := r.db.Where("schedule_id = ?", scheduleID).Find(&exceptions).Error; err != nil {
+		return result, err
+	}
+
+	calendars, err := NewAcademicCalendarRepo(r.db).ListNonTeachingDayBySchedule(scheduleID, converter.GetOnlyDateString(schedule.StartDate), converter.GetOnlyDateString(schedule.EndDate))
+	if err != nil {
+		return result, err
+	}
+
+	plans := model.GenerateSessionPlan(schedule, exceptions, calendars)
+	result.Total = len(plans)
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var existing []model.Session
+		if err := tx.Where("schedule_id = ?", scheduleID).Find(&existing).Error; err != nil {
+			return err
+		}
+		existingByKey := map[string]model.Session{}
+		for _, session := range existing {
+			existingByKey[session.SessionKey()] = session
+		}
+
+		// status pertemuan yang sudah ada dipertahankan sebelum urutan pertemuan dihitung ulang
+		for i := range plans {
+			if current, ok := existingByKey[plans[i].SessionKey()]; ok {
+				plans[i].Status = current.RegenerateStatus(plans[i].Status)
+			}
+		}
+		model.NumberSessions(plans)
+
+		for _, plan := range plans {
+			current, ok := existingByKey[plan.SessionKey()]
+			if !ok {
+				if err := tx.Create(&plan).Error; err != nil {
+					return err
+				}
+				result.Created++
+				continue
+			}
+			delete(existingByKey, plan.SessionKey())
+
+			if err := tx.Model(&model.Session{}).Where("id = ?", current.ID).Updates(map[string]interface{}{
+				"schedule_exception_id": plan.ScheduleExceptionID,
+				"number":                plan.Number,
+				"date":                  plan.Date,
+				"start_time":            plan.StartTime,
+				"end_time":              plan.EndTime,
+				"room_id":               plan.RoomID,
+				"status":                plan.Status,
+				"owner_id":              plan.OwnerID,
+				"orphaned":              false,
+			}).Error; err != nil {
+				return err
+			}
+			result.Updated++
+		}
+
+		for _, session := range existingByKey {
+			var hasAttendance bool
+			if err := tx.Table("attendances").Select("count(*) > 0").Where("session_id = ? AND clock_in > 0", session.ID).Find(&hasAttendance).Error; err != nil {
+				return err
+			}
+			if session.HasContent() || hasAttendance {
+				// status dan tautan presensi tetap, pertemuan hanya ditandai tidak lagi ada pada rencana
+				if err := tx.Model(&model.Session{}).Where("id = ?", session.ID).Updates(map[string]interface{}{
+					"number":   0,
+					"orphaned": true,
+				}).Error; err != nil {
+					return err
+				}
+				result.Kept++
+				continue
+			}
+			if err := tx.Delete(&model.Session{}, session.ID).Error; err != nil {
+				return err
+			}
+			result.Deleted++
+		}
+
+		// presensi ditautkan ke pertemuan dari blok yang sama, pertemuan pindahan / kelas pengganti memakai blok 0
+		return tx.Exec(`UPDATE attendances a SET a.session_id = COALESCE((SELECT s.id FROM sessions s
+			WHERE s.schedule_id = a.schedule_id AND s.date = a.date AND s.daily_schedule_id = a.daily_schedule_id AND s.status != ?
+			ORDER BY s.orphaned, s.start_time LIMIT 1), 0)
+			WHERE a.schedule_id = ?`, model.SessionCancelled, scheduleID).Error
+	})
+	return result, err
+}
+
+func FilterSession(query *gorm.DB, session model.Session) *gorm.DB {
+	if session.ScheduleID > 0 {
+		query = query.Where("schedule_id = ?", session.ScheduleID)
+	}
+	if session.Status != "" {
+		query = query.Where("status = ?", session.Status)
+	}
+	if session.Date != "" {
+		query = query.Where("date = ?", session.Date)
+	}
+	if session.Number > 0 {
+		query = query.Where("number = ?", session.Number)
+	}
+	if session.OwnerID > 0 {
+		query = query.Where("(owner_id = ? OR substitute_id = ?)", session.OwnerID, session.OwnerID)
+	}
+	return query
+}
+
+func SearchSession(query *gorm.DB, search string) *gorm.DB {
+	if search != "" {
+		query = query.Where("topic LIKE ? OR notes LIKE ? ", "%"+search+"%", "%"+search+"%")
+	}
+	return query
+}
@@ -113,6 +113,9 @@ func (r studentRepo) UpdateStudentByOwner(id int, ownerID int, student model.Stu
 }
 
 func (r studentRepo) DeleteStudent(id int) error {
+	if err := r.db.Where("student_id = ?", id).Delete(&model.Guardian{}).Error; err != nil {
+		return err
+	}
 	if err := r.db.Unscoped().Delete(&model.Student{}, id).Error; err != nil {
 		return err
 	}
@@ -251,5 +254,6 @@ func PreloadStudent(query *gorm.DB) *gorm.DB {
 	query = query.Preload("StudyProgram")
 	query = query.Preload("StudyProgram.Major")
 	query = query.Preload("StudyProgram.Major.Faculty")
+	query = query.Preload("Guardians")
 	return query
 }
@@ -35,6 +35,7 @@ type UserScheduleRepo interface {
 	CheckHaveSchedule(userID int, date time.Time) (isHaveSchedule bool, scheduleID int, err error)
 	CheckUserInSchedule(scheduleID int, userID int) bool
 	CountByScheduleID(scheduleID int) (total int)
+	ListUserBySchedule(scheduleID int) ([]model.User, error)
 	GetAll() (results []model.UserSchedule, err error)
 	GetAllByTodayRange() (results []model.UserSchedule, err error)
 }
@@ -190,6 +191,8 @@ func (r userScheduleRepo) ListMySchedule(userID int, filter model.MyScheduleFilt
 
 			var mySchedule []model.MySchedule
 
+			// Pertemuan mingguan (yang dibatalkan / dipindah tetap tampil dengan tanda is_cancelled)
+			// digabung dengan pertemuan pindahan dan kelas pengganti pada tanggal tersebut
 			rawQuery := fmt.Sprintf(`
 					SELECT 
 					us.schedule_id as schedule_id, 
@@ -206,13 +209,49 @@ func (r userScheduleRepo) ListMySchedule(userID int, filter model.MyScheduleFilt
 					s.radius as radius, 
 					s.qr_code as qr_code, 
 					s.owner_id as owner_id, 
+					COALESCE(s.room_id, 0) as room_id, 
 					ds.start_time as start_time, 
-					ds.end_time as end_time 
+					ds.end_time as end_time, 
+					COALESCE(se.type, '') as exception_type, 
+					se.id IS NOT NULL as is_cancelled, 
+					COALESCE(DATE(se.new_date), '') as new_date, 
+					COALESCE(se.reason, '') as reason 
 					FROM user_schedules us 
 					LEFT JOIN schedules s ON us.schedule_id = s.id 
 					LEFT JOIN subjects sbj ON s.subject_id = sbj.id
 					LEFT JOIN daily_schedules ds ON s.id = ds.schedule_id 
-					WHERE us.user_id = %d AND (DATE('%s') BETWEEN DATE(s.start_date) AND DATE(s.end_date)) AND ds.name = '%s' AND us.deleted_at IS NULL`, userID, date, dayName)
+					LEFT JOIN schedule_exceptions se ON se.schedule_id = s.id AND se.daily_schedule_id IN (0, ds.id) AND se.date = '%[2]s' AND (se.type = '%[4]s' OR (se.type = '%[5]s' AND se.new_date != se.date)) 
+					WHERE us.user_id = %[1]d AND (DATE('%[2]s') BETWEEN DATE(s.start_date) AND DATE(s.end_date)) AND ds.name = '%[3]s' AND us.deleted_at IS NULL 
+					AND NOT EXISTS (SELECT 1 FROM schedule_exceptions sx WHERE sx.schedule_id = s.id AND sx.daily_schedule_id IN (0, ds.id) AND sx.date = '%[2]s' AND sx.type = '%[5]s' AND sx.new_date = sx.date) 
+					UNION ALL 
+					SELECT 
+					us.schedule_id as schedule_id, 
+					s.name as schedule_name, 
+					s.code as schedule_code, 
+					DATE(s.start_date) as start_date, 
+					DATE(s.end_date) as end_date, 
+					s.subject_id as subject_id, 
+					sbj.name as subject_name, 
+					sbj.code as subject_code, 
+					s.late_duration as late_duration, 
+					s.latitude as latitude, 
+					s.longitude as longitude, 
+					s.radius as radius, 
+					s.qr_code as qr_code, 
+					s.owner_id as owner_id, 
+					COALESCE(se.room_id, s.room_id, 0) as room_id, 
+					se.start_time as start_time, 
+					se.end_time as end_time, 
+					se.type as exception_type, 
+					0 as is_cancelled, 
+					DATE(se.new_date) as new_date, 
+					COALESCE(se.reason, '') as reason 
+					FROM user_schedules us 
+					JOIN schedule_exceptions se ON se.schedule_id = us.schedule_id 
+					LEFT JOIN schedules s ON us.schedule_id = s.id 
+					LEFT JOIN subjects sbj ON s.subject_id = sbj.id
+					WHERE us.user_id = %[1]d AND se.new_date = '%[2]s' AND se.type IN ('%[5]s','%[6]s') AND us.deleted_at IS NULL 
+					ORDER BY start_time`, userID, date, dayName, model.ExceptionCancel, model.ExceptionReschedule, model.ExceptionMakeup)
 			if err := r.db.Raw(rawQuery).Scan(&mySchedule).Error; err != nil {
 				log.Printf("Error Get Day Name E: %v\n", errorDayName)
 			}
@@ -253,6 +292,7 @@ func (r userScheduleRepo) ListMySchedule(userID int, filter model.MyScheduleFilt
 
 func (r userScheduleRepo) ListTodaySchedule(userID int, dayName string) (results []model.TodaySchedule, err error) {
 	today := time.Now().Format("2006-01-02")
+	// Pertemuan yang dibatalkan / dipindah tidak ditampilkan, pertemuan pindahan dan kelas pengganti hari ini ikut ditampilkan
 	query := fmt.Sprintf(`
 	SELECT 
 	s.id as schedule_id, 
@@ -263,12 +303,34 @@ func (r userScheduleRepo) ListTodaySchedule(userID int, dayName string) (results
 	sbj.id as subject_id, 
 	sbj.name as subject_name, 
 	ds.start_time as start_time, 
-	ds.end_time as end_time 
+	ds.end_time as end_time, 
+	'' as exception_type, 
+	ds.id as daily_schedule_id 
 	FROM user_schedules us 
 	LEFT JOIN schedules s ON us.schedule_id = s.id 
 	LEFT JOIN subjects sbj ON s.subject_id = sbj.id 
 	LEFT JOIN daily_schedules ds ON us.schedule_id = ds.schedule_id 
-	WHERE us.user_id = %d AND ds.name = '%s' AND '%s' BETWEEN DATE(s.start_date) AND DATE(s.end_date) AND us.deleted_at IS NULL`, userID, dayName, today)
+	WHERE us.user_id = %[1]d AND ds.name = '%[2]s' AND '%[3]s' BETWEEN DATE(s.start_date) AND DATE(s.end_date) AND us.deleted_at IS NULL 
+	AND NOT %[4]s 
+	UNION ALL 
+	SELECT 
+	s.id as schedule_id, 
+	s.name as schedule_name, 
+	s.code as schedule_code, 
+	s.qr_code as qr_code, 
+	s.owner_id as owner_id, 
+	sbj.id as subject_id, 
+	sbj.name as subject_name, 
+	se.start_time as start_time, 
+	se.end_time as end_time, 
+	se.type as exception_type, 
+	0 as daily_schedule_id 
+	FROM user_schedules us 
+	JOIN schedule_exceptions se ON se.schedule_id = us.schedule_id 
+	LEFT JOIN schedules s ON us.schedule_id = s.id 
+	LEFT JOIN subjects sbj ON s.subject_id = sbj.id 
+	WHERE us.user_id = %[1]d AND se.new_date = '%[3]s' AND se.type IN ('%[5]s','%[6]s') AND us.deleted_at IS NULL 
+	ORDER BY start_time`, userID, dayName, today, QueryMeetingRemoved("s.id", "ds.id", fmt.Sprintf("'%s'", today)), model.ExceptionReschedule, model.ExceptionMakeup)
 	if err := r.db.Raw(query).Scan(&results).Error; err != nil {
 		return nil, err
 	}
@@ -583,9 +645,9 @@ func (r userScheduleRepo) GetAllByTodayRange() (resutls []model.UserSchedule, er
 
 	var idSchedule []int
 
-	rawQuery := fmt.Sprintf(`SELECT s.id FROM schedules s 
+	rawQuery := fmt.Sprintf(`SELECT DISTINCT s.id FROM schedules s 
 	LEFT JOIN daily_schedules ds ON s.id = ds.schedule_id 
-	WHERE ds.name = '%s' AND '%s' BETWEEN DATE(s.start_date) AND DATE(s.end_date) AND s.deleted_at IS NULL`, converter.GetDayName(today), today.Format("2006-01-02"))
+	WHERE (ds.name = '%s' OR %s) AND '%s' BETWEEN DATE(s.start_date) AND DATE(s.end_date) AND s.deleted_at IS NULL`, converter.GetDayName(today), QueryMeetingAdded("s.id", fmt.Sprintf("'%s'", today.Format("2006-01-02"))), today.Format("2006-01-02"))
 
 	if err := r.db.Raw(rawQuery).Scan(&idSchedule).Error; err != nil {
 		return nil, err
@@ -648,6 +710,19 @@ func (r userScheduleRepo) CountByScheduleID(scheduleID int) (total int) {
 	return
 }
 
+// ListUserBySchedule mahasiswa yang mengikuti jadwal, hanya kolom kontak
+func (r userScheduleRepo) ListUserBySchedule(scheduleID int) ([]model.User, error) {
+	var users []model.User
+	if err := r.db.Table("users").
+		Select("users.id", "users.first_name", "users.last_name", "users.email", "users.handphone").
+		Joins("JOIN user_schedules ON user_schedules.user_id = users.id").
+		Where("user_schedules.schedule_id = ?", scheduleID).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 func (r userScheduleRepo) GetTeacher(ownerID int) (result string) {
 	var userData model.User
 	if ownerID > 0 {
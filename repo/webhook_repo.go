@@ -0,0 +1,280 @@
+package repo
+
+import (
+	"attendance-api/model"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepo interface {
+	CreateWebhook(webhook model.Webhook) (model.Webhook, error)
+	RetrieveWebhook(id int) (model.Webhook, error)
+	UpdateWebhook(id int, webhook model.Webhook) (model.Webhook, error)
+	DeleteWebhook(id int) error
+	ListWebhook(webhook model.Webhook, pagination model.Pagination) ([]model.Webhook, error)
+	ListWebhookMeta(webhook model.Webhook, pagination model.Pagination) (model.Meta, error)
+	ListActiveWebhookByEvent(event string) ([]model.Webhook, error)
+	RecordWebhookSuccess(id int) error
+	RecordWebhookFailure(id int, disableAfter int) (isDisabled bool, err error)
+	CreateWebhookDelivery(delivery model.WebhookDelivery) (model.WebhookDelivery, error)
+	RetrieveWebhookDelivery(id int) (model.WebhookDelivery, error)
+	UpdateWebhookDeliveryResult(id int, delivery model.WebhookDelivery) error
+	ListWebhookDelivery(delivery model.WebhookDelivery, pagination model.Pagination) ([]model.WebhookDelivery, error)
+	ListWebhookDeliveryMeta(delivery model.WebhookDelivery, pagination model.Pagination) (model.Meta, error)
+	ListStaleWebhookDelivery(before time.Time) ([]model.WebhookDelivery, error)
+}
+
+type webhookRepo struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepo(db *gorm.DB) WebhookRepo {
+	return &webhookRepo{db: db}
+}
+
+func (r webhookRepo) CreateWebhook(webhook model.Webhook) (model.Webhook, error) {
+	if err := r.db.Create(&webhook).Error; err != nil {
+		return model.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+func (r webhookRepo) RetrieveWebhook(id int) (model.Webhook, error) {
+	var webhook model.Webhook
+	if err := r.db.First(&webhook, id).Error; err != nil {
+		return model.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+// UpdateWebhook mengaktifkan kembali webhook juga mengosongkan hitungan gagal
+func (r webhookRepo) UpdateWebhook(id int, webhook model.Webhook) (model.Webhook, error) {
+	data := map[string]interface{}{
+		"name":       webhook.Name,
+		"url":        webhook.URL,
+		"events":     webhook.Events,
+		"secret":     webhook.Secret,
+		"is_active":  webhook.IsActive,
+		"updated_by": webhook.UpdatedBy,
+	}
+	if webhook.IsActive {
+		data["failure_count"] = 0
+		data["disabled_at"] = nil
+	}
+	if err := r.db.Model(&model.Webhook{}).Where("id = ?", id).Updates(data).Error; err != nil {
+		return model.Webhook{}, err
+	}
+	return r.RetrieveWebhook(id)
+}
+
+func (r webhookRepo) DeleteWebhook(id int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&model.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		query := tx.Delete(&model.Webhook{}, id)
+		if err := query.Error; err != nil {
+			return err
+		}
+		if query.RowsAffected == 0 {
+			return errors.New("webhook tidak ditemukan")
+		}
+		return nil
+	})
+}
+
+func (r webhookRepo) ListWebhook(webhook model.Webhook, pagination model.Pagination) ([]model.Webhook, error) {
+	var webhooks []model.Webhook
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	query := r.db.Table("webhooks").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterWebhook(query, webhook)
+	query = query.Find(&webhooks)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (r webhookRepo) ListWebhookMeta(webhook model.Webhook, pagination model.Pagination) (model.Meta, error) {
+	var webhooks []model.Webhook
+	var totalRecord int
+	var totalPage int
+
+	queryTotal := r.db.Model(&model.Webhook{}).Select("count(*)")
+	queryTotal = FilterWebhook(queryTotal, webhook)
+	queryTotal = queryTotal.Scan(&totalRecord)
+	if err := queryTotal.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	totalPage = int(totalRecord / pagination.Limit)
+	if totalRecord%pagination.Limit > 0 {
+		totalPage += 1
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	query := r.db.Table("webhooks").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterWebhook(query, webhook)
+	query = query.Find(&webhooks)
+	if err := query.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	meta := model.Meta{
+		CurrentPage:   pagination.Page,
+		TotalPage:     totalPage,
+		TotalRecord:   totalRecord,
+		CurrentRecord: len(webhooks),
+	}
+	return meta, nil
+}
+
+func (r webhookRepo) ListActiveWebhookByEvent(event string) ([]model.Webhook, error) {
+	var webhooks []model.Webhook
+	if err := r.db.Where("is_active = ? AND FIND_IN_SET(?, events) > 0", true, event).Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r webhookRepo) RecordWebhookSuccess(id int) error {
+	return r.db.Model(&model.Webhook{}).Where("id = ? AND failure_count > ?", id, 0).Update("failure_count", 0).Error
+}
+
+// RecordWebhookFailure menambah hitungan gagal berturut-turut dan menonaktifkan webhook saat mencapai disableAfter
+func (r webhookRepo) RecordWebhookFailure(id int, disableAfter int) (isDisabled bool, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Webhook{}).Where("id = ?", id).Update("failure_count", gorm.Expr("failure_count + ?", 1)).Error; err != nil {
+			return err
+		}
+		if disableAfter <= 0 {
+			return nil
+		}
+		query := tx.Model(&model.Webhook{}).
+			Where("id = ? AND is_active = ? AND failure_count >= ?", id, true, disableAfter).
+			Updates(map[string]interface{}{"is_active": false, "disabled_at": time.Now()})
+		if err := query.Error; err != nil {
+			return err
+		}
+		isDisabled = query.RowsAffected > 0
+		return nil
+	})
+	return
+}
+
+func (r webhookRepo) CreateWebhookDelivery(delivery model.WebhookDelivery) (model.WebhookDelivery, error) {
+	if err := r.db.Omit("Webhook").Create(&delivery).Error; err != nil {
+		return model.WebhookDelivery{}, err
+	}
+	return delivery, nil
+}
+
+func (r webhookRepo) RetrieveWebhookDelivery(id int) (model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	if err := r.db.Preload("Webhook").First(&delivery, id).Error; err != nil {
+		return model.WebhookDelivery{}, err
+	}
+	return delivery, nil
+}
+
+// UpdateWebhookDeliveryResult menyimpan hasil satu percobaan pengiriman
+func (r webhookRepo) UpdateWebhookDeliveryResult(id int, delivery model.WebhookDelivery) error {
+	return r.db.Model(&model.WebhookDelivery{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":        delivery.Status,
+		"attempt":       delivery.Attempt,
+		"response_code": delivery.ResponseCode,
+		"response_body": delivery.ResponseBody,
+		"error":         delivery.Error,
+		"next_retry_at": delivery.NextRetryAt,
+		"delivered_at":  delivery.DeliveredAt,
+	}).Error
+}
+
+func (r webhookRepo) ListWebhookDelivery(delivery model.WebhookDelivery, pagination model.Pagination) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	query := r.db.Table("webhook_deliveries").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterWebhookDelivery(query, delivery)
+	query = query.Find(&deliveries)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r webhookRepo) ListWebhookDeliveryMeta(delivery model.WebhookDelivery, pagination model.Pagination) (model.Meta, error) {
+	var deliveries []model.WebhookDelivery
+	var totalRecord int
+	var totalPage int
+
+	queryTotal := r.db.Model(&model.WebhookDelivery{}).Select("count(*)")
+	queryTotal = FilterWebhookDelivery(queryTotal, delivery)
+	queryTotal = queryTotal.Scan(&totalRecord)
+	if err := queryTotal.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	totalPage = int(totalRecord / pagination.Limit)
+	if totalRecord%pagination.Limit > 0 {
+		totalPage += 1
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	query := r.db.Table("webhook_deliveries").Limit(pagination.Limit).Offset(offset).Order(pagination.Sort)
+	query = FilterWebhookDelivery(query, delivery)
+	query = query.Find(&deliveries)
+	if err := query.Error; err != nil {
+		return model.Meta{}, err
+	}
+
+	meta := model.Meta{
+		CurrentPage:   pagination.Page,
+		TotalPage:     totalPage,
+		TotalRecord:   totalRecord,
+		CurrentRecord: len(deliveries),
+	}
+	return meta, nil
+}
+
+// ListStaleWebhookDelivery pengiriman pending yang jadwal percobaannya sudah lewat, misal pesan antrian gagal dikirim
+func (r webhookRepo) ListStaleWebhookDelivery(before time.Time) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	if err := r.db.Preload("Webhook").
+		Where("status = ? AND (next_retry_at IS NULL OR next_retry_at < ?) AND created_at < ?", model.WebhookDeliveryPending, before, before).
+		Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func FilterWebhook(query *gorm.DB, webhook model.Webhook) *gorm.DB {
+	if webhook.Name != "" {
+		query = query.Where("name LIKE ?", "%"+webhook.Name+"%")
+	}
+	if webhook.URL != "" {
+		query = query.Where("url LIKE ?", "%"+webhook.URL+"%")
+	}
+	if webhook.Events != "" {
+		query = query.Where("FIND_IN_SET(?, events) > 0", webhook.Events)
+	}
+	return query
+}
+
+func FilterWebhookDelivery(query *gorm.DB, delivery model.WebhookDelivery) *gorm.DB {
+	if delivery.WebhookID > 0 {
+		query = query.Where("webhook_id = ?", delivery.WebhookID)
+	}
+	if delivery.Event != "" {
+		query = query.Where("event = ?", delivery.Event)
+	}
+	if delivery.Status != "" {
+		query = query.Where("status = ?", delivery.Status)
+	}
+	return query
+}
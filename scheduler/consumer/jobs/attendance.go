@@ -15,23 +15,32 @@ type AttendanceJob interface {
 }
 
 type attendanceJob struct {
-	userScheduleService  service.UserScheduleService
-	attendanceService    service.AttendanceService
-	attendanceLogService service.AttendanceLogService
-	task                 *scheduler.AddTask
+	userScheduleService      service.UserScheduleService
+	attendanceService        service.AttendanceService
+	attendanceLogService     service.AttendanceLogService
+	dailyScheduleService     service.DailyScheduleService
+	scheduleExceptionService service.ScheduleExceptionService
+	academicCalendarService  service.AcademicCalendarService
+	task                     *scheduler.AddTask
 }
 
 func NewAttendanceJob(
 	userScheduleService service.UserScheduleService,
 	attendanceService service.AttendanceService,
 	attendanceLogService service.AttendanceLogService,
+	dailyScheduleService service.DailyScheduleService,
+	scheduleExceptionService service.ScheduleExceptionService,
+	academicCalendarService service.AcademicCalendarService,
 	task *scheduler.AddTask,
 ) AttendanceJob {
 	return &attendanceJob{
-		userScheduleService:  userScheduleService,
-		attendanceService:    attendanceService,
-		attendanceLogService: attendanceLogService,
-		task:                 task,
+		userScheduleService:      userScheduleService,
+		attendanceService:        attendanceService,
+		attendanceLogService:     attendanceLogService,
+		dailyScheduleService:     dailyScheduleService,
+		scheduleExceptionService: scheduleExceptionService,
+		academicCalendarService:  academicCalendarService,
+		task:                     task,
 	}
 }
 
@@ -51,22 +60,51 @@ func (j attendanceJob) AutoCreate() {
 	for _, userSchedule := range userSchedules {
 		wg.Add(1)
 		go func(userSchedule model.UserSchedule) {
-			// Cek apakah jadwal user memang di hari ini
-			log.Printf("IS Today schedule: %v\n", userSchedule.Schedule.IsTodaySchedule())
-			if userSchedule.Schedule.IsTodaySchedule() {
-				// Buat Data Presensi kosong / tidak hadir secara default terlebih dahulu
-				dataAttendance := model.Attendance{
-					UserID:         userSchedule.UserID,
-					ScheduleID:     userSchedule.ScheduleID,
-					Date:           time.Now().Format("2006-01-02"),
-					ClockIn:        0,
-					ClockOut:       0,
-					Status:         "-",
-					StatusPresence: "not_presence",
+			// Cek apakah jadwal user memang di hari ini, termasuk pembatalan, pemindahan dan kelas pengganti
+			isTodaySchedule, _, err := j.dailyScheduleService.CheckHaveDailySchedule(int(userSchedule.ScheduleID), time.Now().Format("2006-01-02"))
+			if err != nil {
+				log.Printf("[Scheduler] [Error] [Attendance-CheckHaveDailySchedule] E: %v\n", err)
+			}
+			log.Printf("IS Today schedule: %v\n", isTodaySchedule)
+			// Lewati hari libur, minggu ujian dan masa jeda pada kalender akademik
+			isNonTeachingDay := j.academicCalendarService.CheckIsNonTeachingDay(int(userSchedule.ScheduleID), time.Now().Format("2006-01-02"))
+			if isTodaySchedule && !isNonTeachingDay {
+				// presensi dibuat per blok jadwal harian yang masih berlangsung, pertemuan pindahan / kelas pengganti
+				// menjadi satu blok tanpa id
+				var dailyScheduleIDs []uint
+				dailySchedules, err := j.dailyScheduleService.ListDailyScheduleByDate(int(userSchedule.ScheduleID), time.Now().Format("2006-01-02"))
+				if err != nil {
+					log.Printf("[Scheduler] [Error] [Attendance-ListDailyScheduleByDate] E: %v\n", err)
+				}
+				for _, dailySchedule := range dailySchedules {
+					dailyScheduleIDs = append(dailyScheduleIDs, dailySchedule.ID)
 				}
-				_, err := j.attendanceService.CreateAttendance(dataAttendance)
+				exceptions, err := j.scheduleExceptionService.ListMeetingException(int(userSchedule.ScheduleID), time.Now().Format("2006-01-02"))
 				if err != nil {
-					log.Printf("[Scheduler] [Error] [Attendance-CreateAttendance] E: %v\n", err)
+					log.Printf("[Scheduler] [Error] [Attendance-ListMeetingException] E: %v\n", err)
+				}
+				if len(exceptions) > 0 {
+					dailyScheduleIDs = append(dailyScheduleIDs, 0)
+				}
+				for _, dailyScheduleID := range dailyScheduleIDs {
+					if j.attendanceService.CheckIsExistByDate(userSchedule.UserID, int(userSchedule.ScheduleID), int(dailyScheduleID), time.Now().Format("2006-01-02")) {
+						continue
+					}
+					// Buat Data Presensi kosong / tidak hadir secara default terlebih dahulu
+					dataAttendance := model.Attendance{
+						UserID:          userSchedule.UserID,
+						ScheduleID:      userSchedule.ScheduleID,
+						DailyScheduleID: dailyScheduleID,
+						Date:            time.Now().Format("2006-01-02"),
+						ClockIn:         0,
+						ClockOut:        0,
+						Status:          "-",
+						StatusPresence:  "not_presence",
+					}
+					_, err := j.attendanceService.CreateAttendance(dataAttendance)
+					if err != nil {
+						log.Printf("[Scheduler] [Error] [Attendance-CreateAttendance] E: %v\n", err)
+					}
 				}
 			}
 			wg.Done()
@@ -0,0 +1,165 @@
+package jobs
+
+import (
+	"attendance-api/common/http/notification"
+	"attendance-api/model"
+	"attendance-api/scheduler"
+	"attendance-api/service"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+type AttendanceAlertJob interface {
+	AutoAlert()
+}
+
+type attendanceAlertJob struct {
+	attendanceAlertService service.AttendanceAlertService
+	attendanceService      service.AttendanceService
+	scheduleService        service.ScheduleService
+	userService            service.UserService
+	notifier               notification.Notifier
+	eligibilityRule        model.EligibilityRule
+	alertRule              model.AttendanceAlertRule
+	task                   *scheduler.AddTask
+}
+
+func NewAttendanceAlertJob(
+	attendanceAlertService service.AttendanceAlertService,
+	attendanceService service.AttendanceService,
+	scheduleService service.ScheduleService,
+	userService service.UserService,
+	notifier notification.Notifier,
+	eligibilityRule model.EligibilityRule,
+	alertRule model.AttendanceAlertRule,
+	task *scheduler.AddTask,
+) AttendanceAlertJob {
+	return &attendanceAlertJob{
+		attendanceAlertService: attendanceAlertService,
+		attendanceService:      attendanceService,
+		scheduleService:        scheduleService,
+		userService:            userService,
+		notifier:               notifier,
+		eligibilityRule:        eligibilityRule,
+		alertRule:              alertRule,
+		task:                   task,
+	}
+}
+
+func (j attendanceAlertJob) AutoAlert() {
+	fmt.Println("Execute Task Attendance Alert")
+	fmt.Printf("Action: %v\n", j.task.Action)
+	fmt.Printf("Body  : %v\n", j.task.Body)
+	fmt.Printf("Date  : %v\n", j.task.Date)
+	fmt.Printf("TStm  : %v\n", j.task.TimeStamp)
+
+	scheduleIDs, err := j.attendanceAlertService.ListActiveScheduleID()
+	if err != nil {
+		log.Printf("[Scheduler] [Error] [AttendanceAlert-ListActiveScheduleID] E: %v\n", err)
+		return
+	}
+
+	for _, scheduleID := range scheduleIDs {
+		j.alertSchedule(scheduleID)
+	}
+}
+
+// alertSchedule menghitung kehadiran berjalan setiap mahasiswa pada jadwal, peringatan hanya dibuat
+// saat ambang terlewati dan belum ada peringatan aktif dengan level yang sama
+func (j attendanceAlertJob) alertSchedule(scheduleID int) {
+	heldMeeting := j.attendanceService.CountHeldMeeting(scheduleID)
+	if heldMeeting < j.alertRule.MinHeldMeeting || heldMeeting == 0 {
+		return
+	}
+
+	schedule, err := j.scheduleService.RetrieveSchedule(scheduleID)
+	if err != nil {
+		log.Printf("[Scheduler] [Error] [AttendanceAlert-RetrieveSchedule] E: %v\n", err)
+		return
+	}
+
+	students, err := j.attendanceService.ListAttendanceEligibility(scheduleID, j.eligibilityRule)
+	if err != nil {
+		log.Printf("[Scheduler] [Error] [AttendanceAlert-ListAttendanceEligibility] E: %v\n", err)
+		return
+	}
+
+	for _, student := range students {
+		student.HeldMeeting = heldMeeting
+		student = j.eligibilityRule.Evaluate(student)
+		// mahasiswa yang izin pada seluruh pertemuan belum memiliki persentase kehadiran
+		isEvaluated := j.eligibilityRule.EffectiveMeeting(student) > 0
+
+		for _, level := range model.AlertLevels {
+			threshold := j.alertRule.Threshold(level)
+			if !isEvaluated || student.Percentage >= float64(threshold) {
+				if err := j.attendanceAlertService.RecoverAttendanceAlert(student.UserID, scheduleID, level); err != nil {
+					log.Printf("[Scheduler] [Error] [AttendanceAlert-RecoverAttendanceAlert] E: %v\n", err)
+				}
+				continue
+			}
+			if j.attendanceAlertService.CheckIsActive(student.UserID, scheduleID, level) {
+				continue
+			}
+
+			alert, err := j.attendanceAlertService.CreateAttendanceAlert(model.AttendanceAlert{
+				UserID:      student.UserID,
+				ScheduleID:  schedule.ID,
+				Level:       level,
+				Rate:        student.Percentage,
+				Threshold:   threshold,
+				HeldMeeting: heldMeeting,
+				Attended:    student.Attended,
+				OwnerID:     int(schedule.OwnerID),
+			})
+			if err != nil {
+				log.Printf("[Scheduler] [Error] [AttendanceAlert-CreateAttendanceAlert] E: %v\n", err)
+				continue
+			}
+			j.notify(alert, student, schedule)
+		}
+	}
+}
+
+func (j attendanceAlertJob) notify(alert model.AttendanceAlert, student model.AttendanceEligibility, schedule model.Schedule) {
+	data := map[string]string{
+		"level":         strings.ToUpper(alert.Level),
+		"schedule_name": schedule.Name,
+		"schedule_code": schedule.Code,
+		"rate":          strconv.FormatFloat(alert.Rate, 'f', 2, 64),
+		"held_meeting":  strconv.Itoa(alert.HeldMeeting),
+		"threshold":     strconv.Itoa(alert.Threshold),
+		"student_name":  student.Name,
+		"username":      student.Username,
+	}
+
+	notifiedStudent := j.send(model.NotificationEventAttendanceAlert, student.UserID, data)
+	notifiedOwner := j.send(model.NotificationEventAttendanceAlertOwner, int(schedule.OwnerID), data)
+
+	if err := j.attendanceAlertService.UpdateAttendanceAlertNotified(int(alert.ID), notifiedStudent, notifiedOwner); err != nil {
+		log.Printf("[Scheduler] [Error] [AttendanceAlert-UpdateAttendanceAlertNotified] E: %v\n", err)
+	} else {
+		log.Printf("[Scheduler] [Success] [AttendanceAlert-AUTO-ALERT] [%v] [%v] [%v]\n", alert.ScheduleID, alert.UserID, alert.Level)
+	}
+}
+
+// send mengirim ke kanal pilihan pengguna, berhasil jika minimal satu kanal terkirim
+func (j attendanceAlertJob) send(event string, userID int, data map[string]string) bool {
+	user, err := j.userService.RetrieveUser(userID)
+	if err != nil {
+		log.Printf("[Scheduler] [Error] [AttendanceAlert-RetrieveUser] E: %v\n", err)
+		return false
+	}
+
+	sent, err := j.notifier.Send(notification.Event{
+		Type:      event,
+		Recipient: notification.Recipient{UserID: userID, Name: user.FirstName, Email: user.Email, Phone: user.Handphone},
+		Data:      data,
+	})
+	if err != nil {
+		log.Printf("[Scheduler] [Error] [AttendanceAlert-Send] E: %v\n", err)
+	}
+	return len(sent) > 0
+}
@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"attendance-api/model"
+	"attendance-api/scheduler"
+	"attendance-api/service"
+	"fmt"
+	"log"
+	"time"
+)
+
+type AttendancePolicyJob interface {
+	AutoApply()
+}
+
+type attendancePolicyJob struct {
+	attendanceService       service.AttendanceService
+	attendancePolicyService service.AttendancePolicyService
+	task                    *scheduler.AddTask
+}
+
+func NewAttendancePolicyJob(
+	attendanceService service.AttendanceService,
+	attendancePolicyService service.AttendancePolicyService,
+	task *scheduler.AddTask,
+) AttendancePolicyJob {
+	return &attendancePolicyJob{
+		attendanceService:       attendanceService,
+		attendancePolicyService: attendancePolicyService,
+		task:                    task,
+	}
+}
+
+// AutoApply menilai presensi yang belum absen keluar dengan aturan missing_clock_out setelah batas absen keluar
+// bloknya lewat. Dua hari terakhir diperiksa agar blok malam yang selesai keesokan harinya ikut dinilai
+func (j attendancePolicyJob) AutoApply() {
+	fmt.Println("Execute Task Attendance Policy")
+	fmt.Printf("Action: %v\n", j.task.Action)
+	fmt.Printf("Body  : %v\n", j.task.Body)
+	fmt.Printf("Date  : %v\n", j.task.Date)
+	fmt.Printf("TStm  : %v\n", j.task.TimeStamp)
+
+	startDate := time.Now().AddDate(0, 0, -2).Format("2006-01-02")
+	scheduleIDs, err := j.attendanceService.ListOpenAttendanceScheduleID(startDate)
+	if err != nil {
+		log.Printf("[Scheduler] [Error] [AttendancePolicy-ListOpenAttendanceScheduleID] E: %v\n", err)
+		return
+	}
+
+	for _, scheduleID := range scheduleIDs {
+		policy, err := j.attendancePolicyService.RetrieveAttendancePolicyBySchedule(scheduleID)
+		if err != nil || !policy.HasRule(model.PolicyRuleMissingClockOut) {
+			continue
+		}
+		total, err := j.attendanceService.RecomputeOpenAttendancePolicy(scheduleID, policy, startDate)
+		if err != nil {
+			log.Printf("[Scheduler] [Error] [AttendancePolicy-RecomputeOpenAttendancePolicy] [%v] E: %v\n", scheduleID, err)
+			continue
+		}
+		if total > 0 {
+			log.Printf("[Scheduler] [Success] [AttendancePolicy-AUTO-APPLY] [%v] [%v]\n", scheduleID, total)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package jobs
+
+import (
+	"attendance-api/common/http/notification"
+	"attendance-api/common/util/converter"
+	"attendance-api/model"
+	"attendance-api/scheduler"
+	"attendance-api/service"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+type GuardianDigestJob interface {
+	AutoDigest()
+}
+
+type guardianDigestJob struct {
+	guardianService service.GuardianService
+	notifier        notification.Notifier
+	baseURL         string
+	task            *scheduler.AddTask
+}
+
+func NewGuardianDigestJob(
+	guardianService service.GuardianService,
+	notifier notification.Notifier,
+	baseURL string,
+	task *scheduler.AddTask,
+) GuardianDigestJob {
+	return &guardianDigestJob{
+		guardianService: guardianService,
+		notifier:        notifier,
+		baseURL:         baseURL,
+		task:            task,
+	}
+}
+
+// AutoDigest satu ringkasan per wali berisi ketidakhadiran dan keterlambatan mahasiswa dari blok yang selesai
+// dalam 24 jam sebelum task, blok yang belum selesai masuk ringkasan berikutnya
+func (j guardianDigestJob) AutoDigest() {
+	fmt.Println("Execute Task Guardian Digest")
+	fmt.Printf("Action: %v\n", j.task.Action)
+	fmt.Printf("Body  : %v\n", j.task.Body)
+	fmt.Printf("Date  : %v\n", j.task.Date)
+	fmt.Printf("TStm  : %v\n", j.task.TimeStamp)
+
+	until, err := time.ParseInLocation("2006-01-02 15:04:05", j.task.TimeStamp, time.Local)
+	if err != nil {
+		log.Printf("[Scheduler] [Error] [GuardianDigest-TimeStamp] E: %v\n", err)
+		return
+	}
+	since := until.Add(-24 * time.Hour)
+
+	records, err := j.guardianService.ListGuardianDigest(since.Format("2006-01-02 15:04:05"), until.Format("2006-01-02 15:04:05"))
+	if err != nil {
+		log.Printf("[Scheduler] [Error] [GuardianDigest-ListGuardianDigest] E: %v\n", err)
+		return
+	}
+
+	// data sudah diurutkan per wali
+	for start := 0; start < len(records); {
+		end := start
+		for end < len(records) && records[end].GuardianID == records[start].GuardianID {
+			end++
+		}
+		j.send(records[start:end])
+		start = end
+	}
+}
+
+func (j guardianDigestJob) send(records []model.GuardianDigestRecord) {
+	guardian := records[0]
+
+	var lines []string
+	for _, record := range records {
+		status := "tidak hadir"
+		if record.IsLate() {
+			status = fmt.Sprintf("terlambat %s", record.LateIn)
+		}
+		lines = append(lines, fmt.Sprintf("- %s (%s) - %s (%s) %s: %s", record.StudentName, record.NIM, record.ScheduleName, record.ScheduleCode, converter.GetOnlyDateString(record.Date), status))
+	}
+
+	event := notification.Event{
+		Type:      model.NotificationEventGuardianDigest,
+		Recipient: notification.Recipient{Name: guardian.GuardianName, Email: guardian.GuardianEmail, Phone: guardian.GuardianPhone},
+		Data: map[string]string{
+			"date":        j.task.Date,
+			"records":     strings.Join(lines, "\n"),
+			"opt_out_url": fmt.Sprintf("%s/v1/guardian/opt-out?token=%s", j.baseURL, guardian.OptOutToken),
+		},
+		Channels: []string{model.NotificationChannelEmail},
+	}
+	// sms dan whatsapp dikirim lewat gateway, jika gagal dan wali memiliki email ringkasan dikirim lewat email
+	if guardian.PreferredChannel != model.GuardianChannelEmail {
+		event.Channels = []string{model.NotificationChannelGateway}
+	}
+
+	_, err := j.notifier.Send(event)
+	if err != nil && event.Channels[0] != model.NotificationChannelEmail && guardian.GuardianEmail != "" {
+		log.Printf("[Scheduler] [Error] [GuardianDigest-Send] E: %v\n", err)
+		event.Channels = []string{model.NotificationChannelEmail}
+		_, err = j.notifier.Send(event)
+	}
+	if err != nil {
+		log.Printf("[Scheduler] [Error] [GuardianDigest-Send] E: %v\n", err)
+		return
+	}
+	log.Printf("[Scheduler] [Success] [GuardianDigest-AUTO-DIGEST] [%v] [%v]\n", guardian.GuardianID, len(records))
+}
@@ -0,0 +1,150 @@
+package jobs
+
+import (
+	"attendance-api/common/http/webhook"
+	"attendance-api/model"
+	"attendance-api/scheduler"
+	"attendance-api/service"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+)
+
+// webhookStaleAfter pengiriman pending yang terlambat selama ini dianggap hilang dari antrian
+const webhookStaleAfter = 10 * time.Minute
+
+type WebhookJob interface {
+	AutoDeliver()
+	AutoRetry()
+}
+
+type webhookJob struct {
+	webhookService service.WebhookService
+	sender         webhook.Sender
+	queue          webhook.Queue
+	rule           model.WebhookRule
+	task           *scheduler.AddTask
+}
+
+func NewWebhookJob(
+	webhookService service.WebhookService,
+	sender webhook.Sender,
+	queue webhook.Queue,
+	rule model.WebhookRule,
+	task *scheduler.AddTask,
+) WebhookJob {
+	return &webhookJob{
+		webhookService: webhookService,
+		sender:         sender,
+		queue:          queue,
+		rule:           rule,
+		task:           task,
+	}
+}
+
+// AutoDeliver mengirim satu pengiriman webhook, Body berisi id pengiriman
+func (j webhookJob) AutoDeliver() {
+	fmt.Println("Execute Task Webhook")
+	fmt.Printf("Action: %v\n", j.task.Action)
+	fmt.Printf("Body  : %v\n", j.task.Body)
+	fmt.Printf("Date  : %v\n", j.task.Date)
+	fmt.Printf("TStm  : %v\n", j.task.TimeStamp)
+
+	id, err := strconv.Atoi(j.task.Body)
+	if err != nil {
+		log.Printf("[Scheduler] [Error] [Webhook-Body] E: %v\n", err)
+		return
+	}
+
+	delivery, err := j.webhookService.RetrieveWebhookDelivery(id)
+	if err != nil {
+		log.Printf("[Scheduler] [Error] [Webhook-RetrieveWebhookDelivery] E: %v\n", err)
+		return
+	}
+	// pesan ganda dari antrian untuk pengiriman yang sudah selesai
+	if delivery.Status != model.WebhookDeliveryPending {
+		return
+	}
+	// pesan ganda dari percobaan sebelumnya, percobaan berikutnya sudah dijadwalkan
+	if delivery.NextRetryAt != nil && time.Now().Before(*delivery.NextRetryAt) {
+		return
+	}
+
+	j.deliver(delivery)
+}
+
+// AutoRetry mengirim ulang pengiriman pending yang pesannya tidak pernah sampai ke consumer
+func (j webhookJob) AutoRetry() {
+	fmt.Println("Execute Task Webhook Retry")
+	fmt.Printf("Action: %v\n", j.task.Action)
+	fmt.Printf("Body  : %v\n", j.task.Body)
+	fmt.Printf("Date  : %v\n", j.task.Date)
+	fmt.Printf("TStm  : %v\n", j.task.TimeStamp)
+
+	deliveries, err := j.webhookService.ListStaleWebhookDelivery(time.Now().Add(-webhookStaleAfter))
+	if err != nil {
+		log.Printf("[Scheduler] [Error] [Webhook-ListStaleWebhookDelivery] E: %v\n", err)
+		return
+	}
+	for _, delivery := range deliveries {
+		j.deliver(delivery)
+	}
+}
+
+// deliver satu percobaan, gagal dijadwalkan ulang dengan jeda berlipat sampai MaxAttempt lalu dicatat gagal pada webhook
+func (j webhookJob) deliver(delivery model.WebhookDelivery) {
+	if !delivery.Webhook.IsActive {
+		delivery.Status = model.WebhookDeliveryFailed
+		delivery.Error = "webhook tidak aktif"
+		delivery.NextRetryAt = nil
+		j.save(delivery)
+		return
+	}
+
+	result := j.sender.Send(delivery.Webhook, delivery)
+	now := time.Now()
+	delivery.Attempt++
+	delivery.ResponseCode = result.StatusCode
+	delivery.ResponseBody = result.Body
+	delivery.Error = result.Message()
+	delivery.NextRetryAt = nil
+
+	if result.IsSuccess() {
+		delivery.Status = model.WebhookDeliverySuccess
+		delivery.DeliveredAt = &now
+		j.save(delivery)
+		if err := j.webhookService.RecordWebhookSuccess(int(delivery.WebhookID)); err != nil {
+			log.Printf("[Scheduler] [Error] [Webhook-RecordWebhookSuccess] E: %v\n", err)
+		}
+		log.Printf("[Scheduler] [Success] [Webhook-AUTO-DELIVER] [%v] [%v]\n", delivery.ID, delivery.Attempt)
+		return
+	}
+
+	if delivery.Attempt < j.rule.MaxAttempt {
+		delay := j.rule.Backoff(delivery.Attempt)
+		nextRetryAt := now.Add(delay)
+		delivery.NextRetryAt = &nextRetryAt
+		j.save(delivery)
+		if err := j.queue.Enqueue(delivery.ID, delay); err != nil {
+			log.Printf("[Scheduler] [Error] [Webhook-Enqueue] E: %v\n", err)
+		}
+		return
+	}
+
+	delivery.Status = model.WebhookDeliveryFailed
+	j.save(delivery)
+	isDisabled, err := j.webhookService.RecordWebhookFailure(int(delivery.WebhookID), j.rule.DisableAfter)
+	if err != nil {
+		log.Printf("[Scheduler] [Error] [Webhook-RecordWebhookFailure] E: %v\n", err)
+	}
+	if isDisabled {
+		log.Printf("[Scheduler] [Webhook-DISABLED] [%v] gagal %v kali berturut-turut\n", delivery.WebhookID, j.rule.DisableAfter)
+	}
+}
+
+func (j webhookJob) save(delivery model.WebhookDelivery) {
+	if err := j.webhookService.UpdateWebhookDeliveryResult(int(delivery.ID), delivery); err != nil {
+		log.Printf("[Scheduler] [Error] [Webhook-UpdateWebhookDeliveryResult] E: %v\n", err)
+	}
+}
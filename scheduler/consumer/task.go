@@ -1,10 +1,14 @@
 package consumer
 
 import (
+	"attendance-api/common/http/webhook"
 	"attendance-api/infra"
 	"attendance-api/manager"
+	"attendance-api/model"
 	"attendance-api/scheduler"
 	"attendance-api/scheduler/consumer/jobs"
+	"net/http"
+	"time"
 )
 
 type Task interface {
@@ -28,6 +32,9 @@ func (t task) InitTask(task *scheduler.AddTask) {
 		t.service.UserScheduleService(),
 		t.service.AttendanceService(),
 		t.service.AttendanceLogService(),
+		t.service.DailyScheduleService(),
+		t.service.ScheduleExceptionService(),
+		t.service.AcademicCalendarService(),
 		task,
 	)
 
@@ -46,6 +53,39 @@ func (t task) InitTask(task *scheduler.AddTask) {
 		task,
 	)
 
+	attendanceAlertJob := jobs.NewAttendanceAlertJob(
+		t.service.AttendanceAlertService(),
+		t.service.AttendanceService(),
+		t.service.ScheduleService(),
+		t.service.UserService(),
+		t.service.Notifier(),
+		model.NewEligibilityRule(t.infra.Config().Sub("eligibility")),
+		model.NewAttendanceAlertRule(t.infra.Config().Sub("attendance_alert")),
+		task,
+	)
+
+	attendancePolicyJob := jobs.NewAttendancePolicyJob(
+		t.service.AttendanceService(),
+		t.service.AttendancePolicyService(),
+		task,
+	)
+
+	guardianDigestJob := jobs.NewGuardianDigestJob(
+		t.service.GuardianService(),
+		t.service.Notifier(),
+		t.infra.Config().Sub("server").GetString("base_url"),
+		task,
+	)
+
+	webhookRule := model.NewWebhookRule(t.infra.Config().Sub("webhook"))
+	webhookJob := jobs.NewWebhookJob(
+		t.service.WebhookService(),
+		webhook.NewSender(&http.Client{Timeout: time.Duration(webhookRule.Timeout) * time.Second}),
+		t.service.WebhookQueue(),
+		webhookRule,
+		task,
+	)
+
 	if task.Action == "attendance" {
 		attendanceJob.AutoCreate()
 	}
@@ -58,4 +98,19 @@ func (t task) InitTask(task *scheduler.AddTask) {
 	if task.Action == "password_reset_token" {
 		passwordResetTokenJob.AutoDelete()
 	}
+	if task.Action == "attendance_alert" {
+		attendanceAlertJob.AutoAlert()
+	}
+	if task.Action == "attendance_policy" {
+		attendancePolicyJob.AutoApply()
+	}
+	if task.Action == "guardian_digest" {
+		guardianDigestJob.AutoDigest()
+	}
+	if task.Action == "webhook" {
+		webhookJob.AutoDeliver()
+	}
+	if task.Action == "webhook_retry" {
+		webhookJob.AutoRetry()
+	}
 }
@@ -18,6 +18,10 @@ func InitCronJob(amqpChannel *amqp.Channel, queueName string) (cronJob *cron.Cro
 	c.AddFunc("@hourly", TaskAuth(amqpChannel, queueName))                 //tiap 1 Jam
 	c.AddFunc("0 3 * * *", TaskActivationToken(amqpChannel, queueName))    //tiap jam 03:00 dini hari
 	c.AddFunc("0 3 * * *", TaskPasswordResetToken(amqpChannel, queueName)) //tiap jam 03:00 dini hari
+	c.AddFunc("0 1 * * *", TaskAttendanceAlert(amqpChannel, queueName))    //tiap jam 01:00 dini hari
+	c.AddFunc("30 * * * *", TaskAttendancePolicy(amqpChannel, queueName))  //tiap jam menit ke-30
+	c.AddFunc("0 21 * * *", TaskGuardianDigest(amqpChannel, queueName))    //tiap jam 21:00, blok yang belum selesai masuk ringkasan esok hari
+	c.AddFunc("*/10 * * * *", TaskWebhookRetry(amqpChannel, queueName))    //tiap 10 menit
 
 	return c
 }
@@ -86,6 +90,70 @@ func TaskPasswordResetToken(amqpChannel *amqp.Channel, queueName string) func()
 	}
 }
 
+func TaskAttendanceAlert(amqpChannel *amqp.Channel, queueName string) func() {
+	return func() {
+		fmt.Println("Task Attendance Alert")
+
+		addTask := scheduler.AddTask{
+			Action:    "attendance_alert",
+			Body:      "auto_alert",
+			Date:      time.Now().Format("2006-01-02"),
+			TimeStamp: time.Now().Format("2006-01-02 15:04:05"),
+		}
+
+		PushMessage(amqpChannel, addTask, queueName)
+
+	}
+}
+
+func TaskAttendancePolicy(amqpChannel *amqp.Channel, queueName string) func() {
+	return func() {
+		fmt.Println("Task Attendance Policy")
+
+		addTask := scheduler.AddTask{
+			Action:    "attendance_policy",
+			Body:      "auto_apply",
+			Date:      time.Now().Format("2006-01-02"),
+			TimeStamp: time.Now().Format("2006-01-02 15:04:05"),
+		}
+
+		PushMessage(amqpChannel, addTask, queueName)
+
+	}
+}
+
+func TaskGuardianDigest(amqpChannel *amqp.Channel, queueName string) func() {
+	return func() {
+		fmt.Println("Task Guardian Digest")
+
+		addTask := scheduler.AddTask{
+			Action:    "guardian_digest",
+			Body:      "auto_digest",
+			Date:      time.Now().Format("2006-01-02"),
+			TimeStamp: time.Now().Format("2006-01-02 15:04:05"),
+		}
+
+		PushMessage(amqpChannel, addTask, queueName)
+
+	}
+}
+
+func TaskWebhookRetry(amqpChannel *amqp.Channel, queueName string) func() {
+	return func() {
+		fmt.Println("Task Webhook Retry")
+
+		addTask := scheduler.AddTask{
+			Action:    "webhook_retry",
+			Body:      "auto_retry",
+			Date:      time.Now().Format("2006-01-02"),
+			TimeStamp: time.Now().Format("2006-01-02 15:04:05"),
+		}
+
+		PushMessage(amqpChannel, addTask, queueName)
+
+	}
+}
+
 func PushMessage(amqpChannel *amqp.Channel, addTask scheduler.AddTask, queueName string) {
 	queue, err := amqpChannel.QueueDeclare(queueName, true, false, false, false, nil)
 	handleError(err, fmt.Sprintf(`Could not declare "%s" queue`, queueName))
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+)
+
+type AcademicCalendarService interface {
+	CreateAcademicCalendar(academicCalendar model.AcademicCalendar) (model.AcademicCalendar, error)
+	RetrieveAcademicCalendar(id int) (model.AcademicCalendar, error)
+	RetrieveAcademicCalendarByUID(uid string, facultyID int) (model.AcademicCalendar, error)
+	UpdateAcademicCalendar(id int, academicCalendar model.AcademicCalendar) (model.AcademicCalendar, error)
+	UpdateAcademicCalendarFaculty(id int, facultyID int) error
+	DeleteAcademicCalendar(id int) error
+	ListAcademicCalendar(academicCalendar model.AcademicCalendar, pagination model.Pagination) ([]model.AcademicCalendar, error)
+	ListAcademicCalendarMeta(academicCalendar model.AcademicCalendar, pagination model.Pagination) (model.Meta, error)
+	ListNonTeachingDayBySchedule(scheduleID int, startDate string, endDate string) ([]model.AcademicCalendar, error)
+	CheckIsNonTeachingDay(scheduleID int, date string) (isExist bool)
+}
+
+type academicCalendarService struct {
+	academicCalendarRepo repo.AcademicCalendarRepo
+}
+
+func NewAcademicCalendarService(academicCalendarRepo repo.AcademicCalendarRepo) AcademicCalendarService {
+	return &academicCalendarService{academicCalendarRepo: academicCalendarRepo}
+}
+
+func (s academicCalendarService) CreateAcademicCalendar(academicCalendar model.AcademicCalendar) (model.AcademicCalendar, error) {
+	data, err := s.academicCalendarRepo.CreateAcademicCalendar(academicCalendar)
+	if err != nil {
+		return model.AcademicCalendar{}, err
+	}
+	return data, nil
+}
+
+func (s academicCalendarService) RetrieveAcademicCalendar(id int) (model.AcademicCalendar, error) {
+	data, err := s.academicCalendarRepo.RetrieveAcademicCalendar(id)
+	if err != nil {
+		return model.AcademicCalendar{}, err
+	}
+	return data, nil
+}
+
+func (s academicCalendarService) RetrieveAcademicCalendarByUID(uid string, facultyID int) (model.AcademicCalendar, error) {
+	data, err := s.academicCalendarRepo.RetrieveAcademicCalendarByUID(uid, facultyID)
+	if err != nil {
+		return model.AcademicCalendar{}, err
+	}
+	return data, nil
+}
+
+func (s academicCalendarService) UpdateAcademicCalendar(id int, academicCalendar model.AcademicCalendar) (model.AcademicCalendar, error) {
+	data, err := s.academicCalendarRepo.UpdateAcademicCalendar(id, academicCalendar)
+	if err != nil {
+		return model.AcademicCalendar{}, err
+	}
+	return data, nil
+}
+
+func (s academicCalendarService) UpdateAcademicCalendarFaculty(id int, facultyID int) error {
+	if err := s.academicCalendarRepo.UpdateAcademicCalendarFaculty(id, facultyID); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s academicCalendarService) DeleteAcademicCalendar(id int) error {
+	if err := s.academicCalendarRepo.DeleteAcademicCalendar(id); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s academicCalendarService) ListAcademicCalendar(academicCalendar model.AcademicCalendar, pagination model.Pagination) ([]model.AcademicCalendar, error) {
+	datas, err := s.academicCalendarRepo.ListAcademicCalendar(academicCalendar, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s academicCalendarService) ListAcademicCalendarMeta(academicCalendar model.AcademicCalendar, pagination model.Pagination) (model.Meta, error) {
+	data, err := s.academicCalendarRepo.ListAcademicCalendarMeta(academicCalendar, pagination)
+	if err != nil {
+		return model.Meta{}, err
+	}
+	return data, nil
+}
+
+func (s academicCalendarService) ListNonTeachingDayBySchedule(scheduleID int, startDate string, endDate string) ([]model.AcademicCalendar, error) {
+	datas, err := s.academicCalendarRepo.ListNonTeachingDayBySchedule(scheduleID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s academicCalendarService) CheckIsNonTeachingDay(scheduleID int, date string) (isExist bool) {
+	return s.academicCalendarRepo.CheckIsNonTeachingDay(scheduleID, date)
+}
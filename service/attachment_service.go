@@ -0,0 +1,72 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+)
+
+type AttachmentService interface {
+	CreateAttachment(attachment model.Attachment) (model.Attachment, error)
+	RetrieveAttachment(id int) (model.Attachment, error)
+	DeleteAttachment(id int) error
+	ListAttachment(attachment model.Attachment, pagination model.Pagination) ([]model.Attachment, error)
+	ListAttachmentMeta(attachment model.Attachment, pagination model.Pagination) (model.Meta, error)
+	CountAttachmentByKey(key string) (total int)
+	CheckRecordAccess(recordType string, recordID int, userID int) (haveAccess bool)
+}
+
+type attachmentService struct {
+	attachmentRepo repo.AttachmentRepo
+}
+
+func NewAttachmentService(attachmentRepo repo.AttachmentRepo) AttachmentService {
+	return &attachmentService{attachmentRepo: attachmentRepo}
+}
+
+func (s attachmentService) CreateAttachment(attachment model.Attachment) (model.Attachment, error) {
+	data, err := s.attachmentRepo.CreateAttachment(attachment)
+	if err != nil {
+		return model.Attachment{}, err
+	}
+	return data, nil
+}
+
+func (s attachmentService) RetrieveAttachment(id int) (model.Attachment, error) {
+	data, err := s.attachmentRepo.RetrieveAttachment(id)
+	if err != nil {
+		return model.Attachment{}, err
+	}
+	return data, nil
+}
+
+func (s attachmentService) DeleteAttachment(id int) error {
+	if err := s.attachmentRepo.DeleteAttachment(id); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s attachmentService) ListAttachment(attachment model.Attachment, pagination model.Pagination) ([]model.Attachment, error) {
+	datas, err := s.attachmentRepo.ListAttachment(attachment, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s attachmentService) ListAttachmentMeta(attachment model.Attachment, pagination model.Pagination) (model.Meta, error) {
+	data, err := s.attachmentRepo.ListAttachmentMeta(attachment, pagination)
+	if err != nil {
+		return model.Meta{}, err
+	}
+	return data, nil
+}
+
+func (s attachmentService) CountAttachmentByKey(key string) (total int) {
+	return s.attachmentRepo.CountAttachmentByKey(key)
+}
+
+func (s attachmentService) CheckRecordAccess(recordType string, recordID int, userID int) (haveAccess bool) {
+	return s.attachmentRepo.CheckRecordAccess(recordType, recordID, userID)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+)
+
+type AttendanceAlertService interface {
+	CreateAttendanceAlert(attendanceAlert model.AttendanceAlert) (model.AttendanceAlert, error)
+	UpdateAttendanceAlertNotified(id int, notifiedStudent bool, notifiedOwner bool) error
+	RecoverAttendanceAlert(userID int, scheduleID int, level string) error
+	ListAttendanceAlert(attendanceAlert model.AttendanceAlert, pagination model.Pagination) ([]model.AttendanceAlert, error)
+	ListAttendanceAlertMeta(attendanceAlert model.AttendanceAlert, pagination model.Pagination) (model.Meta, error)
+	ListActiveScheduleID() ([]int, error)
+	CheckIsActive(userID int, scheduleID int, level string) (isActive bool)
+}
+
+type attendanceAlertService struct {
+	attendanceAlertRepo repo.AttendanceAlertRepo
+}
+
+func NewAttendanceAlertService(attendanceAlertRepo repo.AttendanceAlertRepo) AttendanceAlertService {
+	return &attendanceAlertService{attendanceAlertRepo: attendanceAlertRepo}
+}
+
+func (s attendanceAlertService) CreateAttendanceAlert(attendanceAlert model.AttendanceAlert) (model.AttendanceAlert, error) {
+	data, err := s.attendanceAlertRepo.CreateAttendanceAlert(attendanceAlert)
+	if err != nil {
+		return model.AttendanceAlert{}, err
+	}
+	return data, nil
+}
+
+func (s attendanceAlertService) UpdateAttendanceAlertNotified(id int, notifiedStudent bool, notifiedOwner bool) error {
+	if err := s.attendanceAlertRepo.UpdateAttendanceAlertNotified(id, notifiedStudent, notifiedOwner); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s attendanceAlertService) RecoverAttendanceAlert(userID int, scheduleID int, level string) error {
+	if err := s.attendanceAlertRepo.RecoverAttendanceAlert(userID, scheduleID, level); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s attendanceAlertService) ListAttendanceAlert(attendanceAlert model.AttendanceAlert, pagination model.Pagination) ([]model.AttendanceAlert, error) {
+	datas, err := s.attendanceAlertRepo.ListAttendanceAlert(attendanceAlert, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s attendanceAlertService) ListAttendanceAlertMeta(attendanceAlert model.AttendanceAlert, pagination model.Pagination) (model.Meta, error) {
+	data, err := s.attendanceAlertRepo.ListAttendanceAlertMeta(attendanceAlert, pagination)
+	if err != nil {
+		return model.Meta{}, err
+	}
+	return data, nil
+}
+
+func (s attendanceAlertService) ListActiveScheduleID() ([]int, error) {
+	datas, err := s.attendanceAlertRepo.ListActiveScheduleID()
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s attendanceAlertService) CheckIsActive(userID int, scheduleID int, level string) (isActive bool) {
+	return s.attendanceAlertRepo.CheckIsActive(userID, scheduleID, level)
+}
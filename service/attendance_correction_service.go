@@ -0,0 +1,94 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+)
+
+type AttendanceCorrectionService interface {
+	CreateAttendanceCorrection(attendanceCorrection model.AttendanceCorrection) (model.AttendanceCorrection, error)
+	RetrieveAttendanceCorrection(id int) (model.AttendanceCorrection, error)
+	RetrieveAttendanceCorrectionByUser(id int, userID int) (model.AttendanceCorrection, error)
+	RetrieveAttendanceCorrectionByOwner(id int, ownerID int) (model.AttendanceCorrection, error)
+	CancelAttendanceCorrection(id int, userID int) (model.AttendanceCorrection, error)
+	ReviewAttendanceCorrection(id int, actorID int, review model.AttendanceCorrectionReview, corrected model.Attendance) (model.AttendanceCorrection, error)
+	ListAttendanceCorrection(attendanceCorrection model.AttendanceCorrection, pagination model.Pagination) ([]model.AttendanceCorrection, error)
+	ListAttendanceCorrectionMeta(attendanceCorrection model.AttendanceCorrection, pagination model.Pagination) (model.Meta, error)
+	CheckIsExistPending(attendanceID int) (isExist bool)
+}
+
+type attendanceCorrectionService struct {
+	attendanceCorrectionRepo repo.AttendanceCorrectionRepo
+}
+
+func NewAttendanceCorrectionService(attendanceCorrectionRepo repo.AttendanceCorrectionRepo) AttendanceCorrectionService {
+	return &attendanceCorrectionService{attendanceCorrectionRepo: attendanceCorrectionRepo}
+}
+
+func (s attendanceCorrectionService) CreateAttendanceCorrection(attendanceCorrection model.AttendanceCorrection) (model.AttendanceCorrection, error) {
+	data, err := s.attendanceCorrectionRepo.CreateAttendanceCorrection(attendanceCorrection)
+	if err != nil {
+		return model.AttendanceCorrection{}, err
+	}
+	return data, nil
+}
+
+func (s attendanceCorrectionService) RetrieveAttendanceCorrection(id int) (model.AttendanceCorrection, error) {
+	data, err := s.attendanceCorrectionRepo.RetrieveAttendanceCorrection(id)
+	if err != nil {
+		return model.AttendanceCorrection{}, err
+	}
+	return data, nil
+}
+
+func (s attendanceCorrectionService) RetrieveAttendanceCorrectionByUser(id int, userID int) (model.AttendanceCorrection, error) {
+	data, err := s.attendanceCorrectionRepo.RetrieveAttendanceCorrectionByUser(id, userID)
+	if err != nil {
+		return model.AttendanceCorrection{}, err
+	}
+	return data, nil
+}
+
+func (s attendanceCorrectionService) RetrieveAttendanceCorrectionByOwner(id int, ownerID int) (model.AttendanceCorrection, error) {
+	data, err := s.attendanceCorrectionRepo.RetrieveAttendanceCorrectionByOwner(id, ownerID)
+	if err != nil {
+		return model.AttendanceCorrection{}, err
+	}
+	return data, nil
+}
+
+func (s attendanceCorrectionService) CancelAttendanceCorrection(id int, userID int) (model.AttendanceCorrection, error) {
+	data, err := s.attendanceCorrectionRepo.CancelAttendanceCorrection(id, userID)
+	if err != nil {
+		return model.AttendanceCorrection{}, err
+	}
+	return data, nil
+}
+
+func (s attendanceCorrectionService) ReviewAttendanceCorrection(id int, actorID int, review model.AttendanceCorrectionReview, corrected model.Attendance) (model.AttendanceCorrection, error) {
+	data, err := s.attendanceCorrectionRepo.ReviewAttendanceCorrection(id, actorID, review, corrected)
+	if err != nil {
+		return model.AttendanceCorrection{}, err
+	}
+	return data, nil
+}
+
+func (s attendanceCorrectionService) ListAttendanceCorrection(attendanceCorrection model.AttendanceCorrection, pagination model.Pagination) ([]model.AttendanceCorrection, error) {
+	datas, err := s.attendanceCorrectionRepo.ListAttendanceCorrection(attendanceCorrection, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s attendanceCorrectionService) ListAttendanceCorrectionMeta(attendanceCorrection model.AttendanceCorrection, pagination model.Pagination) (model.Meta, error) {
+	data, err := s.attendanceCorrectionRepo.ListAttendanceCorrectionMeta(attendanceCorrection, pagination)
+	if err != nil {
+		return model.Meta{}, err
+	}
+	return data, nil
+}
+
+func (s attendanceCorrectionService) CheckIsExistPending(attendanceID int) (isExist bool) {
+	return s.attendanceCorrectionRepo.CheckIsExistPending(attendanceID)
+}
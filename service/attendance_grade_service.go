@@ -0,0 +1,44 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+)
+
+type AttendanceGradeService interface {
+	RetrieveAttendanceGradeFormula(scheduleID int) (model.AttendanceGradeFormula, error)
+	SaveAttendanceGradeFormula(formula model.AttendanceGradeFormula) (model.AttendanceGradeFormula, error)
+	ListAttendanceGrade(scheduleID int) ([]model.AttendanceGrade, error)
+}
+
+type attendanceGradeService struct {
+	attendanceGradeRepo repo.AttendanceGradeRepo
+}
+
+func NewAttendanceGradeService(attendanceGradeRepo repo.AttendanceGradeRepo) AttendanceGradeService {
+	return &attendanceGradeService{attendanceGradeRepo: attendanceGradeRepo}
+}
+
+func (s attendanceGradeService) RetrieveAttendanceGradeFormula(scheduleID int) (model.AttendanceGradeFormula, error) {
+	data, err := s.attendanceGradeRepo.RetrieveAttendanceGradeFormula(scheduleID)
+	if err != nil {
+		return model.AttendanceGradeFormula{}, err
+	}
+	return data, nil
+}
+
+func (s attendanceGradeService) SaveAttendanceGradeFormula(formula model.AttendanceGradeFormula) (model.AttendanceGradeFormula, error) {
+	data, err := s.attendanceGradeRepo.SaveAttendanceGradeFormula(formula)
+	if err != nil {
+		return model.AttendanceGradeFormula{}, err
+	}
+	return data, nil
+}
+
+func (s attendanceGradeService) ListAttendanceGrade(scheduleID int) ([]model.AttendanceGrade, error) {
+	datas, err := s.attendanceGradeRepo.ListAttendanceGrade(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+)
+
+type AttendancePolicyService interface {
+	CreateAttendancePolicy(attendancePolicy model.AttendancePolicy) (model.AttendancePolicy, error)
+	RetrieveAttendancePolicy(id int) (model.AttendancePolicy, error)
+	RetrieveAttendancePolicyBySchedule(scheduleID int) (model.AttendancePolicy, error)
+	UpdateAttendancePolicy(id int, attendancePolicy model.AttendancePolicy) (model.AttendancePolicy, error)
+	DeleteAttendancePolicy(id int) error
+	ListAttendancePolicy(attendancePolicy model.AttendancePolicy, pagination model.Pagination) ([]model.AttendancePolicy, error)
+	ListAttendancePolicyMeta(attendancePolicy model.AttendancePolicy, pagination model.Pagination) (model.Meta, error)
+	ListAttendancePolicyScheduleID(id int) ([]int, error)
+	AssignAttendancePolicySchedule(scheduleID int, id int) error
+	AssignAttendancePolicyFaculty(facultyID int, id int) error
+	CheckIsExist(id int) (isExist bool)
+}
+
+type attendancePolicyService struct {
+	attendancePolicyRepo repo.AttendancePolicyRepo
+}
+
+func NewAttendancePolicyService(attendancePolicyRepo repo.AttendancePolicyRepo) AttendancePolicyService {
+	return &attendancePolicyService{attendancePolicyRepo: attendancePolicyRepo}
+}
+
+func (s attendancePolicyService) CreateAttendancePolicy(attendancePolicy model.AttendancePolicy) (model.AttendancePolicy, error) {
+	data, err := s.attendancePolicyRepo.CreateAttendancePolicy(attendancePolicy)
+	if err != nil {
+		return model.AttendancePolicy{}, err
+	}
+	return data, nil
+}
+
+func (s attendancePolicyService) RetrieveAttendancePolicy(id int) (model.AttendancePolicy, error) {
+	data, err := s.attendancePolicyRepo.RetrieveAttendancePolicy(id)
+	if err != nil {
+		return model.AttendancePolicy{}, err
+	}
+	return data, nil
+}
+
+func (s attendancePolicyService) RetrieveAttendancePolicyBySchedule(scheduleID int) (model.AttendancePolicy, error) {
+	data, err := s.attendancePolicyRepo.RetrieveAttendancePolicyBySchedule(scheduleID)
+	if err != nil {
+		return model.AttendancePolicy{}, err
+	}
+	return data, nil
+}
+
+func (s attendancePolicyService) UpdateAttendancePolicy(id int, attendancePolicy model.AttendancePolicy) (model.AttendancePolicy, error) {
+	data, err := s.attendancePolicyRepo.UpdateAttendancePolicy(id, attendancePolicy)
+	if err != nil {
+		return model.AttendancePolicy{}, err
+	}
+	return data, nil
+}
+
+func (s attendancePolicyService) DeleteAttendancePolicy(id int) error {
+	if err := s.attendancePolicyRepo.DeleteAttendancePolicy(id); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s attendancePolicyService) ListAttendancePolicy(attendancePolicy model.AttendancePolicy, pagination model.Pagination) ([]model.AttendancePolicy, error) {
+	datas, err := s.attendancePolicyRepo.ListAttendancePolicy(attendancePolicy, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s attendancePolicyService) ListAttendancePolicyMeta(attendancePolicy model.AttendancePolicy, pagination model.Pagination) (model.Meta, error) {
+	data, err := s.attendancePolicyRepo.ListAttendancePolicyMeta(attendancePolicy, pagination)
+	if err != nil {
+		return model.Meta{}, err
+	}
+	return data, nil
+}
+
+func (s attendancePolicyService) ListAttendancePolicyScheduleID(id int) ([]int, error) {
+	datas, err := s.attendancePolicyRepo.ListAttendancePolicyScheduleID(id)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s attendancePolicyService) AssignAttendancePolicySchedule(scheduleID int, id int) error {
+	if err := s.attendancePolicyRepo.AssignAttendancePolicySchedule(scheduleID, id); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s attendancePolicyService) AssignAttendancePolicyFaculty(facultyID int, id int) error {
+	if err := s.attendancePolicyRepo.AssignAttendancePolicyFaculty(facultyID, id); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s attendancePolicyService) CheckIsExist(id int) (isExist bool) {
+	return s.attendancePolicyRepo.CheckIsExist(id)
+}
@@ -9,7 +9,7 @@ type AttendanceService interface {
 	CreateAttendance(attendance model.Attendance) (model.Attendance, error)
 	RetrieveAttendance(id int) (model.Attendance, error)
 	RetrieveAttendanceByUserID(id int, userID int) (model.Attendance, error)
-	RetrieveAttendanceByDate(userID int, scheduleID int, date string) (model.Attendance, error)
+	RetrieveAttendanceByDate(userID int, scheduleID int, dailyScheduleID int, date string) (model.Attendance, error)
 	UpdateAttendance(id int, attendance model.Attendance) (model.Attendance, error)
 	UpdateAttendanceByUserID(id int, userID int, attendance model.Attendance) (model.Attendance, error)
 	UpdateStatusAttendance(id int, statusPresence string, userID int) (model.Attendance, error)
@@ -19,8 +19,17 @@ type AttendanceService interface {
 	ListAttendanceMeta(attendance model.Attendance, pagination model.Pagination) (model.Meta, error)
 	DropDownAttendance(attendance model.Attendance) ([]model.Attendance, error)
 	CheckIsExist(id int) (isExist bool, err error)
-	CheckIsExistByDate(userID int, scheduleID int, date string) bool
+	CheckIsExistByDate(userID int, scheduleID int, dailyScheduleID int, date string) bool
+	MigrateAttendanceDailySchedule() (total int64, err error)
+	MigrateAttendancePresenceScore() (total int64, err error)
+	RecomputeAttendancePolicy(scheduleID int, policy model.AttendancePolicy) (total int64, err error)
+	RecomputeOpenAttendancePolicy(scheduleID int, policy model.AttendancePolicy, startDate string) (total int64, err error)
+	ListOpenAttendanceScheduleID(startDate string) ([]int, error)
+	CountHeldMeeting(scheduleID int) (result int)
+	ListAttendanceEligibility(scheduleID int, rule model.EligibilityRule) ([]model.AttendanceEligibility, error)
 	CountAttendanceByStatus(userID int, statusAttendance string, startDate string, endDate string) (result int)
+	CountClockInByDate(scheduleID int, dailyScheduleID int, date string) (result int)
+	ExportAttendance(attendance model.Attendance, fn func(model.AttendanceExport) error) error
 }
 
 type attendanceService struct {
@@ -55,8 +64,8 @@ func (s attendanceService) RetrieveAttendanceByUserID(id int, userID int) (model
 	return data, nil
 }
 
-func (s attendanceService) RetrieveAttendanceByDate(userID int, scheduleID int, date string) (model.Attendance, error) {
-	data, err := s.attendanceRepo.RetrieveAttendanceByDate(userID, scheduleID, date)
+func (s attendanceService) RetrieveAttendanceByDate(userID int, scheduleID int, dailyScheduleID int, date string) (model.Attendance, error) {
+	data, err := s.attendanceRepo.RetrieveAttendanceByDate(userID, scheduleID, dailyScheduleID, date)
 	if err != nil {
 		return model.Attendance{}, err
 	}
@@ -131,10 +140,58 @@ func (s attendanceService) CheckIsExist(id int) (isExist bool, err error) {
 	return s.attendanceRepo.CheckIsExist(id)
 }
 
-func (s attendanceService) CheckIsExistByDate(userID int, scheduleID int, date string) bool {
-	return s.attendanceRepo.CheckIsExistByDate(userID, scheduleID, date)
+func (s attendanceService) CheckIsExistByDate(userID int, scheduleID int, dailyScheduleID int, date string) bool {
+	return s.attendanceRepo.CheckIsExistByDate(userID, scheduleID, dailyScheduleID, date)
+}
+
+func (s attendanceService) MigrateAttendanceDailySchedule() (total int64, err error) {
+	return s.attendanceRepo.MigrateAttendanceDailySchedule()
+}
+
+func (s attendanceService) MigrateAttendancePresenceScore() (total int64, err error) {
+	return s.attendanceRepo.MigrateAttendancePresenceScore()
+}
+
+func (s attendanceService) RecomputeAttendancePolicy(scheduleID int, policy model.AttendancePolicy) (total int64, err error) {
+	return s.attendanceRepo.RecomputeAttendancePolicy(scheduleID, policy)
+}
+
+func (s attendanceService) RecomputeOpenAttendancePolicy(scheduleID int, policy model.AttendancePolicy, startDate string) (total int64, err error) {
+	return s.attendanceRepo.RecomputeOpenAttendancePolicy(scheduleID, policy, startDate)
+}
+
+func (s attendanceService) ListOpenAttendanceScheduleID(startDate string) ([]int, error) {
+	datas, err := s.attendanceRepo.ListOpenAttendanceScheduleID(startDate)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
 }
 
 func (s attendanceService) CountAttendanceByStatus(userID int, statusAttendance string, startDate string, endDate string) (result int) {
 	return s.attendanceRepo.CountAttendanceByStatus(userID, statusAttendance, startDate, endDate)
 }
+
+func (s attendanceService) CountClockInByDate(scheduleID int, dailyScheduleID int, date string) (result int) {
+	return s.attendanceRepo.CountClockInByDate(scheduleID, dailyScheduleID, date)
+}
+
+func (s attendanceService) CountHeldMeeting(scheduleID int) (result int) {
+	return s.attendanceRepo.CountHeldMeeting(scheduleID)
+}
+
+func (s attendanceService) ListAttendanceEligibility(scheduleID int, rule model.EligibilityRule) ([]model.AttendanceEligibility, error) {
+	datas, err := s.attendanceRepo.ListAttendanceEligibility(scheduleID, rule)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s attendanceService) ExportAttendance(attendance model.Attendance, fn func(model.AttendanceExport) error) error {
+	if err := s.attendanceRepo.ExportAttendance(attendance, fn); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
@@ -19,7 +19,8 @@ type DailyScheduleService interface {
 	ListDailySchedule(dailyschedule model.DailySchedule, pagination model.Pagination) ([]model.DailySchedule, error)
 	ListDailyScheduleMeta(dailyschedule model.DailySchedule, pagination model.Pagination) (model.Meta, error)
 	DropDownDailySchedule(dailyschedule model.DailySchedule) ([]model.DailySchedule, error)
-	CheckHaveDailySchedule(scheduleID int, day string) (isHaveDailySchedule bool, dailyScheduleID int, err error)
+	CheckHaveDailySchedule(scheduleID int, date string) (isHaveDailySchedule bool, dailyScheduleID int, err error)
+	ListDailyScheduleByDate(scheduleID int, date string) ([]model.DailySchedule, error)
 }
 
 type dailyScheduleService struct {
@@ -134,6 +135,14 @@ func (s dailyScheduleService) DropDownDailySchedule(dailyschedule model.DailySch
 	return datas, nil
 }
 
-func (s dailyScheduleService) CheckHaveDailySchedule(scheduleID int, day string) (isHaveDailySchedule bool, dailyScheduleID int, err error) {
-	return s.dailyScheduleRepo.CheckHaveDailySchedule(scheduleID, day)
+func (s dailyScheduleService) CheckHaveDailySchedule(scheduleID int, date string) (isHaveDailySchedule bool, dailyScheduleID int, err error) {
+	return s.dailyScheduleRepo.CheckHaveDailySchedule(scheduleID, date)
+}
+
+func (s dailyScheduleService) ListDailyScheduleByDate(scheduleID int, date string) ([]model.DailySchedule, error) {
+	datas, err := s.dailyScheduleRepo.ListDailyScheduleByDate(scheduleID, date)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
 }
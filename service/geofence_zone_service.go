@@ -0,0 +1,78 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+)
+
+type GeofenceZoneService interface {
+	CreateGeofenceZone(zone model.GeofenceZone) (model.GeofenceZone, error)
+	UpdateGeofenceZoneByScheduleID(id int, scheduleID int, zone model.GeofenceZone) (model.GeofenceZone, error)
+	UpdateGeofenceZoneByRoomID(id int, roomID int, zone model.GeofenceZone) (model.GeofenceZone, error)
+	DeleteGeofenceZoneByScheduleIDAndExceptListID(scheduleID int, exceptID []int) error
+	ListGeofenceZoneByScheduleID(scheduleID int) ([]model.GeofenceZone, error)
+	DeleteGeofenceZoneByRoomIDAndExceptListID(roomID int, exceptID []int) error
+	ListGeofenceZoneByRoomID(roomID int) ([]model.GeofenceZone, error)
+}
+
+type geofenceZoneService struct {
+	geofenceZoneRepo repo.GeofenceZoneRepo
+}
+
+func NewGeofenceZoneService(geofenceZoneRepo repo.GeofenceZoneRepo) GeofenceZoneService {
+	return &geofenceZoneService{geofenceZoneRepo: geofenceZoneRepo}
+}
+
+func (s geofenceZoneService) CreateGeofenceZone(zone model.GeofenceZone) (model.GeofenceZone, error) {
+	data, err := s.geofenceZoneRepo.CreateGeofenceZone(zone)
+	if err != nil {
+		return model.GeofenceZone{}, err
+	}
+	return data, nil
+}
+
+func (s geofenceZoneService) UpdateGeofenceZoneByScheduleID(id int, scheduleID int, zone model.GeofenceZone) (model.GeofenceZone, error) {
+	data, err := s.geofenceZoneRepo.UpdateGeofenceZoneByScheduleID(id, scheduleID, zone)
+	if err != nil {
+		return model.GeofenceZone{}, err
+	}
+	return data, nil
+}
+
+func (s geofenceZoneService) UpdateGeofenceZoneByRoomID(id int, roomID int, zone model.GeofenceZone) (model.GeofenceZone, error) {
+	data, err := s.geofenceZoneRepo.UpdateGeofenceZoneByRoomID(id, roomID, zone)
+	if err != nil {
+		return model.GeofenceZone{}, err
+	}
+	return data, nil
+}
+
+func (s geofenceZoneService) DeleteGeofenceZoneByScheduleIDAndExceptListID(scheduleID int, exceptID []int) error {
+	if err := s.geofenceZoneRepo.DeleteGeofenceZoneByScheduleIDAndExceptListID(scheduleID, exceptID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s geofenceZoneService) ListGeofenceZoneByScheduleID(scheduleID int) ([]model.GeofenceZone, error) {
+	datas, err := s.geofenceZoneRepo.ListGeofenceZoneByScheduleID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s geofenceZoneService) DeleteGeofenceZoneByRoomIDAndExceptListID(roomID int, exceptID []int) error {
+	if err := s.geofenceZoneRepo.DeleteGeofenceZoneByRoomIDAndExceptListID(roomID, exceptID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s geofenceZoneService) ListGeofenceZoneByRoomID(roomID int) ([]model.GeofenceZone, error) {
+	datas, err := s.geofenceZoneRepo.ListGeofenceZoneByRoomID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
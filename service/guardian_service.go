@@ -0,0 +1,53 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+)
+
+type GuardianService interface {
+	ListStudentGuardian(studentID int) ([]model.Guardian, error)
+	SaveStudentGuardian(studentID int, guardians []model.Guardian) ([]model.Guardian, error)
+	OptOutGuardian(token string) error
+	ListGuardianDigest(since string, until string) ([]model.GuardianDigestRecord, error)
+}
+
+type guardianService struct {
+	guardianRepo repo.GuardianRepo
+}
+
+func NewGuardianService(guardianRepo repo.GuardianRepo) GuardianService {
+	return &guardianService{guardianRepo: guardianRepo}
+}
+
+func (s guardianService) ListStudentGuardian(studentID int) ([]model.Guardian, error) {
+	datas, err := s.guardianRepo.ListStudentGuardian(studentID)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s guardianService) SaveStudentGuardian(studentID int, guardians []model.Guardian) ([]model.Guardian, error) {
+	datas, err := s.guardianRepo.SaveStudentGuardian(studentID, guardians)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s guardianService) OptOutGuardian(token string) error {
+	if err := s.guardianRepo.OptOutGuardian(token); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s guardianService) ListGuardianDigest(since string, until string) ([]model.GuardianDigestRecord, error) {
+	datas, err := s.guardianRepo.ListGuardianDigest(since, until)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+)
+
+type LeaveRequestService interface {
+	CreateLeaveRequest(leaveRequest model.LeaveRequest) (model.LeaveRequest, error)
+	RetrieveLeaveRequest(id int) (model.LeaveRequest, error)
+	RetrieveLeaveRequestByUser(id int, userID int) (model.LeaveRequest, error)
+	RetrieveLeaveRequestByOwner(id int, ownerID int) (model.LeaveRequest, error)
+	CancelLeaveRequest(id int, userID int) (model.LeaveRequest, error)
+	DecideLeaveRequest(id int, ownerID int, actorID int, decision model.LeaveRequestDecision) (model.LeaveRequest, error)
+	ListLeaveRequest(leaveRequest model.LeaveRequest, pagination model.Pagination) ([]model.LeaveRequest, error)
+	ListLeaveRequestMeta(leaveRequest model.LeaveRequest, pagination model.Pagination) (model.Meta, error)
+	ListLeaveRequestLog(leaveRequestID int) ([]model.LeaveRequestLog, error)
+	ListLeaveScheduleCandidate(userID int, startDate string, endDate string) ([]model.Schedule, error)
+	RetrieveApprovedStatusPresence(userID int, scheduleID int, date string) (statusPresence string)
+}
+
+type leaveRequestService struct {
+	leaveRequestRepo repo.LeaveRequestRepo
+}
+
+func NewLeaveRequestService(leaveRequestRepo repo.LeaveRequestRepo) LeaveRequestService {
+	return &leaveRequestService{leaveRequestRepo: leaveRequestRepo}
+}
+
+func (s leaveRequestService) CreateLeaveRequest(leaveRequest model.LeaveRequest) (model.LeaveRequest, error) {
+	data, err := s.leaveRequestRepo.CreateLeaveRequest(leaveRequest)
+	if err != nil {
+		return model.LeaveRequest{}, err
+	}
+	return data, nil
+}
+
+func (s leaveRequestService) RetrieveLeaveRequest(id int) (model.LeaveRequest, error) {
+	data, err := s.leaveRequestRepo.RetrieveLeaveRequest(id)
+	if err != nil {
+		return model.LeaveRequest{}, err
+	}
+	return data, nil
+}
+
+func (s leaveRequestService) RetrieveLeaveRequestByUser(id int, userID int) (model.LeaveRequest, error) {
+	data, err := s.leaveRequestRepo.RetrieveLeaveRequestByUser(id, userID)
+	if err != nil {
+		return model.LeaveRequest{}, err
+	}
+	return data, nil
+}
+
+func (s leaveRequestService) RetrieveLeaveRequestByOwner(id int, ownerID int) (model.LeaveRequest, error) {
+	data, err := s.leaveRequestRepo.RetrieveLeaveRequestByOwner(id, ownerID)
+	if err != nil {
+		return model.LeaveRequest{}, err
+	}
+	return data, nil
+}
+
+func (s leaveRequestService) CancelLeaveRequest(id int, userID int) (model.LeaveRequest, error) {
+	data, err := s.leaveRequestRepo.CancelLeaveRequest(id, userID)
+	if err != nil {
+		return model.LeaveRequest{}, err
+	}
+	return data, nil
+}
+
+func (s leaveRequestService) DecideLeaveRequest(id int, ownerID int, actorID int, decision model.LeaveRequestDecision) (model.LeaveRequest, error) {
+	data, err := s.leaveRequestRepo.DecideLeaveRequest(id, ownerID, actorID, decision)
+	if err != nil {
+		return model.LeaveRequest{}, err
+	}
+	return data, nil
+}
+
+func (s leaveRequestService) ListLeaveRequest(leaveRequest model.LeaveRequest, pagination model.Pagination) ([]model.LeaveRequest, error) {
+	datas, err := s.leaveRequestRepo.ListLeaveRequest(leaveRequest, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s leaveRequestService) ListLeaveRequestMeta(leaveRequest model.LeaveRequest, pagination model.Pagination) (model.Meta, error) {
+	data, err := s.leaveRequestRepo.ListLeaveRequestMeta(leaveRequest, pagination)
+	if err != nil {
+		return model.Meta{}, err
+	}
+	return data, nil
+}
+
+func (s leaveRequestService) ListLeaveRequestLog(leaveRequestID int) ([]model.LeaveRequestLog, error) {
+	datas, err := s.leaveRequestRepo.ListLeaveRequestLog(leaveRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s leaveRequestService) ListLeaveScheduleCandidate(userID int, startDate string, endDate string) ([]model.Schedule, error) {
+	datas, err := s.leaveRequestRepo.ListLeaveScheduleCandidate(userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s leaveRequestService) RetrieveApprovedStatusPresence(userID int, scheduleID int, date string) (statusPresence string) {
+	return s.leaveRequestRepo.RetrieveApprovedStatusPresence(userID, scheduleID, date)
+}
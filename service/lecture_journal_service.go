@@ -0,0 +1,127 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+	"time"
+)
+
+type LectureJournalService interface {
+	CreateLectureJournal(lectureJournal model.LectureJournal) (model.LectureJournal, error)
+	RetrieveLectureJournal(id int) (model.LectureJournal, error)
+	RetrieveLectureJournalByOwner(id int, ownerID int) (model.LectureJournal, error)
+	UpdateLectureJournal(id int, lectureJournal model.LectureJournal) (model.LectureJournal, error)
+	UpdateLectureJournalByOwner(id int, ownerID int, lectureJournal model.LectureJournal) (model.LectureJournal, error)
+	UpdateLectureJournalCount(id int, count model.LectureJournalCount) error
+	UpdateLectureJournalStatus(id int, status string, submittedAt *time.Time) error
+	DeleteLectureJournal(id int) error
+	DeleteLectureJournalByOwner(id int, ownerID int) error
+	ListLectureJournal(lectureJournal model.LectureJournal, pagination model.Pagination) ([]model.LectureJournal, error)
+	ListLectureJournalMeta(lectureJournal model.LectureJournal, pagination model.Pagination) (model.Meta, error)
+	CheckIsExistByDate(scheduleID int, date string, exceptID int) (isExist bool)
+	CountLectureJournalAttendance(scheduleID int, date string) (count model.LectureJournalCount)
+}
+
+type lectureJournalService struct {
+	lectureJournalRepo repo.LectureJournalRepo
+}
+
+func NewLectureJournalService(lectureJournalRepo repo.LectureJournalRepo) LectureJournalService {
+	return &lectureJournalService{lectureJournalRepo: lectureJournalRepo}
+}
+
+func (s lectureJournalService) CreateLectureJournal(lectureJournal model.LectureJournal) (model.LectureJournal, error) {
+	data, err := s.lectureJournalRepo.CreateLectureJournal(lectureJournal)
+	if err != nil {
+		return model.LectureJournal{}, err
+	}
+	return data, nil
+}
+
+func (s lectureJournalService) RetrieveLectureJournal(id int) (model.LectureJournal, error) {
+	data, err := s.lectureJournalRepo.RetrieveLectureJournal(id)
+	if err != nil {
+		return model.LectureJournal{}, err
+	}
+	return data, nil
+}
+
+func (s lectureJournalService) RetrieveLectureJournalByOwner(id int, ownerID int) (model.LectureJournal, error) {
+	data, err := s.lectureJournalRepo.RetrieveLectureJournalByOwner(id, ownerID)
+	if err != nil {
+		return model.LectureJournal{}, err
+	}
+	return data, nil
+}
+
+func (s lectureJournalService) UpdateLectureJournal(id int, lectureJournal model.LectureJournal) (model.LectureJournal, error) {
+	data, err := s.lectureJournalRepo.UpdateLectureJournal(id, lectureJournal)
+	if err != nil {
+		return model.LectureJournal{}, err
+	}
+	return data, nil
+}
+
+func (s lectureJournalService) UpdateLectureJournalByOwner(id int, ownerID int, lectureJournal model.LectureJournal) (model.LectureJournal, error) {
+	data, err := s.lectureJournalRepo.UpdateLectureJournalByOwner(id, ownerID, lectureJournal)
+	if err != nil {
+		return model.LectureJournal{}, err
+	}
+	return data, nil
+}
+
+func (s lectureJournalService) UpdateLectureJournalCount(id int, count model.LectureJournalCount) error {
+	if err := s.lectureJournalRepo.UpdateLectureJournalCount(id, count); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s lectureJournalService) UpdateLectureJournalStatus(id int, status string, submittedAt *time.Time) error {
+	if err := s.lectureJournalRepo.UpdateLectureJournalStatus(id, status, submittedAt); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s lectureJournalService) DeleteLectureJournal(id int) error {
+	if err := s.lectureJournalRepo.DeleteLectureJournal(id); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s lectureJournalService) DeleteLectureJournalByOwner(id int, ownerID int) error {
+	if err := s.lectureJournalRepo.DeleteLectureJournalByOwner(id, ownerID); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s lectureJournalService) ListLectureJournal(lectureJournal model.LectureJournal, pagination model.Pagination) ([]model.LectureJournal, error) {
+	datas, err := s.lectureJournalRepo.ListLectureJournal(lectureJournal, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s lectureJournalService) ListLectureJournalMeta(lectureJournal model.LectureJournal, pagination model.Pagination) (model.Meta, error) {
+	data, err := s.lectureJournalRepo.ListLectureJournalMeta(lectureJournal, pagination)
+	if err != nil {
+		return model.Meta{}, err
+	}
+	return data, nil
+}
+
+func (s lectureJournalService) CheckIsExistByDate(scheduleID int, date string, exceptID int) (isExist bool) {
+	return s.lectureJournalRepo.CheckIsExistByDate(scheduleID, date, exceptID)
+}
+
+func (s lectureJournalService) CountLectureJournalAttendance(scheduleID int, date string) (count model.LectureJournalCount) {
+	return s.lectureJournalRepo.CountLectureJournalAttendance(scheduleID, date)
+}
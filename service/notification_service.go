@@ -0,0 +1,98 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+)
+
+type NotificationService interface {
+	CreateNotification(notification model.Notification) (model.Notification, error)
+	ListNotification(notification model.Notification, pagination model.Pagination) ([]model.Notification, error)
+	ListNotificationMeta(notification model.Notification, pagination model.Pagination) (model.Meta, error)
+	CountUnreadNotification(userID int) (int64, error)
+	ReadNotification(id int, userID int) (model.Notification, error)
+	ReadAllNotification(userID int) (int64, error)
+	DeleteNotification(id int, userID int) error
+	ListNotificationPreference(userID int) ([]model.NotificationPreference, error)
+	SaveNotificationPreference(userID int, preferences []model.NotificationPreference) ([]model.NotificationPreference, error)
+}
+
+type notificationService struct {
+	notificationRepo repo.NotificationRepo
+}
+
+func NewNotificationService(notificationRepo repo.NotificationRepo) NotificationService {
+	return &notificationService{notificationRepo: notificationRepo}
+}
+
+func (s notificationService) CreateNotification(notification model.Notification) (model.Notification, error) {
+	data, err := s.notificationRepo.CreateNotification(notification)
+	if err != nil {
+		return model.Notification{}, err
+	}
+	return data, nil
+}
+
+func (s notificationService) ListNotification(notification model.Notification, pagination model.Pagination) ([]model.Notification, error) {
+	datas, err := s.notificationRepo.ListNotification(notification, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s notificationService) ListNotificationMeta(notification model.Notification, pagination model.Pagination) (model.Meta, error) {
+	data, err := s.notificationRepo.ListNotificationMeta(notification, pagination)
+	if err != nil {
+		return model.Meta{}, err
+	}
+	return data, nil
+}
+
+func (s notificationService) CountUnreadNotification(userID int) (int64, error) {
+	data, err := s.notificationRepo.CountUnreadNotification(userID)
+	if err != nil {
+		return 0, err
+	}
+	return data, nil
+}
+
+func (s notificationService) ReadNotification(id int, userID int) (model.Notification, error) {
+	data, err := s.notificationRepo.ReadNotification(id, userID)
+	if err != nil {
+		return model.Notification{}, err
+	}
+	return data, nil
+}
+
+func (s notificationService) ReadAllNotification(userID int) (int64, error) {
+	data, err := s.notificationRepo.ReadAllNotification(userID)
+	if err != nil {
+		return 0, err
+	}
+	return data, nil
+}
+
+func (s notificationService) DeleteNotification(id int, userID int) error {
+	if err := s.notificationRepo.DeleteNotification(id, userID); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s notificationService) ListNotificationPreference(userID int) ([]model.NotificationPreference, error) {
+	datas, err := s.notificationRepo.ListNotificationPreference(userID)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s notificationService) SaveNotificationPreference(userID int, preferences []model.NotificationPreference) ([]model.NotificationPreference, error) {
+	datas, err := s.notificationRepo.SaveNotificationPreference(userID, preferences)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+)
+
+type RoomService interface {
+	CreateRoom(room model.Room) (model.Room, error)
+	RetrieveRoom(id int) (model.Room, error)
+	RetrieveRoomByOwner(id int, ownerID int) (model.Room, error)
+	UpdateRoom(id int, room model.Room) (model.Room, error)
+	UpdateRoomByOwner(id int, ownerID int, room model.Room) (model.Room, error)
+	DeleteRoom(id int) error
+	DeleteRoomByOwner(id int, ownerID int) error
+	ListRoom(room model.Room, pagination model.Pagination) ([]model.Room, error)
+	ListRoomMeta(room model.Room, pagination model.Pagination) (model.Meta, error)
+	DropDownRoom(room model.Room) ([]model.Room, error)
+	CheckIsExist(id int) (isExist bool)
+	CheckIsExistByName(name string, exceptID int) (isExist bool)
+	CheckIsExistByCode(code string, exceptID int) (isExist bool)
+	CheckIsUsed(id int) (isUsed bool)
+	MigrateRoomFromSchedule() (total int, err error)
+}
+
+type roomService struct {
+	roomRepo repo.RoomRepo
+}
+
+func NewRoomService(roomRepo repo.RoomRepo) RoomService {
+	return &roomService{roomRepo: roomRepo}
+}
+
+func (s roomService) CreateRoom(room model.Room) (model.Room, error) {
+	data, err := s.roomRepo.CreateRoom(room)
+	if err != nil {
+		return model.Room{}, err
+	}
+	return data, nil
+}
+
+func (s roomService) RetrieveRoom(id int) (model.Room, error) {
+	data, err := s.roomRepo.RetrieveRoom(id)
+	if err != nil {
+		return model.Room{}, err
+	}
+	return data, nil
+}
+
+func (s roomService) RetrieveRoomByOwner(id int, ownerID int) (model.Room, error) {
+	data, err := s.roomRepo.RetrieveRoomByOwner(id, ownerID)
+	if err != nil {
+		return model.Room{}, err
+	}
+	return data, nil
+}
+
+func (s roomService) UpdateRoom(id int, room model.Room) (model.Room, error) {
+	data, err := s.roomRepo.UpdateRoom(id, room)
+	if err != nil {
+		return model.Room{}, err
+	}
+	return data, nil
+}
+
+func (s roomService) UpdateRoomByOwner(id int, ownerID int, room model.Room) (model.Room, error) {
+	data, err := s.roomRepo.UpdateRoomByOwner(id, ownerID, room)
+	if err != nil {
+		return model.Room{}, err
+	}
+	return data, nil
+}
+
+func (s roomService) DeleteRoom(id int) error {
+	if err := s.roomRepo.DeleteRoom(id); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s roomService) DeleteRoomByOwner(id int, ownerID int) error {
+	if err := s.roomRepo.DeleteRoomByOwner(id, ownerID); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s roomService) ListRoom(room model.Room, pagination model.Pagination) ([]model.Room, error) {
+	datas, err := s.roomRepo.ListRoom(room, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s roomService) ListRoomMeta(room model.Room, pagination model.Pagination) (model.Meta, error) {
+	data, err := s.roomRepo.ListRoomMeta(room, pagination)
+	if err != nil {
+		return model.Meta{}, err
+	}
+	return data, nil
+}
+
+func (s roomService) DropDownRoom(room model.Room) ([]model.Room, error) {
+	datas, err := s.roomRepo.DropDownRoom(room)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s roomService) CheckIsExist(id int) (isExist bool) {
+	return s.roomRepo.CheckIsExist(id)
+}
+
+func (s roomService) CheckIsExistByName(name string, exceptID int) (isExist bool) {
+	return s.roomRepo.CheckIsExistByName(name, exceptID)
+}
+
+func (s roomService) CheckIsExistByCode(code string, exceptID int) (isExist bool) {
+	return s.roomRepo.CheckIsExistByCode(code, exceptID)
+}
+
+func (s roomService) CheckIsUsed(id int) (isUsed bool) {
+	return s.roomRepo.CheckIsUsed(id)
+}
+
+func (s roomService) MigrateRoomFromSchedule() (total int, err error) {
+	return s.roomRepo.MigrateRoomFromSchedule()
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+)
+
+type ScheduleExceptionService interface {
+	CreateScheduleException(scheduleException model.ScheduleException) (model.ScheduleException, error)
+	RetrieveScheduleException(id int) (model.ScheduleException, error)
+	RetrieveScheduleExceptionByOwner(id int, ownerID int) (model.ScheduleException, error)
+	ListMeetingException(scheduleID int, date string) ([]model.ScheduleException, error)
+	UpdateScheduleException(id int, scheduleException model.ScheduleException) (model.ScheduleException, error)
+	UpdateScheduleExceptionByOwner(id int, ownerID int, scheduleException model.ScheduleException) (model.ScheduleException, error)
+	DeleteScheduleException(id int) error
+	DeleteScheduleExceptionByOwner(id int, ownerID int) error
+	DeleteScheduleExceptionByScheduleID(scheduleID int) error
+	ListScheduleException(scheduleException model.ScheduleException, pagination model.Pagination) ([]model.ScheduleException, error)
+	ListScheduleExceptionMeta(scheduleException model.ScheduleException, pagination model.Pagination) (model.Meta, error)
+	CheckIsExistByDate(scheduleID int, date string, dailyScheduleID int, exceptID int) (isExist bool)
+	CheckIsExistByNewDate(scheduleID int, newDate string, exceptID int) (isExist bool)
+}
+
+type scheduleExceptionService struct {
+	scheduleExceptionRepo repo.ScheduleExceptionRepo
+}
+
+func NewScheduleExceptionService(scheduleExceptionRepo repo.ScheduleExceptionRepo) ScheduleExceptionService {
+	return &scheduleExceptionService{scheduleExceptionRepo: scheduleExceptionRepo}
+}
+
+func (s scheduleExceptionService) CreateScheduleException(scheduleException model.ScheduleException) (model.ScheduleException, error) {
+	data, err := s.scheduleExceptionRepo.CreateScheduleException(scheduleException)
+	if err != nil {
+		return model.ScheduleException{}, err
+	}
+	return data, nil
+}
+
+func (s scheduleExceptionService) RetrieveScheduleException(id int) (model.ScheduleException, error) {
+	data, err := s.scheduleExceptionRepo.RetrieveScheduleException(id)
+	if err != nil {
+		return model.ScheduleException{}, err
+	}
+	return data, nil
+}
+
+func (s scheduleExceptionService) RetrieveScheduleExceptionByOwner(id int, ownerID int) (model.ScheduleException, error) {
+	data, err := s.scheduleExceptionRepo.RetrieveScheduleExceptionByOwner(id, ownerID)
+	if err != nil {
+		return model.ScheduleException{}, err
+	}
+	return data, nil
+}
+
+func (s scheduleExceptionService) ListMeetingException(scheduleID int, date string) ([]model.ScheduleException, error) {
+	datas, err := s.scheduleExceptionRepo.ListMeetingException(scheduleID, date)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s scheduleExceptionService) UpdateScheduleException(id int, scheduleException model.ScheduleException) (model.ScheduleException, error) {
+	data, err := s.scheduleExceptionRepo.UpdateScheduleException(id, scheduleException)
+	if err != nil {
+		return model.ScheduleException{}, err
+	}
+	return data, nil
+}
+
+func (s scheduleExceptionService) UpdateScheduleExceptionByOwner(id int, ownerID int, scheduleException model.ScheduleException) (model.ScheduleException, error) {
+	data, err := s.scheduleExceptionRepo.UpdateScheduleExceptionByOwner(id, ownerID, scheduleException)
+	if err != nil {
+		return model.ScheduleException{}, err
+	}
+	return data, nil
+}
+
+func (s scheduleExceptionService) DeleteScheduleException(id int) error {
+	if err := s.scheduleExceptionRepo.DeleteScheduleException(id); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s scheduleExceptionService) DeleteScheduleExceptionByOwner(id int, ownerID int) error {
+	if err := s.scheduleExceptionRepo.DeleteScheduleExceptionByOwner(id, ownerID); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s scheduleExceptionService) DeleteScheduleExceptionByScheduleID(scheduleID int) error {
+	if err := s.scheduleExceptionRepo.DeleteScheduleExceptionByScheduleID(scheduleID); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s scheduleExceptionService) ListScheduleException(scheduleException model.ScheduleException, pagination model.Pagination) ([]model.ScheduleException, error) {
+	datas, err := s.scheduleExceptionRepo.ListScheduleException(scheduleException, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s scheduleExceptionService) ListScheduleExceptionMeta(scheduleException model.ScheduleException, pagination model.Pagination) (model.Meta, error) {
+	data, err := s.scheduleExceptionRepo.ListScheduleExceptionMeta(scheduleException, pagination)
+	if err != nil {
+		return model.Meta{}, err
+	}
+	return data, nil
+}
+
+func (s scheduleExceptionService) CheckIsExistByDate(scheduleID int, date string, dailyScheduleID int, exceptID int) (isExist bool) {
+	return s.scheduleExceptionRepo.CheckIsExistByDate(scheduleID, date, dailyScheduleID, exceptID)
+}
+
+func (s scheduleExceptionService) CheckIsExistByNewDate(scheduleID int, newDate string, exceptID int) (isExist bool) {
+	return s.scheduleExceptionRepo.CheckIsExistByNewDate(scheduleID, newDate, exceptID)
+}
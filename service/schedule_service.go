@@ -12,8 +12,8 @@ type ScheduleService interface {
 	RetrieveScheduleByQRcode(QRcode string) (model.Schedule, error)
 	UpdateSchedule(id int, schedule model.Schedule) (model.Schedule, error)
 	UpdateScheduleByOwner(id int, ownerID int, schedule model.Schedule) (model.Schedule, error)
-	UpdateQRcode(id int, QRcode string) (model.Schedule, error)
-	UpdateQRcodeByOwner(id int, ownerID int, QRcode string) (model.Schedule, error)
+	UpdateQRcode(id int, QRcode string, QRSecret string) (model.Schedule, error)
+	UpdateQRcodeByOwner(id int, ownerID int, QRcode string, QRSecret string) (model.Schedule, error)
 	DeleteSchedule(id int) error
 	DeleteScheduleByOwner(id int, ownerID int) error
 	ListSchedule(schedule model.Schedule, pagination model.Pagination) ([]model.Schedule, error)
@@ -21,6 +21,10 @@ type ScheduleService interface {
 	DropDownSchedule(schedule model.Schedule) ([]model.Schedule, error)
 	CheckIsExist(id int) (isExist bool, err error)
 	CheckCodeIsExist(code string, exceptID int) bool
+	ListTeacherConflict(ownerID int, exceptScheduleID int, slot model.ScheduleSlot) ([]model.ScheduleConflict, error)
+	ListRoomConflict(roomID int, exceptScheduleID int, slot model.ScheduleSlot) ([]model.ScheduleConflict, error)
+	ListStudentConflict(userID int, exceptScheduleID int, slot model.ScheduleSlot) ([]model.ScheduleConflict, error)
+	ListEnrolledStudentConflict(scheduleID int, slot model.ScheduleSlot) ([]model.ScheduleConflict, error)
 }
 
 type scheduleService struct {
@@ -79,16 +83,16 @@ func (s scheduleService) UpdateScheduleByOwner(id int, ownerID int, schedule mod
 	return data, nil
 }
 
-func (s scheduleService) UpdateQRcode(id int, QRcode string) (schedule model.Schedule, err error) {
-	data, err := s.scheduleRepo.UpdateQRcode(id, QRcode)
+func (s scheduleService) UpdateQRcode(id int, QRcode string, QRSecret string) (schedule model.Schedule, err error) {
+	data, err := s.scheduleRepo.UpdateQRcode(id, QRcode, QRSecret)
 	if err != nil {
 		return model.Schedule{}, err
 	}
 	return data, nil
 }
 
-func (s scheduleService) UpdateQRcodeByOwner(id int, ownerID int, QRcode string) (schedule model.Schedule, err error) {
-	data, err := s.scheduleRepo.UpdateQRcodeByOwner(id, ownerID, QRcode)
+func (s scheduleService) UpdateQRcodeByOwner(id int, ownerID int, QRcode string, QRSecret string) (schedule model.Schedule, err error) {
+	data, err := s.scheduleRepo.UpdateQRcodeByOwner(id, ownerID, QRcode, QRSecret)
 	if err != nil {
 		return model.Schedule{}, err
 	}
@@ -142,3 +146,35 @@ func (s scheduleService) CheckIsExist(id int) (isExist bool, err error) {
 func (s scheduleService) CheckCodeIsExist(code string, exceptID int) bool {
 	return s.scheduleRepo.CheckCodeIsExist(code, exceptID)
 }
+
+func (s scheduleService) ListTeacherConflict(ownerID int, exceptScheduleID int, slot model.ScheduleSlot) ([]model.ScheduleConflict, error) {
+	datas, err := s.scheduleRepo.ListTeacherConflict(ownerID, exceptScheduleID, slot)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s scheduleService) ListRoomConflict(roomID int, exceptScheduleID int, slot model.ScheduleSlot) ([]model.ScheduleConflict, error) {
+	datas, err := s.scheduleRepo.ListRoomConflict(roomID, exceptScheduleID, slot)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s scheduleService) ListStudentConflict(userID int, exceptScheduleID int, slot model.ScheduleSlot) ([]model.ScheduleConflict, error) {
+	datas, err := s.scheduleRepo.ListStudentConflict(userID, exceptScheduleID, slot)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s scheduleService) ListEnrolledStudentConflict(scheduleID int, slot model.ScheduleSlot) ([]model.ScheduleConflict, error) {
+	datas, err := s.scheduleRepo.ListEnrolledStudentConflict(scheduleID, slot)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
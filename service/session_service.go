@@ -0,0 +1,116 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+)
+
+type SessionService interface {
+	RetrieveSession(id int) (model.Session, error)
+	RetrieveSessionByOwner(id int, ownerID int) (model.Session, error)
+	RetrieveSessionByDate(scheduleID int, date string) (model.Session, error)
+	RetrieveSessionByDailySchedule(scheduleID int, dailyScheduleID int, date string) (model.Session, error)
+	UpdateSession(id int, session model.Session) (model.Session, error)
+	UpdateSessionByOwner(id int, ownerID int, session model.Session) (model.Session, error)
+	UpdateSessionStatus(id int, status string) error
+	DeleteSessionByScheduleID(scheduleID int) error
+	ListSession(session model.Session, pagination model.Pagination) ([]model.Session, error)
+	ListSessionMeta(session model.Session, pagination model.Pagination) (model.Meta, error)
+	GenerateSession(scheduleID int) (model.SessionGenerateResult, error)
+}
+
+type sessionService struct {
+	sessionRepo repo.SessionRepo
+}
+
+func NewSessionService(sessionRepo repo.SessionRepo) SessionService {
+	return &sessionService{sessionRepo: sessionRepo}
+}
+
+func (s sessionService) RetrieveSession(id int) (model.Session, error) {
+	data, err := s.sessionRepo.RetrieveSession(id)
+	if err != nil {
+		return model.Session{}, err
+	}
+	return data, nil
+}
+
+func (s sessionService) RetrieveSessionByOwner(id int, ownerID int) (model.Session, error) {
+	data, err := s.sessionRepo.RetrieveSessionByOwner(id, ownerID)
+	if err != nil {
+		return model.Session{}, err
+	}
+	return data, nil
+}
+
+func (s sessionService) RetrieveSessionByDate(scheduleID int, date string) (model.Session, error) {
+	data, err := s.sessionRepo.RetrieveSessionByDate(scheduleID, date)
+	if err != nil {
+		return model.Session{}, err
+	}
+	return data, nil
+}
+
+func (s sessionService) RetrieveSessionByDailySchedule(scheduleID int, dailyScheduleID int, date string) (model.Session, error) {
+	data, err := s.sessionRepo.RetrieveSessionByDailySchedule(scheduleID, dailyScheduleID, date)
+	if err != nil {
+		return model.Session{}, err
+	}
+	return data, nil
+}
+
+func (s sessionService) UpdateSession(id int, session model.Session) (model.Session, error) {
+	data, err := s.sessionRepo.UpdateSession(id, session)
+	if err != nil {
+		return model.Session{}, err
+	}
+	return data, nil
+}
+
+func (s sessionService) UpdateSessionByOwner(id int, ownerID int, session model.Session) (model.Session, error) {
+	data, err := s.sessionRepo.UpdateSessionByOwner(id, ownerID, session)
+	if err != nil {
+		return model.Session{}, err
+	}
+	return data, nil
+}
+
+func (s sessionService) UpdateSessionStatus(id int, status string) error {
+	if err := s.sessionRepo.UpdateSessionStatus(id, status); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s sessionService) DeleteSessionByScheduleID(scheduleID int) error {
+	if err := s.sessionRepo.DeleteSessionByScheduleID(scheduleID); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s sessionService) ListSession(session model.Session, pagination model.Pagination) ([]model.Session, error) {
+	datas, err := s.sessionRepo.ListSession(session, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s sessionService) ListSessionMeta(session model.Session, pagination model.Pagination) (model.Meta, error) {
+	data, err := s.sessionRepo.ListSessionMeta(session, pagination)
+	if err != nil {
+		return model.Meta{}, err
+	}
+	return data, nil
+}
+
+func (s sessionService) GenerateSession(scheduleID int) (model.SessionGenerateResult, error) {
+	data, err := s.sessionRepo.GenerateSession(scheduleID)
+	if err != nil {
+		return model.SessionGenerateResult{}, err
+	}
+	return data, nil
+}
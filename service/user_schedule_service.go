@@ -29,6 +29,7 @@ type UserScheduleService interface {
 	CheckHaveSchedule(userID int, date time.Time) (isHaveSchedule bool, scheduleID int, err error)
 	CheckUserInSchedule(scheduleID int, userID int) bool
 	CountByScheduleID(scheduleID int) (total int)
+	ListUserBySchedule(scheduleID int) ([]model.User, error)
 	GetAll() (results []model.UserSchedule, err error)
 	GetAllByTodayRange() (results []model.UserSchedule, err error)
 }
@@ -197,6 +198,14 @@ func (s userScheduleService) CountByScheduleID(scheduleID int) (total int) {
 	return s.userScheduleRepo.CountByScheduleID(scheduleID)
 }
 
+func (s userScheduleService) ListUserBySchedule(scheduleID int) ([]model.User, error) {
+	datas, err := s.userScheduleRepo.ListUserBySchedule(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
 func (s userScheduleService) GetAll() (resutls []model.UserSchedule, err error) {
 	return s.userScheduleRepo.GetAll()
 }
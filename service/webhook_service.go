@@ -0,0 +1,149 @@
+package service
+
+import (
+	"attendance-api/model"
+	"attendance-api/repo"
+	"time"
+)
+
+type WebhookService interface {
+	CreateWebhook(webhook model.Webhook) (model.Webhook, error)
+	RetrieveWebhook(id int) (model.Webhook, error)
+	UpdateWebhook(id int, webhook model.Webhook) (model.Webhook, error)
+	DeleteWebhook(id int) error
+	ListWebhook(webhook model.Webhook, pagination model.Pagination) ([]model.Webhook, error)
+	ListWebhookMeta(webhook model.Webhook, pagination model.Pagination) (model.Meta, error)
+	ListActiveWebhookByEvent(event string) ([]model.Webhook, error)
+	RecordWebhookSuccess(id int) error
+	RecordWebhookFailure(id int, disableAfter int) (isDisabled bool, err error)
+	CreateWebhookDelivery(delivery model.WebhookDelivery) (model.WebhookDelivery, error)
+	RetrieveWebhookDelivery(id int) (model.WebhookDelivery, error)
+	UpdateWebhookDeliveryResult(id int, delivery model.WebhookDelivery) error
+	ListWebhookDelivery(delivery model.WebhookDelivery, pagination model.Pagination) ([]model.WebhookDelivery, error)
+	ListWebhookDeliveryMeta(delivery model.WebhookDelivery, pagination model.Pagination) (model.Meta, error)
+	ListStaleWebhookDelivery(before time.Time) ([]model.WebhookDelivery, error)
+}
+
+type webhookService struct {
+	webhookRepo repo.WebhookRepo
+}
+
+func NewWebhookService(webhookRepo repo.WebhookRepo) WebhookService {
+	return &webhookService{webhookRepo: webhookRepo}
+}
+
+func (s webhookService) CreateWebhook(webhook model.Webhook) (model.Webhook, error) {
+	data, err := s.webhookRepo.CreateWebhook(webhook)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+	return data, nil
+}
+
+func (s webhookService) RetrieveWebhook(id int) (model.Webhook, error) {
+	data, err := s.webhookRepo.RetrieveWebhook(id)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+	return data, nil
+}
+
+func (s webhookService) UpdateWebhook(id int, webhook model.Webhook) (model.Webhook, error) {
+	data, err := s.webhookRepo.UpdateWebhook(id, webhook)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+	return data, nil
+}
+
+func (s webhookService) DeleteWebhook(id int) error {
+	if err := s.webhookRepo.DeleteWebhook(id); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s webhookService) ListWebhook(webhook model.Webhook, pagination model.Pagination) ([]model.Webhook, error) {
+	datas, err := s.webhookRepo.ListWebhook(webhook, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s webhookService) ListWebhookMeta(webhook model.Webhook, pagination model.Pagination) (model.Meta, error) {
+	data, err := s.webhookRepo.ListWebhookMeta(webhook, pagination)
+	if err != nil {
+		return model.Meta{}, err
+	}
+	return data, nil
+}
+
+func (s webhookService) ListActiveWebhookByEvent(event string) ([]model.Webhook, error) {
+	datas, err := s.webhookRepo.ListActiveWebhookByEvent(event)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s webhookService) RecordWebhookSuccess(id int) error {
+	if err := s.webhookRepo.RecordWebhookSuccess(id); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s webhookService) RecordWebhookFailure(id int, disableAfter int) (isDisabled bool, err error) {
+	return s.webhookRepo.RecordWebhookFailure(id, disableAfter)
+}
+
+func (s webhookService) CreateWebhookDelivery(delivery model.WebhookDelivery) (model.WebhookDelivery, error) {
+	data, err := s.webhookRepo.CreateWebhookDelivery(delivery)
+	if err != nil {
+		return model.WebhookDelivery{}, err
+	}
+	return data, nil
+}
+
+func (s webhookService) RetrieveWebhookDelivery(id int) (model.WebhookDelivery, error) {
+	data, err := s.webhookRepo.RetrieveWebhookDelivery(id)
+	if err != nil {
+		return model.WebhookDelivery{}, err
+	}
+	return data, nil
+}
+
+func (s webhookService) UpdateWebhookDeliveryResult(id int, delivery model.WebhookDelivery) error {
+	if err := s.webhookRepo.UpdateWebhookDeliveryResult(id, delivery); err != nil {
+		return err
+	} else {
+		return nil
+	}
+}
+
+func (s webhookService) ListWebhookDelivery(delivery model.WebhookDelivery, pagination model.Pagination) ([]model.WebhookDelivery, error) {
+	datas, err := s.webhookRepo.ListWebhookDelivery(delivery, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}
+
+func (s webhookService) ListWebhookDeliveryMeta(delivery model.WebhookDelivery, pagination model.Pagination) (model.Meta, error) {
+	data, err := s.webhookRepo.ListWebhookDeliveryMeta(delivery, pagination)
+	if err != nil {
+		return model.Meta{}, err
+	}
+	return data, nil
+}
+
+func (s webhookService) ListStaleWebhookDelivery(before time.Time) ([]model.WebhookDelivery, error) {
+	datas, err := s.webhookRepo.ListStaleWebhookDelivery(before)
+	if err != nil {
+		return nil, err
+	}
+	return datas, nil
+}